@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/cache"
+	"github.com/sarrooo/go-clean/internal/database"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/repositories"
+	"github.com/sarrooo/go-clean/internal/services"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// command is a CLI subcommand
+// A command either runs or dispatches to its own subcommands
+type command struct {
+	usage       string
+	run         func(logger *zap.Logger, args []string) error
+	subcommands map[string]command
+}
+
+var rootCommands = map[string]command{
+	"serve": {
+		usage: "Start the HTTP server",
+		run:   serveCommand,
+	},
+	"migrate": {
+		usage:       "Manage database migrations",
+		subcommands: migrateCommands,
+	},
+	"seed": {
+		usage: "Seed the database with fixtures (--set, --dir)",
+		run:   seedCommand,
+	},
+	"db": {
+		usage:       "Manage the database",
+		subcommands: dbCommands,
+	},
+	"user": {
+		usage:       "Manage users",
+		subcommands: userCommands,
+	},
+	"token": {
+		usage:       "Manage access tokens",
+		subcommands: tokenCommands,
+	},
+	"openapi": {
+		usage: "Write the OpenAPI document of the routes (--out, stdout by default)",
+		run:   openAPICommand,
+	},
+}
+
+// runCommand finds the command named by the first argument and runs it with the others
+func runCommand(logger *zap.Logger, commands map[string]command, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(commands)
+		return nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printUsage(commands)
+		return fmt.Errorf("%w: unknown command %s", errcode.ErrInvalidParameters, args[0])
+	}
+	if cmd.subcommands != nil {
+		return runCommand(logger, cmd.subcommands, args[1:])
+	}
+	return cmd.run(logger, args[1:])
+}
+
+func printUsage(commands map[string]command) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
+
+// app holds the dependencies shared by commands
+type app struct {
+	db               *gorm.DB
+	globalRepository *repositories.GlobalRepository
+	service          *services.Service
+}
+
+// newApp opens the database without migrating it, and initializes repositories and services
+func newApp(logger *zap.Logger) (*app, error) {
+	gormClient, err := database.Open(logger)
+	if err != nil {
+		return nil, err
+	}
+	return newAppFromDB(logger, gormClient), nil
+}
+
+func newAppFromDB(logger *zap.Logger, gormClient *gorm.DB) *app {
+	// Initialize repositories, catalog repositories are cached unless CACHE_SIZE is 0
+	viper.SetDefault("CACHE_SIZE", 1000)
+	viper.SetDefault("CACHE_TTL", 5*time.Minute)
+	globalRepository := repositories.NewGlobalRepository(gormClient)
+	if cacheSize := viper.GetInt("CACHE_SIZE"); cacheSize > 0 {
+		globalRepository = repositories.NewCachedGlobalRepository(gormClient, cache.NewLRU(cacheSize), viper.GetDuration("CACHE_TTL"))
+	}
+
+	// Initialize services
+	service := services.New(logger, globalRepository)
+
+	return &app{
+		db:               gormClient,
+		globalRepository: globalRepository,
+		service:          service,
+	}
+}
+
+// errMissingFlag is returned when a required flag is not set
+func errMissingFlag(name string) error {
+	return fmt.Errorf("%w: missing flag --%s", errcode.ErrInvalidParameters, name)
+}
@@ -4,11 +4,7 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/sarrooo/go-clean/internal/controllers"
-	"github.com/sarrooo/go-clean/internal/database"
 	"github.com/sarrooo/go-clean/internal/logger"
-	"github.com/sarrooo/go-clean/internal/repositories"
-	"github.com/sarrooo/go-clean/internal/services"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
@@ -28,23 +24,14 @@ func main() {
 		}
 	}(logger)
 
-	// Initialize database
-	gormClient, err := database.NewGormClient()
-	if err != nil {
-		logger.Fatal("Error initializing database", zap.Error(err))
+	// Run the command, serve by default
+	args := os.Args[1:]
+	if len(args) == 0 {
+		args = []string{"serve"}
 	}
-
-	// Initialize repositories
-	globalRepository := repositories.NewGlobalRepository(gormClient)
-
-	// Initialize services
-	service := services.New(logger, globalRepository)
-
-	// Initialize handlers
-	routing := controllers.NewRouter(logger, service)
-	err = routing.Run(":" + viper.GetString("PORT"))
+	err = runCommand(logger, rootCommands, args)
 	if err != nil {
-		logger.Fatal("Error running router", zap.Error(err))
+		logger.Fatal("Error running command", zap.Strings("args", args), zap.Error(err))
 	}
 }
 
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/sarrooo/go-clean/internal/database"
+	"go.uber.org/zap"
+)
+
+var migrateCommands = map[string]command{
+	"up": {
+		usage: "Apply all pending migrations",
+		run:   migrateUpCommand,
+	},
+	"down": {
+		usage: "Revert the last migrations (--steps, default 1)",
+		run:   migrateDownCommand,
+	},
+	"status": {
+		usage: "List migrations and if they were applied",
+		run:   migrateStatusCommand,
+	},
+}
+
+func migrateUpCommand(logger *zap.Logger, args []string) error {
+	migrator, err := newMigrator(logger)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(context.Background())
+	for _, migration := range applied {
+		logger.Info("Migration applied", zap.Uint("version", migration.Version), zap.String("name", migration.Name))
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("Database is up to date", zap.Int("applied", len(applied)))
+	return nil
+}
+
+func migrateDownCommand(logger *zap.Logger, args []string) error {
+	flags := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+	steps := flags.Int("steps", 1, "number of migrations to revert")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	migrator, err := newMigrator(logger)
+	if err != nil {
+		return err
+	}
+	reverted, err := migrator.Down(context.Background(), *steps)
+	for _, migration := range reverted {
+		logger.Info("Migration reverted", zap.Uint("version", migration.Version), zap.String("name", migration.Name))
+	}
+	return err
+}
+
+func migrateStatusCommand(logger *zap.Logger, args []string) error {
+	migrator, err := newMigrator(logger)
+	if err != nil {
+		return err
+	}
+	statuses, err := migrator.Status(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.Applied {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%04d  %-40s %s\n", status.Version, status.Name, appliedAt)
+	}
+	return nil
+}
+
+func newMigrator(logger *zap.Logger) (*database.Migrator, error) {
+	app, err := newApp(logger)
+	if err != nil {
+		return nil, err
+	}
+	return database.NewMigrator(app.db)
+}
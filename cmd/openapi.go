@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/controllers"
+	"go.uber.org/zap"
+)
+
+// openAPICommand writes the OpenAPI document of the routes, the database is not opened
+func openAPICommand(logger *zap.Logger, args []string) error {
+	flags := flag.NewFlagSet("openapi", flag.ContinueOnError)
+	out := flags.String("out", "", "file of the document, stdout by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// The routes are only registered, the debug logs of gin would be written with the document
+	gin.SetMode(gin.ReleaseMode)
+	document, err := controllers.NewRouter(logger, nil, nil).OpenAPIJSON()
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(document)
+		return err
+	}
+	if err := os.WriteFile(*out, document, 0o644); err != nil {
+		return err
+	}
+	logger.Info("OpenAPI document written", zap.String("out", *out))
+	return nil
+}
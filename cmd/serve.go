@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/controllers"
+	"github.com/sarrooo/go-clean/internal/database"
+	"github.com/sarrooo/go-clean/internal/services"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// serveCommand starts the HTTP server
+// Pending migrations are handled according to MIGRATE_MODE
+// On SIGINT or SIGTERM, the server stops accepting requests, stops the periodic tasks, and waits for the requests,
+// the periodic tasks and the background jobs in progress, at most SHUTDOWN_TIMEOUT
+func serveCommand(logger *zap.Logger, args []string) error {
+	viper.SetDefault("SHUTDOWN_TIMEOUT", 30*time.Second)
+
+	// Initialize database
+	gormClient, err := database.NewGormClient(logger)
+	if err != nil {
+		return err
+	}
+	app := newAppFromDB(logger, gormClient)
+
+	// The import jobs of the previous run which didn't end will never end
+	if failed, err := app.service.FailInterruptedImportJobs(context.Background()); err != nil {
+		logger.Error("interrupted import jobs update failed", zap.Error(err))
+	} else if failed > 0 {
+		logger.Warn("interrupted import jobs failed", zap.Int64("failed", failed))
+	}
+
+	stopCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The periodic tasks run until the server stops, they are waited for before the service is closed
+	tasksCtx, stopTasks := context.WithCancel(stopCtx)
+	defer stopTasks()
+	var tasks sync.WaitGroup
+	runTask := func(task func(ctx context.Context)) {
+		tasks.Add(1)
+		go func() {
+			defer tasks.Done()
+			task(tasksCtx)
+		}()
+	}
+
+	// Check the database health in background, for the readiness probe
+	databaseHealth := database.NewHealthChecker(gormClient, logger)
+	runTask(databaseHealth.Run)
+
+	// Purge the trash periodically
+	runTask(func(ctx context.Context) { purgeTrashPeriodically(ctx, logger, app.service) })
+
+	// Purge the audit logs periodically
+	runTask(func(ctx context.Context) { purgeAuditPeriodically(ctx, logger, app.service) })
+
+	// Purge the expired idempotency keys periodically
+	runTask(func(ctx context.Context) { purgeIdempotencyKeysPeriodically(ctx, logger, app.service) })
+
+	// Initialize handlers
+	routing := controllers.NewRouter(logger, app.service, map[string]controllers.ReadinessProbe{
+		"database": databaseHealth,
+	})
+	server := &http.Server{Addr: ":" + viper.GetString("PORT"), Handler: routing.Handler()}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-served:
+		stopTasks()
+		tasks.Wait()
+		return err
+	case <-stopCtx.Done():
+	}
+
+	logger.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("SHUTDOWN_TIMEOUT"))
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	stopTasks()
+	if err := waitTasks(shutdownCtx, &tasks); err != nil {
+		return err
+	}
+	return app.service.Close(shutdownCtx)
+}
+
+// waitTasks waits for the periodic tasks, at most until the context is done
+func waitTasks(ctx context.Context, tasks *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		tasks.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("periodic tasks still running: %w", ctx.Err())
+	}
+}
+
+// purgeTrashPeriodically permanently deletes, every TRASH_PURGE_INTERVAL, the entities
+// soft deleted for more than TRASH_RETENTION
+// A TRASH_RETENTION of 0 keeps deleted entities forever
+func purgeTrashPeriodically(ctx context.Context, logger *zap.Logger, svc services.ServiceInterface) {
+	viper.SetDefault("TRASH_RETENTION", 30*24*time.Hour)
+	viper.SetDefault("TRASH_PURGE_INTERVAL", time.Hour)
+	retention := viper.GetDuration("TRASH_RETENTION")
+	if retention <= 0 {
+		return
+	}
+
+	every(ctx, viper.GetDuration("TRASH_PURGE_INTERVAL"), func() {
+		purged, err := svc.PurgeExpiredTrash(ctx, time.Now().Add(-retention))
+		if err != nil {
+			logger.Error("trash purge failed", zap.Error(err))
+		} else if purged > 0 {
+			logger.Info("trash purged", zap.Int("purged", purged), zap.Duration("retention", retention))
+		}
+	})
+}
+
+// purgeAuditPeriodically deletes, every AUDIT_PURGE_INTERVAL, the audit logs older than AUDIT_RETENTION
+// An AUDIT_RETENTION of 0 keeps audit logs forever
+func purgeAuditPeriodically(ctx context.Context, logger *zap.Logger, svc services.ServiceInterface) {
+	viper.SetDefault("AUDIT_RETENTION", 365*24*time.Hour)
+	viper.SetDefault("AUDIT_PURGE_INTERVAL", 24*time.Hour)
+	retention := viper.GetDuration("AUDIT_RETENTION")
+	if retention <= 0 {
+		return
+	}
+
+	every(ctx, viper.GetDuration("AUDIT_PURGE_INTERVAL"), func() {
+		purged, err := svc.PurgeAuditLogs(ctx, time.Now().Add(-retention))
+		if err != nil {
+			logger.Error("audit purge failed", zap.Error(err))
+		} else if purged > 0 {
+			logger.Info("audit logs purged", zap.Int64("purged", purged), zap.Duration("retention", retention))
+		}
+	})
+}
+
+// purgeIdempotencyKeysPeriodically deletes, every IDEMPOTENCY_PURGE_INTERVAL, the expired idempotency keys
+func purgeIdempotencyKeysPeriodically(ctx context.Context, logger *zap.Logger, svc services.ServiceInterface) {
+	viper.SetDefault("IDEMPOTENCY_PURGE_INTERVAL", time.Hour)
+
+	every(ctx, viper.GetDuration("IDEMPOTENCY_PURGE_INTERVAL"), func() {
+		purged, err := svc.PurgeIdempotencyKeys(ctx, time.Now())
+		if err != nil {
+			logger.Error("idempotency keys purge failed", zap.Error(err))
+		} else if purged > 0 {
+			logger.Info("idempotency keys purged", zap.Int64("purged", purged))
+		}
+	})
+}
+
+// every runs fn now and then every interval, until the context is done
+// An interval of 0 or less disables fn, time.NewTicker would panic
+func every(ctx context.Context, interval time.Duration, fn func()) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fn()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
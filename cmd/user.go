@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"go.uber.org/zap"
+)
+
+var userCommands = map[string]command{
+	"create": {
+		usage: "Create a user (--email, --password, --first-name, --last-name, --admin)",
+		run:   userCreateCommand,
+	},
+}
+
+var tokenCommands = map[string]command{
+	"issue": {
+		usage: "Issue an access token for a user (--email)",
+		run:   tokenIssueCommand,
+	},
+}
+
+func userCreateCommand(logger *zap.Logger, args []string) error {
+	registerUser := &dto.RegisterUser{}
+	flags := flag.NewFlagSet("user create", flag.ContinueOnError)
+	flags.StringVar(&registerUser.Email, "email", "", "email of the user")
+	flags.StringVar(&registerUser.Password, "password", "", "password of the user")
+	flags.StringVar(&registerUser.FirstName, "first-name", "", "first name of the user")
+	flags.StringVar(&registerUser.LastName, "last-name", "", "last name of the user")
+	flags.BoolVar(&registerUser.IsAdmin, "admin", false, "create an administrator")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Apply the same rules than the register route
+	validate := validator.New()
+	validate.SetTagName("binding")
+	if err := validate.Struct(registerUser); err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+	}
+
+	app, err := newApp(logger)
+	if err != nil {
+		return err
+	}
+	user, err := app.service.RegisterUser(context.Background(), registerUser)
+	if err != nil {
+		return err
+	}
+	logger.Info("User created", zap.Uint("id", user.ID), zap.String("email", user.Email), zap.Bool("admin", user.IsAdmin))
+	return nil
+}
+
+func tokenIssueCommand(logger *zap.Logger, args []string) error {
+	flags := flag.NewFlagSet("token issue", flag.ContinueOnError)
+	email := flags.String("email", "", "email of the user")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errMissingFlag("email")
+	}
+
+	app, err := newApp(logger)
+	if err != nil {
+		return err
+	}
+	user, err := app.service.GetUserByEmail(context.Background(), *email)
+	if err != nil {
+		return err
+	}
+	token, err := app.service.GenerateToken(context.Background(), user)
+	if err != nil {
+		return err
+	}
+	fmt.Println(token)
+	return nil
+}
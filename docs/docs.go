@@ -0,0 +1,11 @@
+// Package docs holds the committed OpenAPI document of the routes, written by `make openapi`
+package docs
+
+import (
+	_ "embed"
+)
+
+// OpenAPI is the content of openapi.json, the requests and the responses are validated against it
+//
+//go:embed openapi.json
+var OpenAPI []byte
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"context"
+)
+
+// Types of actor
+const (
+	// An authenticated user, the actor ID is the user ID
+	ActorUser = "user"
+	// A client authenticated with an API key, the actor ID is the key ID
+	ActorAPIKey = "api_key"
+	// An unauthenticated HTTP request
+	ActorAnonymous = "anonymous"
+	// The server itself: commands and background jobs
+	ActorSystem = "system"
+)
+
+// Audited actions
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+)
+
+// Audited entity types
+const (
+	EntityUsers   = "users"
+	EntityArtists = "artists"
+	EntityAlbums  = "albums"
+	// The library entries, albums saved by the users
+	EntityUserAlbums = "user_albums"
+)
+
+type Actor struct {
+	Type string
+	ID   string
+}
+
+// Metadata describes the origin of the operations made with a context
+type Metadata struct {
+	Actor     Actor
+	RequestID string
+	IP        string
+}
+
+type metadataKey struct{}
+
+// WithMetadata returns a context whose operations are audited with the metadata
+func WithMetadata(ctx context.Context, metadata Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// WithActor returns a context whose operations are audited as made by the actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	metadata := FromContext(ctx)
+	metadata.Actor = actor
+	return WithMetadata(ctx, metadata)
+}
+
+// FromContext returns the metadata of the context
+// Without metadata, the operations are made by the system
+func FromContext(ctx context.Context) Metadata {
+	metadata, ok := ctx.Value(metadataKey{}).(Metadata)
+	if !ok {
+		return Metadata{Actor: Actor{Type: ActorSystem}}
+	}
+	return metadata
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	tests := map[string]struct {
+		ctx      func() context.Context
+		expected Metadata
+	}{
+		"Without metadata": {
+			ctx:      context.Background,
+			expected: Metadata{Actor: Actor{Type: ActorSystem}},
+		},
+		"With metadata": {
+			ctx: func() context.Context {
+				return WithMetadata(context.Background(), Metadata{Actor: Actor{Type: ActorAnonymous}, RequestID: "42", IP: "127.0.0.1"})
+			},
+			expected: Metadata{Actor: Actor{Type: ActorAnonymous}, RequestID: "42", IP: "127.0.0.1"},
+		},
+		"Actor set after metadata": {
+			ctx: func() context.Context {
+				ctx := WithMetadata(context.Background(), Metadata{Actor: Actor{Type: ActorAnonymous}, RequestID: "42", IP: "127.0.0.1"})
+				return WithActor(ctx, Actor{Type: ActorUser, ID: "1"})
+			},
+			expected: Metadata{Actor: Actor{Type: ActorUser, ID: "1"}, RequestID: "42", IP: "127.0.0.1"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, FromContext(test.ctx()))
+		})
+	}
+}
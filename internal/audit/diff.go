@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+// Change of a field, Before is nil for a creation and After is nil for a deletion
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Fields are named like their column
+var naming = schema.NamingStrategy{}
+
+// Diff returns the JSON object of the changed fields between two states of an entity, by column name
+// before is nil for a creation and after is nil for a deletion
+// Relations and fields tagged `audit:"-"` (e.g. password hashes) are left out
+func Diff(before, after interface{}) (string, error) {
+	beforeFields, err := snapshot(before)
+	if err != nil {
+		return "", err
+	}
+	afterFields, err := snapshot(after)
+	if err != nil {
+		return "", err
+	}
+
+	changes := map[string]*Change{}
+	for name, value := range beforeFields {
+		if afterValue, ok := afterFields[name]; !ok || !bytes.Equal(value, afterValue) {
+			changes[name] = &Change{Before: value}
+		}
+	}
+	for name, value := range afterFields {
+		if beforeValue, ok := beforeFields[name]; ok && bytes.Equal(value, beforeValue) {
+			continue
+		}
+		if changes[name] == nil {
+			changes[name] = &Change{}
+		}
+		changes[name].After = value
+	}
+
+	diff, err := json.Marshal(changes)
+	if err != nil {
+		return "", err
+	}
+	return string(diff), nil
+}
+
+// snapshot returns the JSON value of the fields of a struct, embedded structs are flattened
+func snapshot(entity interface{}) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	value := reflect.ValueOf(entity)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return fields, nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fields, nil
+	}
+	return fields, snapshotStruct(value, fields)
+}
+
+func snapshotStruct(value reflect.Value, fields map[string]json.RawMessage) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Tag.Get("audit") == "-" {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := snapshotStruct(value.Field(i), fields); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		// Relations are audited with their own entity
+		switch field.Type.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			continue
+		}
+
+		raw, err := json.Marshal(value.Field(i).Interface())
+		if err != nil {
+			return err
+		}
+		fields[naming.ColumnName("", field.Name)] = raw
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type diffedEmbedded struct {
+	ID uint
+}
+
+type diffedRelation struct {
+	Name string
+}
+
+type diffed struct {
+	diffedEmbedded
+	Name      string
+	BirthDate time.Time
+	Password  string `audit:"-"`
+	Relation  *diffedRelation
+	Relations []*diffedRelation
+}
+
+func TestDiff(t *testing.T) {
+	birthDate := time.Date(1972, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		before   interface{}
+		after    interface{}
+		expected string
+	}{
+		"Creation": {
+			after:    &diffed{diffedEmbedded: diffedEmbedded{ID: 1}, Name: "Eminem", BirthDate: birthDate, Password: "secret"},
+			expected: `{"birth_date":{"before":null,"after":"1972-10-17T00:00:00Z"},"id":{"before":null,"after":1},"name":{"before":null,"after":"Eminem"}}`,
+		},
+		"Update": {
+			before:   &diffed{diffedEmbedded: diffedEmbedded{ID: 1}, Name: "Eminem", Relation: &diffedRelation{Name: "Before"}},
+			after:    diffed{diffedEmbedded: diffedEmbedded{ID: 1}, Name: "Slim Shady", Password: "changed"},
+			expected: `{"name":{"before":"Eminem","after":"Slim Shady"}}`,
+		},
+		"Deletion": {
+			before:   &diffed{diffedEmbedded: diffedEmbedded{ID: 1}, Name: "Eminem", BirthDate: birthDate},
+			expected: `{"birth_date":{"before":"1972-10-17T00:00:00Z","after":null},"id":{"before":1,"after":null},"name":{"before":"Eminem","after":null}}`,
+		},
+		"Without states": {
+			expected: `{}`,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			diff, err := Diff(test.before, test.after)
+
+			require.NoError(t, err, "No error should have occurred")
+			assert.JSONEq(t, test.expected, diff)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores values by key for a limited time
+// Implementations must be safe for concurrent use
+type Cache interface {
+	// Get returns the value of the key, ok is false if the key is missing or expired
+	Get(ctx context.Context, key string) (value interface{}, ok bool)
+	// Set stores the value of the key for ttl
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration)
+	// Delete removes the keys
+	Delete(ctx context.Context, keys ...string)
+}
+
+// Stats are the counters of a cache user
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now()
+
+	tests := map[string]struct {
+		size     int
+		actions  func(c *LRU, now *time.Time)
+		expected map[string]interface{}
+	}{
+		"Get set value": {
+			size: 2,
+			actions: func(c *LRU, now *time.Time) {
+				c.Set(ctx, "a", 1, time.Minute)
+			},
+			expected: map[string]interface{}{"a": 1},
+		},
+		"Set replaces value": {
+			size: 2,
+			actions: func(c *LRU, now *time.Time) {
+				c.Set(ctx, "a", 1, time.Minute)
+				c.Set(ctx, "a", 2, time.Minute)
+			},
+			expected: map[string]interface{}{"a": 2},
+		},
+		"Evict least recently used": {
+			size: 2,
+			actions: func(c *LRU, now *time.Time) {
+				c.Set(ctx, "a", 1, time.Minute)
+				c.Set(ctx, "b", 2, time.Minute)
+				c.Get(ctx, "a")
+				c.Set(ctx, "c", 3, time.Minute)
+			},
+			expected: map[string]interface{}{"a": 1, "b": nil, "c": 3},
+		},
+		"Expire after ttl": {
+			size: 2,
+			actions: func(c *LRU, now *time.Time) {
+				c.Set(ctx, "a", 1, time.Minute)
+				c.Set(ctx, "b", 2, time.Hour)
+				*now = now.Add(time.Minute)
+			},
+			expected: map[string]interface{}{"a": nil, "b": 2},
+		},
+		"Delete keys": {
+			size: 3,
+			actions: func(c *LRU, now *time.Time) {
+				c.Set(ctx, "a", 1, time.Minute)
+				c.Set(ctx, "b", 2, time.Minute)
+				c.Set(ctx, "c", 3, time.Minute)
+				c.Delete(ctx, "a", "c", "unknown")
+			},
+			expected: map[string]interface{}{"a": nil, "b": 2, "c": nil},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			now := start
+			c := NewLRU(test.size)
+			c.now = func() time.Time { return now }
+
+			test.actions(c, &now)
+
+			for key, expected := range test.expected {
+				value, ok := c.Get(ctx, key)
+				assert.Equal(t, expected != nil, ok, "Presence of %s should match", key)
+				assert.Equal(t, expected, value, "Value of %s should match", key)
+			}
+			assert.LessOrEqual(t, c.Len(), test.size, "Cache should not exceed its size")
+		})
+	}
+}
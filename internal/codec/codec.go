@@ -0,0 +1,282 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Codec encodes the response bodies and decodes the request bodies of a media type
+// The bodies are converted from and to JSON, so the view models only have JSON tags
+type Codec struct {
+	// Content-Type of the encoded bodies
+	ContentType string
+	// encode receives the values of an ordered JSON document, see decodeJSON
+	encode func(value interface{}) ([]byte, error)
+	// decode returns the values of a JSON document, objects are map[string]interface{}
+	decode func(data []byte) (interface{}, error)
+}
+
+// Codecs of the supported media types
+var (
+	JSON = &Codec{
+		ContentType: "application/json; charset=utf-8",
+		encode:      json.Marshal,
+		decode:      decodeJSONValue,
+	}
+	// PrettyJSON is negotiated with the pretty parameter, e.g. Accept: application/json; pretty=true
+	PrettyJSON = &Codec{
+		ContentType: "application/json; charset=utf-8",
+		encode: func(value interface{}) ([]byte, error) {
+			return json.MarshalIndent(value, "", "  ")
+		},
+		decode: decodeJSONValue,
+	}
+	XML = &Codec{
+		ContentType: "application/xml; charset=utf-8",
+		encode:      encodeXML,
+		decode:      decodeXML,
+	}
+	MsgPack = &Codec{
+		ContentType: "application/msgpack",
+		encode:      encodeMsgPack,
+		decode:      decodeMsgPack,
+	}
+	YAML = &Codec{
+		ContentType: "application/yaml; charset=utf-8",
+		encode:      encodeYAML,
+		decode:      decodeYAML,
+	}
+)
+
+// mediaTypes maps the media types to their codec, in the order of preference of the server
+var mediaTypes = []struct {
+	mediaType string
+	codec     *Codec
+}{
+	{"application/json", JSON},
+	{"application/xml", XML},
+	{"text/xml", XML},
+	{"application/msgpack", MsgPack},
+	{"application/x-msgpack", MsgPack},
+	{"application/vnd.msgpack", MsgPack},
+	{"application/yaml", YAML},
+	{"application/x-yaml", YAML},
+	{"text/yaml", YAML},
+}
+
+// MediaTypes returns the main media type of each codec, in the order of preference of the server
+func MediaTypes() []string {
+	var types []string
+	seen := map[*Codec]bool{}
+	for _, supported := range mediaTypes {
+		if !seen[supported.codec] {
+			seen[supported.codec] = true
+			types = append(types, supported.mediaType)
+		}
+	}
+	return types
+}
+
+// ErrNotAcceptable is returned when the Accept header has no supported media type
+var ErrNotAcceptable = errors.New("no codec for the Accept header")
+
+// ForContentType returns the codec of a Content-Type, without its parameters
+func ForContentType(contentType string) (*Codec, bool) {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	for _, supported := range mediaTypes {
+		if supported.mediaType == contentType {
+			return supported.codec, true
+		}
+	}
+	return nil, false
+}
+
+// acceptRange is a media range of an Accept header
+type acceptRange struct {
+	mediaType string
+	params    map[string]string
+	quality   float64
+	// Position in the header, the first ranges win the ties
+	position int
+}
+
+// specificity returns how specific the range is for a media type, -1 if it does not match
+func (r *acceptRange) specificity(mediaType string) int {
+	switch {
+	case r.mediaType == mediaType:
+		return 2
+	case r.mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(r.mediaType, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(r.mediaType, "*")):
+		return 1
+	default:
+		return -1
+	}
+}
+
+func parseAccept(header string) []*acceptRange {
+	var ranges []*acceptRange
+	for position, part := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, &acceptRange{mediaType: mediaType, params: params, quality: quality, position: position})
+	}
+	return ranges
+}
+
+// Negotiate returns the codec of the response from the Accept header
+// Each media type gets the quality of its most specific range, the best quality wins,
+// then the first range of the header, then the preference of the server. JSON is the default
+func Negotiate(accept string) (*Codec, error) {
+	if strings.TrimSpace(accept) == "" {
+		return JSON, nil
+	}
+	ranges := parseAccept(accept)
+
+	var best *acceptRange
+	var bestCodec *Codec
+	for _, supported := range mediaTypes {
+		var match *acceptRange
+		matchSpecificity := -1
+		for _, r := range ranges {
+			if specificity := r.specificity(supported.mediaType); specificity > matchSpecificity {
+				match, matchSpecificity = r, specificity
+			}
+		}
+		if match == nil || match.quality == 0 {
+			continue
+		}
+		if best == nil || match.quality > best.quality || (match.quality == best.quality && match.position < best.position) {
+			best, bestCodec = match, supported.codec
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotAcceptable, accept)
+	}
+	if bestCodec == JSON && best.mediaType == "application/json" {
+		if pretty, _ := strconv.ParseBool(best.params["pretty"]); pretty {
+			return PrettyJSON, nil
+		}
+	}
+	return bestCodec, nil
+}
+
+// FromJSON encodes a JSON document with the codec, the fields keep their order
+func FromJSON(c *Codec, body []byte) ([]byte, error) {
+	if c == JSON {
+		return body, nil
+	}
+	if c == PrettyJSON {
+		var indented bytes.Buffer
+		err := json.Indent(&indented, body, "", "  ")
+		return indented.Bytes(), err
+	}
+	value, err := decodeJSON(body)
+	if err != nil {
+		return nil, err
+	}
+	return c.encode(value)
+}
+
+// ToJSON decodes a body of the codec to a JSON document bound to a value of the type
+// The decoded values are converted to the JSON types of the fields, see Coerce
+func ToJSON(c *Codec, data []byte, typ reflect.Type) ([]byte, error) {
+	if c == JSON || c == PrettyJSON {
+		return data, nil
+	}
+	value, err := c.decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if typ != nil {
+		value = Coerce(value, typ)
+	}
+	return json.Marshal(value)
+}
+
+// object is a JSON object whose members keep their order
+type object []member
+
+type member struct {
+	key   string
+	value interface{}
+}
+
+// decodeJSON decodes a JSON document, objects are decoded to object and numbers to json.Number
+func decodeJSON(body []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	return decodeJSONToken(decoder)
+}
+
+func decodeJSONToken(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		value := object{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			memberValue, err := decodeJSONToken(decoder)
+			if err != nil {
+				return nil, err
+			}
+			value = append(value, member{key: key.(string), value: memberValue})
+		}
+		_, err = decoder.Token()
+		return value, err
+	case json.Delim('['):
+		value := []interface{}{}
+		for decoder.More() {
+			item, err := decodeJSONToken(decoder)
+			if err != nil {
+				return nil, err
+			}
+			value = append(value, item)
+		}
+		_, err = decoder.Token()
+		return value, err
+	default:
+		return token, nil
+	}
+}
+
+func decodeJSONValue(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	err := decoder.Decode(&value)
+	return value, err
+}
+
+// scalarString returns the text of a scalar value
+func scalarString(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
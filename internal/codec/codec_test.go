@@ -0,0 +1,212 @@
+package codec
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]struct {
+		accept   string
+		expected *Codec
+		err      error
+	}{
+		"No Accept":                 {accept: "", expected: JSON},
+		"Any":                       {accept: "*/*", expected: JSON},
+		"JSON":                      {accept: "application/json", expected: JSON},
+		"Pretty JSON":               {accept: "application/json; pretty=true", expected: PrettyJSON},
+		"XML":                       {accept: "application/xml", expected: XML},
+		"Text XML":                  {accept: "text/xml", expected: XML},
+		"MessagePack":               {accept: "application/x-msgpack", expected: MsgPack},
+		"YAML":                      {accept: "application/yaml", expected: YAML},
+		"Text range":                {accept: "text/*", expected: XML},
+		"Best quality":              {accept: "application/json;q=0.5, application/yaml", expected: YAML},
+		"First range of the header": {accept: "application/yaml, application/xml", expected: YAML},
+		"Specific range wins":       {accept: "*/*;q=0.1, application/json;q=0", expected: XML},
+		"Unsupported":               {accept: "text/html", err: ErrNotAcceptable},
+		"Excluded":                  {accept: "application/json;q=0", err: ErrNotAcceptable},
+		"Invalid ranges skipped":    {accept: "not a type, application/xml;q=2, application/yaml", expected: YAML},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			c, err := Negotiate(test.accept)
+
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err), "Error should be %v, got %v", test.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, test.expected, c)
+		})
+	}
+}
+
+func TestForContentType(t *testing.T) {
+	c, ok := ForContentType("Application/X-YAML")
+	assert.True(t, ok)
+	assert.Same(t, YAML, c)
+
+	_, ok = ForContentType("text/html")
+	assert.False(t, ok)
+}
+
+func TestMediaTypes(t *testing.T) {
+	assert.Equal(t, []string{"application/json", "application/xml", "application/msgpack", "application/yaml"}, MediaTypes())
+}
+
+func TestFromJSON(t *testing.T) {
+	body := `{"id":12345678901234567890,"name":"1999","ratio":0.5,"live":false,"label":null,"tags":["rap","pop"],"context":{"albums[0].name":"invalid"}}`
+
+	tests := map[string]struct {
+		codec    *Codec
+		expected string
+	}{
+		"JSON": {
+			codec:    JSON,
+			expected: body,
+		},
+		"Pretty JSON": {
+			codec: PrettyJSON,
+			expected: `{
+  "id": 12345678901234567890,
+  "name": "1999",
+  "ratio": 0.5,
+  "live": false,
+  "label": null,
+  "tags": [
+    "rap",
+    "pop"
+  ],
+  "context": {
+    "albums[0].name": "invalid"
+  }
+}`,
+		},
+		"XML": {
+			codec: XML,
+			expected: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<response><id>12345678901234567890</id><name>1999</name><ratio>0.5</ratio><live>false</live><label></label>` +
+				`<tags><item>rap</item><item>pop</item></tags><context><entry key="albums[0].name">invalid</entry></context></response>`,
+		},
+		"YAML": {
+			codec: YAML,
+			expected: `id: 12345678901234567890
+name: "1999"
+ratio: 0.5
+live: false
+label: null
+tags:
+  - rap
+  - pop
+context:
+  albums[0].name: invalid
+`,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			encoded, err := FromJSON(test.codec, []byte(body))
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(encoded))
+		})
+	}
+
+	t.Run("MessagePack keeps the order of the fields", func(t *testing.T) {
+		encoded, err := FromJSON(MsgPack, []byte(`{"name":"Eminem","id":1,"albums":["Recovery"]}`))
+		require.NoError(t, err)
+
+		// fixmap of 3 members: "name", "Eminem", "id", 1, "albums", ["Recovery"]
+		expected := []byte{0x83, 0xa4, 'n', 'a', 'm', 'e', 0xa6, 'E', 'm', 'i', 'n', 'e', 'm', 0xa2, 'i', 'd', 0x01,
+			0xa6, 'a', 'l', 'b', 'u', 'm', 's', 0x91, 0xa8, 'R', 'e', 'c', 'o', 'v', 'e', 'r', 'y'}
+		assert.Equal(t, expected, encoded)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := FromJSON(XML, []byte("{"))
+		assert.Error(t, err)
+	})
+}
+
+type testBody struct {
+	testEmbedded
+	Name     string         `json:"name"`
+	Version  uint           `json:"version"`
+	Live     *bool          `json:"live"`
+	Albums   []testAlbum    `json:"albums"`
+	Tags     []string       `json:"tags"`
+	Metadata map[string]int `json:"metadata"`
+	Skipped  string         `json:"-"`
+}
+
+type testEmbedded struct {
+	Mode string `json:"mode"`
+}
+
+type testAlbum struct {
+	ID uint `json:"id"`
+}
+
+func TestToJSON(t *testing.T) {
+	bodyType := reflect.TypeOf(testBody{})
+
+	tests := map[string]struct {
+		codec    *Codec
+		body     string
+		expected string
+	}{
+		"JSON is kept": {
+			codec:    JSON,
+			body:     `{"name":"Eminem"}`,
+			expected: `{"name":"Eminem"}`,
+		},
+		"XML": {
+			codec: XML,
+			body: `<artist><mode>atomic</mode><name>1999</name><version>3</version><live>true</live>` +
+				`<albums><album><id>1</id></album><album><id>2</id></album></albums><tags><item>rap</item></tags>` +
+				`<metadata><entry key="sold copies">10</entry></metadata><unknown>a</unknown></artist>`,
+			expected: `{"albums":[{"id":1},{"id":2}],"live":true,"metadata":{"sold copies":10},"mode":"atomic","name":"1999","tags":["rap"],"unknown":"a","version":3}`,
+		},
+		"XML empty list": {
+			codec:    XML,
+			body:     `<artist><name>Eminem</name><tags/></artist>`,
+			expected: `{"name":"Eminem","tags":[]}`,
+		},
+		"YAML": {
+			codec:    YAML,
+			body:     "name: 1999\nversion: 3\ntags: [rap]\nalbums:\n  - id: 1\n",
+			expected: `{"albums":[{"id":1}],"name":"1999","tags":["rap"],"version":3}`,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			body, err := ToJSON(test.codec, []byte(test.body), bodyType)
+
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(body))
+		})
+	}
+
+	t.Run("MessagePack", func(t *testing.T) {
+		encoded, err := FromJSON(MsgPack, []byte(`{"name":"Eminem","version":3,"tags":["rap"]}`))
+		require.NoError(t, err)
+
+		body, err := ToJSON(MsgPack, encoded, bodyType)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"Eminem","version":3,"tags":["rap"]}`, string(body))
+	})
+
+	t.Run("Invalid bodies", func(t *testing.T) {
+		for c, body := range map[*Codec]string{XML: "<artist><name>", YAML: "name: [", MsgPack: "\xc1"} {
+			_, err := ToJSON(c, []byte(body), bodyType)
+			assert.Error(t, err, "%s should be invalid", c.ContentType)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package codec
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Coerce converts a decoded value to the JSON types of the fields of the type
+// XML only has texts, and YAML guesses the types of its scalars, e.g. a name 1999 is a number:
+// the scalars are converted to the kinds of the fields, e.g. "3" to 3 for an uint
+// An XML list, e.g. <items><item>a</item></items>, is the list of the values of its only child
+// The values without a field are kept, so the unknown fields are refused like in JSON
+func Coerce(value interface{}, typ reflect.Type) interface{} {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if value == nil || typ.Implements(jsonUnmarshalerType) || reflect.PtrTo(typ).Implements(jsonUnmarshalerType) ||
+		typ.Implements(textUnmarshalerType) || reflect.PtrTo(typ).Implements(textUnmarshalerType) {
+		return value
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		if isScalar(value) {
+			return scalarString(value)
+		}
+	case reflect.Bool:
+		if text, ok := value.(string); ok {
+			if text = strings.TrimSpace(text); text == "" {
+				return nil
+			}
+			if parsed, err := strconv.ParseBool(text); err == nil {
+				return parsed
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if text, ok := value.(string); ok {
+			if text = strings.TrimSpace(text); text == "" {
+				return nil
+			}
+			if _, err := strconv.ParseFloat(text, 64); err == nil {
+				return json.Number(text)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			// Bytes are a base64 string in JSON
+			return value
+		}
+		return coerceList(value, typ.Elem())
+	case reflect.Map:
+		if values, ok := value.(map[string]interface{}); ok {
+			coerced := make(map[string]interface{}, len(values))
+			for key, item := range values {
+				coerced[key] = Coerce(item, typ.Elem())
+			}
+			return coerced
+		}
+	case reflect.Struct:
+		if values, ok := value.(map[string]interface{}); ok {
+			coerced := make(map[string]interface{}, len(values))
+			for key, item := range values {
+				coerced[key] = item
+			}
+			coerceFields(coerced, typ)
+			return coerced
+		}
+	}
+	return value
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	default:
+		return true
+	}
+}
+
+func coerceList(value interface{}, elem reflect.Type) interface{} {
+	// An XML list element is an object with one repeated child, or a text if it is empty
+	if values, ok := value.(map[string]interface{}); ok && len(values) == 1 {
+		for _, child := range values {
+			value = child
+		}
+	}
+	if text, ok := value.(string); ok && strings.TrimSpace(text) == "" {
+		return []interface{}{}
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	coerced := make([]interface{}, len(items))
+	for i, item := range items {
+		coerced[i] = Coerce(item, elem)
+	}
+	return coerced
+}
+
+// coerceFields converts the values of the fields of the struct, by their JSON name
+// The fields of the embedded structs are fields of the struct, like in JSON
+func coerceFields(values map[string]interface{}, typ reflect.Type) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			coerceFields(values, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if value, ok := values[name]; ok {
+			values[name] = Coerce(value, field.Type)
+		}
+	}
+}
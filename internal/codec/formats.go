@@ -0,0 +1,290 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	ugorji "github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+)
+
+// Element names of the XML documents
+const (
+	xmlRoot  = "response"
+	xmlItem  = "item"
+	xmlEntry = "entry"
+	xmlKey   = "key"
+)
+
+// encodeXML writes the document in a response element, the items of a list are item elements
+// A key which is not an XML name, e.g. albums[0].name, is written as <entry key="albums[0].name">
+func encodeXML(value interface{}) ([]byte, error) {
+	var encoded bytes.Buffer
+	encoded.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&encoded)
+	if err := encodeXMLElement(encoder, xmlRoot, value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+	return encoded.Bytes(), nil
+}
+
+func encodeXMLElement(encoder *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if !isXMLName(name) {
+		start = xml.StartElement{
+			Name: xml.Name{Local: xmlEntry},
+			Attr: []xml.Attr{{Name: xml.Name{Local: xmlKey}, Value: name}},
+		}
+	}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+	switch value := value.(type) {
+	case object:
+		for _, member := range value {
+			if err := encodeXMLElement(encoder, member.key, member.value); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if err := encodeXMLElement(encoder, xmlItem, item); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := encoder.EncodeToken(xml.CharData(scalarString(value))); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// isXMLName tells if a key can be an element name, e.g. created_at
+func isXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		letter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !letter && (i == 0 || !(r == '-' || r == '.' || (r >= '0' && r <= '9'))) {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeXML reads the content of the root element, whatever its name
+// An element with children is an object, a repeated child is a list, and an element without children is a text
+func decodeXML(data []byte) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			return decodeXMLElement(decoder)
+		}
+	}
+}
+
+func decodeXMLElement(decoder *xml.Decoder) (interface{}, error) {
+	var text strings.Builder
+	var children map[string]interface{}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder)
+			if err != nil {
+				return nil, err
+			}
+			key := token.Name.Local
+			for _, attr := range token.Attr {
+				if key == xmlEntry && attr.Name.Local == xmlKey {
+					key = attr.Value
+				}
+			}
+			if children == nil {
+				children = map[string]interface{}{}
+			}
+			switch existing := children[key].(type) {
+			case nil:
+				children[key] = child
+			case []interface{}:
+				children[key] = append(existing, child)
+			default:
+				children[key] = []interface{}{existing, child}
+			}
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			if children != nil {
+				return children, nil
+			}
+			return text.String(), nil
+		}
+	}
+}
+
+// msgpackHandle encodes the strings with the str format, and decodes them to strings
+var msgpackHandle = newMsgpackHandle()
+
+func newMsgpackHandle() *ugorji.MsgpackHandle {
+	handle := &ugorji.MsgpackHandle{WriteExt: true}
+	handle.RawToString = true
+	handle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return handle
+}
+
+// msgpackMap is an object encoded as a map, its keys and values alternate
+type msgpackMap []interface{}
+
+func (msgpackMap) MapBySlice() {}
+
+func encodeMsgPack(value interface{}) ([]byte, error) {
+	var encoded []byte
+	err := ugorji.NewEncoderBytes(&encoded, msgpackHandle).Encode(msgpackValue(value))
+	return encoded, err
+}
+
+// msgpackValue converts the objects to maps keeping their order, and the numbers to integers or floats
+func msgpackValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case object:
+		converted := make(msgpackMap, 0, 2*len(value))
+		for _, member := range value {
+			converted = append(converted, member.key, msgpackValue(member.value))
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(value))
+		for i, item := range value {
+			converted[i] = msgpackValue(item)
+		}
+		return converted
+	case json.Number:
+		if integer, err := value.Int64(); err == nil {
+			return integer
+		}
+		if integer, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return integer
+		}
+		float, _ := value.Float64()
+		return float
+	default:
+		return value
+	}
+}
+
+func decodeMsgPack(data []byte) (interface{}, error) {
+	var value interface{}
+	err := ugorji.NewDecoderBytes(data, msgpackHandle).Decode(&value)
+	return value, err
+}
+
+func encodeYAML(value interface{}) ([]byte, error) {
+	var encoded bytes.Buffer
+	encoder := yaml.NewEncoder(&encoded)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNode(value)); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return encoded.Bytes(), nil
+}
+
+// yamlNode returns the node of a value, the scalars are tagged so the strings are quoted when needed
+func yamlNode(value interface{}) *yaml.Node {
+	switch value := value.(type) {
+	case object:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, member := range value {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: member.key}, yamlNode(member.value))
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range value {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(value)}
+	case json.Number:
+		if !strings.ContainsAny(value.String(), ".eE") {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value.String()}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: value.String()}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: scalarString(value)}
+	}
+}
+
+func decodeYAML(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return yamlValue(value)
+}
+
+// yamlValue converts the maps with any keys to JSON objects
+func yamlValue(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			converted, err := yamlValue(item)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = converted
+		}
+		return value, nil
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			convertedItem, err := yamlValue(item)
+			if err != nil {
+				return nil, err
+			}
+			converted[fmt.Sprint(key)] = convertedItem
+		}
+		return converted, nil
+	case []interface{}:
+		for i, item := range value {
+			converted, err := yamlValue(item)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = converted
+		}
+		return value, nil
+	case float64:
+		// .inf and .nan have no JSON number
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return nil, errors.New("the number is not finite")
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
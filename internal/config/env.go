@@ -0,0 +1,25 @@
+package config
+
+import "github.com/spf13/viper"
+
+// Environments, set with the ENV variable
+const (
+	EnvDevelopment = "dev"
+	EnvTest        = "test"
+	EnvDemo        = "demo"
+	EnvProduction  = "production"
+)
+
+// Env returns the current environment, dev by default
+func Env() string {
+	env := viper.GetString("ENV")
+	if env == "" {
+		return EnvDevelopment
+	}
+	return env
+}
+
+// IsProduction returns true if the server runs in production
+func IsProduction() bool {
+	return Env() == EnvProduction
+}
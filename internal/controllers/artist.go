@@ -1,94 +1,335 @@
 package controllers
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/fieldset"
+	"github.com/sarrooo/go-clean/internal/filter"
 	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/pagination"
 	"github.com/sarrooo/go-clean/internal/services"
 	"github.com/sarrooo/go-clean/internal/viewmodel"
 )
 
 func registerArtistesRoutes(group *gin.RouterGroup, svc services.ServiceInterface) {
-	group.POST("/", requestViewmodelMiddleware(&viewmodel.CreateArtistResponse{}), createArtistController(svc))
-	group.GET("/:id", requestViewmodelMiddleware(&viewmodel.GetArtistRequest{}), getArtistController(svc))
-	group.DELETE("/:id", requestViewmodelMiddleware(&viewmodel.DeleteArtistRequest{}), deleteArtistController(svc))
+	Handle(group, http.MethodPost, "/", createArtistController(svc))
+	Handle(group, http.MethodGet, "/", listArtistsController(svc))
+	Handle(group, http.MethodPost, "/bulk", bulkCreateArtistsController(svc))
+	Handle(group, http.MethodPut, "/bulk", bulkUpdateArtistsController(svc))
+	Handle(group, http.MethodDelete, "/bulk", bulkDeleteArtistsController(svc))
+	Handle(group, http.MethodGet, "/:id", getArtistController(svc))
+	Handle(group, http.MethodPut, "/:id", updateArtistController(svc), ifMatchMiddleware())
+	Handle(group, http.MethodDelete, "/:id", deleteArtistController(svc), ifMatchMiddleware())
+}
+
+// Relations of an artist which can be expanded
+var artistExpandable = fieldset.Expandable{
+	"albums": "Albums",
 }
 
 // swagger:route GET /artists/{id} artistes getArtistController
 //
 // Endpoint for getting artist.
+// The fields of the response are selected with fields, e.g. fields=id,name, and its albums are included with expand=albums.
+// Without expansion the ETag is the artist version, a request with a matching If-None-Match header is answered 304.
 //
 // responses:
 //
 //	200: getArtistController
 //	400: errorResponse
-func getArtistController(svc services.ServiceInterface) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		request := ctx.MustGet(ContextKeyRequestViewmodel).(*viewmodel.GetArtistRequest)
+func getArtistController(svc services.ServiceInterface) HandlerFunc[viewmodel.GetArtistRequest, viewmodel.GetArtistResponse] {
+	return func(ctx *gin.Context, request *viewmodel.GetArtistRequest) (*viewmodel.GetArtistResponse, int, error) {
 		response := &viewmodel.GetArtistResponse{}
 
-		artist, err := svc.GetArtist(request.ID)
+		params, err := fieldsetParams(ctx, &request.Request, response.Body, artistExpandable)
 		if err != nil {
-			ctx.Error(err)
-			return
+			return nil, 0, err
+		}
+		artist, err := svc.GetArtist(ctx.Request.Context(), request.ID, params.Preloads)
+		if err != nil {
+			return nil, 0, err
 		}
 
 		response.Body.ID = artist.ID
 		response.Body.Name = artist.Name
+		response.Body.Version = artist.Version
+		for _, album := range artist.Albums {
+			response.Body.Albums = append(response.Body.Albums, &viewmodel.Album{ID: album.ID, Name: album.Name})
+		}
+
+		// The expanded relations can change without the artist version
+		if len(params.Preloads) == 0 {
+			ctx.Set(ContextKeyVersion, artist.Version)
+		}
+		return response, 200, nil
+	}
+}
+
+// Sort fields of the artists
+var artistSortable = pagination.Sortable{
+	"id":         "id",
+	"name":       "name",
+	"created_at": "created_at",
+}
+
+// swagger:route GET /artists artistes listArtistsController
+//
+// Endpoint for listing artists, by name by default.
+// The artists are filtered by id, name and created_at, e.g. name[contains]=em&created_at[gte]=2024-01-01.
+// The pages are found by cursor, or by number with the page parameter.
+// The fields of each artist are selected with fields, e.g. fields=id,name, and their albums are included with expand=albums.
+//
+// responses:
+//
+//	200: listArtistsController
+//	400: errorResponse
+func listArtistsController(svc services.ServiceInterface) HandlerFunc[viewmodel.ListArtistsRequest, viewmodel.ListArtistsResponse] {
+	return func(ctx *gin.Context, request *viewmodel.ListArtistsRequest) (*viewmodel.ListArtistsResponse, int, error) {
+		response := &viewmodel.ListArtistsResponse{}
+
+		params, err := paginationParams(ctx, &request.Request, artistSortable, "name")
+		if err != nil {
+			return nil, 0, err
+		}
+		fields, err := itemsFieldsetParams(ctx, &request.Fieldset, response.Body, "artists", viewmodel.Artist{}, artistExpandable)
+		if err != nil {
+			return nil, 0, err
+		}
+		page, err := svc.ListArtists(ctx.Request.Context(), filter.Conditions(request), params, fields.Preloads)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		response.Body.Artists = make([]*viewmodel.Artist, 0, len(page.Items))
+		for _, artist := range page.Items {
+			item := &viewmodel.Artist{
+				ID:      artist.ID,
+				Name:    artist.Name,
+				Version: artist.Version,
+			}
+			for _, album := range artist.Albums {
+				item.Albums = append(item.Albums, &viewmodel.Album{ID: album.ID, Name: album.Name})
+			}
+			response.Body.Artists = append(response.Body.Artists, item)
+		}
+		response.Body.Pagination = pagination.NewEnvelope(ctx.Request.URL, page.Info)
 
-		ctx.Set(ContextKeyStatusCode, 200)
-		ctx.Set(ContextKeyResponseViewmodel, response)
+		return response, 200, nil
 	}
 }
 
 // swagger:route POST /artists artistes createArtistController
 //
 // Endpoint for creating artist.
+// A request sent with an Idempotency-Key header is processed once, its retries get the same response.
 //
 // responses:
 //
 //	200: createArtistController
 //	400: errorResponse
-func createArtistController(svc services.ServiceInterface) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		request := ctx.MustGet(ContextKeyRequestViewmodel).(*viewmodel.CreateArtistRequest)
+//	409: errorResponse
+func createArtistController(svc services.ServiceInterface) HandlerFunc[viewmodel.CreateArtistRequest, viewmodel.CreateArtistResponse] {
+	return func(ctx *gin.Context, request *viewmodel.CreateArtistRequest) (*viewmodel.CreateArtistResponse, int, error) {
 		response := &viewmodel.CreateArtistResponse{}
 
 		artist := &models.Artist{
-			Name: request.Name,
+			Name: request.Body.Name,
 		}
-		err := svc.CreateArtist(artist)
+		err := svc.CreateArtist(ctx.Request.Context(), artist)
 		if err != nil {
-			ctx.Error(err)
-			return
+			return nil, 0, err
 		}
 
 		response.Body.ID = artist.ID
 
-		ctx.Set(ContextKeyStatusCode, 200)
-		ctx.Set(ContextKeyResponseViewmodel, response)
+		return response, 200, nil
+	}
+}
+
+// swagger:route PUT /artists/{id} artistes updateArtistController
+//
+// Endpoint for updating artist.
+// The If-Match header is required, it is the ETag of the updated version or * to update any version.
+//
+// responses:
+//
+//	200: updateArtistController
+//	400: errorResponse
+//	412: errorResponse
+//	428: errorResponse
+func updateArtistController(svc services.ServiceInterface) HandlerFunc[viewmodel.UpdateArtistRequest, viewmodel.UpdateArtistResponse] {
+	return func(ctx *gin.Context, request *viewmodel.UpdateArtistRequest) (*viewmodel.UpdateArtistResponse, int, error) {
+		response := &viewmodel.UpdateArtistResponse{}
+
+		artist := &models.Artist{
+			Model: models.Model{ID: request.ID, Version: ctx.GetUint(ContextKeyIfMatchVersion)},
+			Name:  request.Body.Name,
+		}
+		err := svc.UpdateArtist(ctx.Request.Context(), artist)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		response.Body.ID = artist.ID
+		response.Body.Name = artist.Name
+		response.Body.Version = artist.Version
+
+		ctx.Set(ContextKeyVersion, artist.Version)
+		return response, 200, nil
 	}
 }
 
 // swagger:route DELETE /artists/{id} artistes deleteArtistController
 //
 // Endpoint for deleting artist.
+// The deletion is refused while albums reference the artist, unless they are deleted
+// with it (strategy=cascade) or moved to another artist (strategy=reassign&reassign_to={id}).
+// The If-Match header is required, it is the ETag of the deleted version or * to delete any version.
 //
 // responses:
 //
 //	200: deleteArtistController
 //	400: errorResponse
-func deleteArtistController(svc services.ServiceInterface) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		request := ctx.MustGet(ContextKeyRequestViewmodel).(*viewmodel.DeleteArtistRequest)
+//	409: errorResponse
+//	412: errorResponse
+//	428: errorResponse
+func deleteArtistController(svc services.ServiceInterface) HandlerFunc[viewmodel.DeleteArtistRequest, viewmodel.DeleteArtistResponse] {
+	return func(ctx *gin.Context, request *viewmodel.DeleteArtistRequest) (*viewmodel.DeleteArtistResponse, int, error) {
 		response := &viewmodel.DeleteArtistResponse{}
 
-		err := svc.DeleteArtist(request.ID)
+		err := svc.DeleteArtist(ctx.Request.Context(), &dto.DeleteArtist{
+			ID:         request.ID,
+			Version:    ctx.GetUint(ContextKeyIfMatchVersion),
+			Strategy:   request.Strategy,
+			ReassignTo: request.ReassignTo,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return response, 200, nil
+	}
+}
+
+// swagger:route POST /artists/bulk artistes bulkCreateArtistsController
+//
+// Endpoint for creating artists in bulk.
+// The items are validated one by one, and each item gets its status and error in the results.
+// In atomic mode (default) a failed item aborts all the items, in best_effort mode the other items are created.
+//
+// responses:
+//
+//	200: bulkResponse
+//	207: bulkResponse
+//	400: errorResponse
+func bulkCreateArtistsController(svc services.ServiceInterface) HandlerFunc[viewmodel.BulkCreateArtistsRequest, viewmodel.BulkResponse] {
+	return func(ctx *gin.Context, request *viewmodel.BulkCreateArtistsRequest) (*viewmodel.BulkResponse, int, error) {
+		b, err := newBulk(ctx, request.Body.Mode, request.Body.Items)
+		if err != nil {
+			return nil, 0, err
+		}
+		artists := make([]*models.Artist, len(request.Body.Items))
+		err = b.run(func(valid []int) ([]error, error) {
+			items := make([]*models.Artist, 0, len(valid))
+			for _, i := range valid {
+				artists[i] = &models.Artist{Name: request.Body.Items[i].Name}
+				items = append(items, artists[i])
+			}
+			return svc.BulkCreateArtists(ctx.Request.Context(), items, b.mode)
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		response, statusCode := b.response(func(i int, result *viewmodel.BulkResult) {
+			result.ID = artists[i].ID
+			result.Version = artists[i].Version
+		})
+		return response, statusCode, nil
+	}
+}
+
+// swagger:route PUT /artists/bulk artistes bulkUpdateArtistsController
+//
+// Endpoint for updating artists in bulk.
+// Each item gives the version it updates, an item whose artist was updated meanwhile fails with 412.
+// In atomic mode (default) a failed item aborts all the items, in best_effort mode the other items are updated.
+//
+// responses:
+//
+//	200: bulkResponse
+//	207: bulkResponse
+//	400: errorResponse
+func bulkUpdateArtistsController(svc services.ServiceInterface) HandlerFunc[viewmodel.BulkUpdateArtistsRequest, viewmodel.BulkResponse] {
+	return func(ctx *gin.Context, request *viewmodel.BulkUpdateArtistsRequest) (*viewmodel.BulkResponse, int, error) {
+		b, err := newBulk(ctx, request.Body.Mode, request.Body.Items)
+		if err != nil {
+			return nil, 0, err
+		}
+		artists := make([]*models.Artist, len(request.Body.Items))
+		err = b.run(func(valid []int) ([]error, error) {
+			items := make([]*models.Artist, 0, len(valid))
+			for _, i := range valid {
+				item := request.Body.Items[i]
+				artists[i] = &models.Artist{
+					Model: models.Model{ID: item.ID, Version: item.Version},
+					Name:  item.Name,
+				}
+				items = append(items, artists[i])
+			}
+			return svc.BulkUpdateArtists(ctx.Request.Context(), items, b.mode)
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		response, statusCode := b.response(func(i int, result *viewmodel.BulkResult) {
+			result.ID = artists[i].ID
+			result.Version = artists[i].Version
+		})
+		return response, statusCode, nil
+	}
+}
+
+// swagger:route DELETE /artists/bulk artistes bulkDeleteArtistsController
+//
+// Endpoint for deleting artists in bulk.
+// Each item gives the version it deletes and the strategy of its albums, like DELETE /artists/{id}.
+// In atomic mode (default) a failed item aborts all the items, in best_effort mode the other items are deleted.
+//
+// responses:
+//
+//	200: bulkResponse
+//	207: bulkResponse
+//	400: errorResponse
+func bulkDeleteArtistsController(svc services.ServiceInterface) HandlerFunc[viewmodel.BulkDeleteArtistsRequest, viewmodel.BulkResponse] {
+	return func(ctx *gin.Context, request *viewmodel.BulkDeleteArtistsRequest) (*viewmodel.BulkResponse, int, error) {
+		b, err := newBulk(ctx, request.Body.Mode, request.Body.Items)
+		if err != nil {
+			return nil, 0, err
+		}
+		err = b.run(func(valid []int) ([]error, error) {
+			items := make([]*dto.DeleteArtist, 0, len(valid))
+			for _, i := range valid {
+				item := request.Body.Items[i]
+				items = append(items, &dto.DeleteArtist{
+					ID:         item.ID,
+					Version:    item.Version,
+					Strategy:   item.Strategy,
+					ReassignTo: item.ReassignTo,
+				})
+			}
+			return svc.BulkDeleteArtists(ctx.Request.Context(), items, b.mode)
+		})
 		if err != nil {
-			ctx.Error(err)
-			return
+			return nil, 0, err
 		}
 
-		ctx.Set(ContextKeyStatusCode, 200)
-		ctx.Set(ContextKeyResponseViewmodel, response)
+		response, statusCode := b.response(func(i int, result *viewmodel.BulkResult) {
+			result.ID = request.Body.Items[i].ID
+		})
+		return response, statusCode, nil
 	}
 }
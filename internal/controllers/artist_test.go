@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/fieldset"
+	"github.com/sarrooo/go-clean/internal/filter"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ControllerSuiteTest) TestListArtistsController() {
+	page := &pagination.Page[*models.Artist]{
+		Items: []*models.Artist{{Model: models.Model{ID: 2, Version: 1}, Name: "Dr. Dre"}, {Model: models.Model{ID: 1, Version: 3}, Name: "Eminem"}},
+		Info:  pagination.Info{Limit: 2, NextCursor: "next"},
+	}
+	response := &viewmodel.ListArtistsResponse{}
+	response.Body.Artists = []*viewmodel.Artist{{ID: 2, Name: "Dr. Dre", Version: 1}, {ID: 1, Name: "Eminem", Version: 3}}
+	response.Body.Pagination = pagination.Envelope{Limit: 2, Next: "/?cursor=next"}
+	expandedPage := &pagination.Page[*models.Artist]{
+		Items: []*models.Artist{{Model: models.Model{ID: 1, Version: 3}, Name: "Eminem", Albums: []*models.Album{{Model: models.Model{ID: 4}, Name: "Recovery"}}}},
+	}
+	expandedResponse := &viewmodel.ListArtistsResponse{}
+	expandedResponse.Body.Artists = []*viewmodel.Artist{{ID: 1, Name: "Eminem", Version: 3, Albums: []*viewmodel.Album{{ID: 4, Name: "Recovery"}}}}
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("ListArtists", mock.Anything, []filter.Condition{}, &pagination.Params{Limit: 2, Sort: []pagination.Order{{Column: "name"}}}, []string(nil)).Return(page, nil)
+			},
+			requestViewmodel: &viewmodel.ListArtistsRequest{Request: pagination.Request{Limit: 2}},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: response,
+			},
+		},
+		"Expand albums": {
+			setupMock: func() {
+				suite.svc.On("ListArtists", mock.Anything, []filter.Condition{}, mock.Anything, []string{"Albums"}).Return(expandedPage, nil)
+			},
+			requestViewmodel: &viewmodel.ListArtistsRequest{Fieldset: fieldset.Request{Fields: "name,albums.name", Expand: "albums"}},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: expandedResponse,
+			},
+		},
+		"Unknown sort field": {
+			setupMock:        func() {},
+			requestViewmodel: &viewmodel.ListArtistsRequest{Request: pagination.Request{Sort: "password"}},
+			expected:         controllerTestExpected{isError: true},
+		},
+		"Nested field without expansion": {
+			setupMock:        func() {},
+			requestViewmodel: &viewmodel.ListArtistsRequest{Fieldset: fieldset.Request{Fields: "albums.name"}},
+			expected:         controllerTestExpected{isError: true},
+		},
+		"Error from ListArtists": {
+			setupMock: func() {
+				suite.svc.On("ListArtists", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errcode.ErrDatabase)
+			},
+			requestViewmodel: &viewmodel.ListArtistsRequest{},
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(listArtistsController(suite.svc)))
+}
+
+func (suite *ControllerSuiteTest) TestGetArtistController() {
+	response := &viewmodel.GetArtistResponse{}
+	response.Body.ID = 1
+	response.Body.Name = "Eminem"
+	response.Body.Version = 3
+	expandedResponse := &viewmodel.GetArtistResponse{}
+	expandedResponse.Body = response.Body
+	expandedResponse.Body.Albums = []*viewmodel.Album{{ID: 4, Name: "Recovery"}}
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("GetArtist", mock.Anything, uint(1), []string(nil)).Return(&models.Artist{Model: models.Model{ID: 1, Version: 3}, Name: "Eminem"}, nil)
+			},
+			requestViewmodel: &viewmodel.GetArtistRequest{ID: 1},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: response,
+				version:           3,
+			},
+		},
+		"Expand albums": {
+			setupMock: func() {
+				suite.svc.On("GetArtist", mock.Anything, uint(1), []string{"Albums"}).Return(&models.Artist{
+					Model:  models.Model{ID: 1, Version: 3},
+					Name:   "Eminem",
+					Albums: []*models.Album{{Model: models.Model{ID: 4}, Name: "Recovery"}},
+				}, nil)
+			},
+			requestViewmodel: &viewmodel.GetArtistRequest{ID: 1, Request: fieldset.Request{Fields: "name,albums.name", Expand: "albums"}},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: expandedResponse,
+			},
+		},
+		"Unknown expansion": {
+			setupMock:        func() {},
+			requestViewmodel: &viewmodel.GetArtistRequest{ID: 1, Request: fieldset.Request{Expand: "albums.tracks"}},
+			expected:         controllerTestExpected{isError: true},
+		},
+		"Unknown field": {
+			setupMock:        func() {},
+			requestViewmodel: &viewmodel.GetArtistRequest{ID: 1, Request: fieldset.Request{Fields: "password"}},
+			expected:         controllerTestExpected{isError: true},
+		},
+		"Nested field without expansion": {
+			setupMock:        func() {},
+			requestViewmodel: &viewmodel.GetArtistRequest{ID: 1, Request: fieldset.Request{Fields: "albums.name"}},
+			expected:         controllerTestExpected{isError: true},
+		},
+		"Error from GetArtist": {
+			setupMock: func() {
+				suite.svc.On("GetArtist", mock.Anything, uint(1), []string(nil)).Return(nil, errcode.ErrNotFound)
+			},
+			requestViewmodel: &viewmodel.GetArtistRequest{ID: 1},
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(getArtistController(suite.svc)))
+}
+
+func (suite *ControllerSuiteTest) TestUpdateArtistController() {
+	request := &viewmodel.UpdateArtistRequest{ID: 1}
+	request.Body.Name = "Slim Shady"
+	response := &viewmodel.UpdateArtistResponse{}
+	response.Body.ID = 1
+	response.Body.Name = "Slim Shady"
+	response.Body.Version = 4
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("UpdateArtist", mock.Anything, &models.Artist{Model: models.Model{ID: 1, Version: 3}, Name: "Slim Shady"}).
+					Run(func(args mock.Arguments) {
+						args.Get(1).(*models.Artist).Version = 4
+					}).
+					Return(nil)
+			},
+			requestViewmodel: request,
+			context:          map[string]interface{}{ContextKeyIfMatchVersion: uint(3)},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: response,
+				version:           4,
+			},
+		},
+		"Error from UpdateArtist": {
+			setupMock: func() {
+				suite.svc.On("UpdateArtist", mock.Anything, mock.Anything).Return(errcode.ErrPreconditionFailed)
+			},
+			requestViewmodel: request,
+			context:          map[string]interface{}{ContextKeyIfMatchVersion: uint(2)},
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(updateArtistController(suite.svc)))
+}
+
+func (suite *ControllerSuiteTest) TestDeleteArtistController() {
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("DeleteArtist", mock.Anything, &dto.DeleteArtist{ID: 1}).Return(nil)
+			},
+			requestViewmodel: &viewmodel.DeleteArtistRequest{ID: 1},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: &viewmodel.DeleteArtistResponse{},
+			},
+		},
+		"Success at version": {
+			setupMock: func() {
+				suite.svc.On("DeleteArtist", mock.Anything, &dto.DeleteArtist{ID: 1, Version: 3}).Return(nil)
+			},
+			requestViewmodel: &viewmodel.DeleteArtistRequest{ID: 1},
+			context:          map[string]interface{}{ContextKeyIfMatchVersion: uint(3)},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: &viewmodel.DeleteArtistResponse{},
+			},
+		},
+		"Success with reassign strategy": {
+			setupMock: func() {
+				suite.svc.On("DeleteArtist", mock.Anything, &dto.DeleteArtist{ID: 1, Strategy: dto.DeleteArtistReassign, ReassignTo: 2}).Return(nil)
+			},
+			requestViewmodel: &viewmodel.DeleteArtistRequest{ID: 1, Strategy: dto.DeleteArtistReassign, ReassignTo: 2},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: &viewmodel.DeleteArtistResponse{},
+			},
+		},
+		"Error from DeleteArtist": {
+			setupMock: func() {
+				suite.svc.On("DeleteArtist", mock.Anything, &dto.DeleteArtist{ID: 1}).Return(errcode.ErrConflict)
+			},
+			requestViewmodel: &viewmodel.DeleteArtistRequest{ID: 1},
+			expected:         controllerTestExpected{isError: true, status: http.StatusConflict},
+		},
+		"Artist not found": {
+			setupMock: func() {
+				suite.svc.On("DeleteArtist", mock.Anything, &dto.DeleteArtist{ID: 2}).Return(fmt.Errorf("%w: artist 2", errcode.ErrNotFound))
+			},
+			requestViewmodel: &viewmodel.DeleteArtistRequest{ID: 2},
+			expected:         controllerTestExpected{isError: true, status: http.StatusBadRequest},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(deleteArtistController(suite.svc)))
+}
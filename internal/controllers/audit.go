@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/services"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+)
+
+func registerAuditRoutes(group *gin.RouterGroup, svc services.ServiceInterface) {
+	Handle(group, http.MethodGet, "", listAuditController(svc))
+}
+
+// swagger:route GET /admin/audit admin listAuditController
+//
+// Endpoint for listing the audit logs of the mutations.
+//
+// responses:
+//
+//	200: listAuditController
+//	400: errorResponse
+//	401: errorResponse
+//	403: errorResponse
+func listAuditController(svc services.ServiceInterface) HandlerFunc[viewmodel.ListAuditRequest, viewmodel.ListAuditResponse] {
+	return func(ctx *gin.Context, request *viewmodel.ListAuditRequest) (*viewmodel.ListAuditResponse, int, error) {
+		response := &viewmodel.ListAuditResponse{}
+
+		logs, err := svc.ListAuditLogs(ctx.Request.Context(), &dto.AuditFilter{
+			ActorType:  request.ActorType,
+			ActorID:    request.ActorID,
+			Action:     request.Action,
+			EntityType: request.EntityType,
+			EntityID:   request.EntityID,
+			From:       request.From,
+			To:         request.To,
+			Limit:      request.Limit,
+			Offset:     request.Offset,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		response.Body.Logs = make([]*viewmodel.AuditLog, 0, len(logs))
+		for _, log := range logs {
+			response.Body.Logs = append(response.Body.Logs, &viewmodel.AuditLog{
+				ID:         log.ID,
+				CreatedAt:  log.CreatedAt,
+				ActorType:  log.ActorType,
+				ActorID:    log.ActorID,
+				Action:     log.Action,
+				EntityType: log.EntityType,
+				EntityID:   log.EntityID,
+				Changes:    json.RawMessage(log.Changes),
+				RequestID:  log.RequestID,
+				IP:         log.IP,
+			})
+		}
+
+		return response, http.StatusOK, nil
+	}
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ControllerSuiteTest) TestListAuditController() {
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	logs := []*models.AuditLog{{
+		ID:         1,
+		CreatedAt:  createdAt,
+		ActorType:  audit.ActorUser,
+		ActorID:    "1",
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityArtists,
+		EntityID:   2,
+		Changes:    `{"name":{"before":"Eminem","after":null}}`,
+		RequestID:  "request",
+		IP:         "127.0.0.1",
+	}}
+	response := &viewmodel.ListAuditResponse{}
+	response.Body.Logs = []*viewmodel.AuditLog{{
+		ID:         1,
+		CreatedAt:  createdAt,
+		ActorType:  audit.ActorUser,
+		ActorID:    "1",
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityArtists,
+		EntityID:   2,
+		Changes:    json.RawMessage(`{"name":{"before":"Eminem","after":null}}`),
+		RequestID:  "request",
+		IP:         "127.0.0.1",
+	}}
+	filter := &dto.AuditFilter{EntityType: audit.EntityArtists, EntityID: 2, Limit: 10}
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("ListAuditLogs", mock.Anything, filter).Return(logs, nil)
+			},
+			requestViewmodel: &viewmodel.ListAuditRequest{EntityType: audit.EntityArtists, EntityID: 2, Limit: 10},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: response,
+			},
+		},
+		"Error from ListAuditLogs": {
+			setupMock: func() {
+				suite.svc.On("ListAuditLogs", mock.Anything, filter).Return(nil, errcode.ErrDatabase)
+			},
+			requestViewmodel: &viewmodel.ListAuditRequest{EntityType: audit.EntityArtists, EntityID: 2, Limit: 10},
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(listAuditController(suite.svc)))
+}
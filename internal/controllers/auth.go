@@ -8,40 +8,39 @@ import (
 	"github.com/sarrooo/go-clean/internal/viewmodel"
 )
 
-func registerAuthRoutes(group *gin.RouterGroup, svc services.ServiceInterface) {
-	group.POST("/register", requestViewmodelMiddleware(&viewmodel.RegisterUserRequest{}), registerController(svc))
-	group.POST("/login", requestViewmodelMiddleware(&viewmodel.LoginUserRequest{}), loginController(svc))
+// Only the registration is idempotent, a replayed login would answer a token issued before, e.g. before a password change
+func registerAuthRoutes(group *gin.RouterGroup, svc services.ServiceInterface, idempotency gin.HandlerFunc) {
+	Handle(group, http.MethodPost, "/register", registerController(svc), idempotency)
+	Handle(group, http.MethodPost, "/login", loginController(svc))
 }
 
 // swagger:route POST /auth/register auth registerController
 //
 // Endpoint for user registration.
+// A request sent with an Idempotency-Key header is processed once, its retries with the same body get the same response.
 //
 // responses:
 //
 //	200: registerController
 //	400: errorResponse
-func registerController(svc services.ServiceInterface) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		request := ctx.MustGet(ContextKeyRequestViewmodel).(*viewmodel.RegisterUserRequest)
+//	409: errorResponse
+func registerController(svc services.ServiceInterface) HandlerFunc[viewmodel.RegisterUserRequest, viewmodel.RegisterUserResponse] {
+	return func(ctx *gin.Context, request *viewmodel.RegisterUserRequest) (*viewmodel.RegisterUserResponse, int, error) {
 		response := &viewmodel.RegisterUserResponse{}
 
-		user, err := svc.RegisterUser(&request.Body)
+		user, err := svc.RegisterUser(ctx.Request.Context(), &request.Body)
 		if err != nil {
-			ctx.Error(err)
-			return
+			return nil, 0, err
 		}
 
-		token, err := svc.GenerateToken(user)
+		token, err := svc.GenerateToken(ctx.Request.Context(), user)
 		if err != nil {
-			ctx.Error(err)
-			return
+			return nil, 0, err
 		}
 
 		response.Body.Token = token
 
-		ctx.Set(ContextKeyStatusCode, http.StatusOK)
-		ctx.Set(ContextKeyResponseViewmodel, response)
+		return response, http.StatusOK, nil
 	}
 }
 
@@ -53,26 +52,22 @@ func registerController(svc services.ServiceInterface) gin.HandlerFunc {
 //
 //	200: loginController
 //	400: errorResponse
-func loginController(svc services.ServiceInterface) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		request := ctx.MustGet(ContextKeyRequestViewmodel).(*viewmodel.LoginUserRequest)
+func loginController(svc services.ServiceInterface) HandlerFunc[viewmodel.LoginUserRequest, viewmodel.LoginUserResponse] {
+	return func(ctx *gin.Context, request *viewmodel.LoginUserRequest) (*viewmodel.LoginUserResponse, int, error) {
 		response := &viewmodel.LoginUserResponse{}
 
-		user, err := svc.LoginUser(request.Body.Email, request.Body.Password)
+		user, err := svc.LoginUser(ctx.Request.Context(), request.Body.Email, request.Body.Password)
 		if err != nil {
-			ctx.Error(err)
-			return
+			return nil, 0, err
 		}
 
-		token, err := svc.GenerateToken(user)
+		token, err := svc.GenerateToken(ctx.Request.Context(), user)
 		if err != nil {
-			ctx.Error(err)
-			return
+			return nil, 0, err
 		}
 
 		response.Body.Token = token
 
-		ctx.Set(ContextKeyStatusCode, http.StatusOK)
-		ctx.Set(ContextKeyResponseViewmodel, response)
+		return response, http.StatusOK, nil
 	}
 }
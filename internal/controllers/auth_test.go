@@ -7,6 +7,7 @@ import (
 	"github.com/sarrooo/go-clean/internal/errcode"
 	"github.com/sarrooo/go-clean/internal/models"
 	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
@@ -26,9 +27,8 @@ func (suite *ControllerSuiteTest) TestRegisterController() {
 	tests := controllerTestTable{
 		"Success": {
 			setupMock: func() {
-				suite.svc.On("RegisterUser", &sampleDtoUser).Return(sampleModelUser, nil)
-				suite.svc.On("GenerateToken", sampleModelUser).Return("token", nil)
-				suite.svc.On("GenerateRefreshToken", sampleModelUser).Return("refreshToken", nil)
+				suite.svc.On("RegisterUser", mock.Anything, &sampleDtoUser).Return(sampleModelUser, nil)
+				suite.svc.On("GenerateToken", mock.Anything, sampleModelUser).Return("token", nil)
 			},
 			requestViewmodel: &viewmodel.RegisterUserRequest{
 				Body: sampleDtoUser,
@@ -46,7 +46,7 @@ func (suite *ControllerSuiteTest) TestRegisterController() {
 		},
 		"Error from RegisterUser": {
 			setupMock: func() {
-				suite.svc.On("RegisterUser", &sampleDtoUser).Return(nil, errcode.ErrDatabase)
+				suite.svc.On("RegisterUser", mock.Anything, &sampleDtoUser).Return(nil, errcode.ErrDatabase)
 			},
 			requestViewmodel: &viewmodel.RegisterUserRequest{
 				Body: sampleDtoUser,
@@ -55,19 +55,8 @@ func (suite *ControllerSuiteTest) TestRegisterController() {
 		},
 		"Error from GenerateToken": {
 			setupMock: func() {
-				suite.svc.On("RegisterUser", &sampleDtoUser).Return(sampleModelUser, nil)
-				suite.svc.On("GenerateToken", sampleModelUser).Return("", errcode.ErrGenerateToken)
-			},
-			requestViewmodel: &viewmodel.RegisterUserRequest{
-				Body: sampleDtoUser,
-			},
-			expected: controllerTestExpected{isError: true},
-		},
-		"Error from GenerateRefreshToken": {
-			setupMock: func() {
-				suite.svc.On("RegisterUser", &sampleDtoUser).Return(sampleModelUser, nil)
-				suite.svc.On("GenerateToken", sampleModelUser).Return("token", nil)
-				suite.svc.On("GenerateRefreshToken", sampleModelUser).Return("", errcode.ErrGenerateToken)
+				suite.svc.On("RegisterUser", mock.Anything, &sampleDtoUser).Return(sampleModelUser, nil)
+				suite.svc.On("GenerateToken", mock.Anything, sampleModelUser).Return("", errcode.ErrGenerateToken)
 			},
 			requestViewmodel: &viewmodel.RegisterUserRequest{
 				Body: sampleDtoUser,
@@ -76,16 +65,15 @@ func (suite *ControllerSuiteTest) TestRegisterController() {
 		},
 	}
 
-	suite.executeTestTable(tests, registerController)
+	suite.executeTestTable(tests, handle(registerController(suite.svc)))
 }
 
 func (suite *ControllerSuiteTest) TestLoginController() {
 	tests := controllerTestTable{
 		"Success": {
 			setupMock: func() {
-				suite.svc.On("LoginUser", sampleDtoUser.Email, sampleDtoUser.Password).Return(sampleModelUser, nil)
-				suite.svc.On("GenerateToken", sampleModelUser).Return("token", nil)
-				suite.svc.On("GenerateRefreshToken", sampleModelUser).Return("refreshToken", nil)
+				suite.svc.On("LoginUser", mock.Anything, sampleDtoUser.Email, sampleDtoUser.Password).Return(sampleModelUser, nil)
+				suite.svc.On("GenerateToken", mock.Anything, sampleModelUser).Return("token", nil)
 			},
 			requestViewmodel: &viewmodel.LoginUserRequest{
 				Body: struct {
@@ -109,7 +97,7 @@ func (suite *ControllerSuiteTest) TestLoginController() {
 		},
 		"Error from LoginUser": {
 			setupMock: func() {
-				suite.svc.On("LoginUser", sampleDtoUser.Email, sampleDtoUser.Password).Return(nil, errcode.ErrDatabase)
+				suite.svc.On("LoginUser", mock.Anything, sampleDtoUser.Email, sampleDtoUser.Password).Return(nil, errcode.ErrDatabase)
 			},
 			requestViewmodel: &viewmodel.LoginUserRequest{
 				Body: struct {
@@ -124,25 +112,8 @@ func (suite *ControllerSuiteTest) TestLoginController() {
 		},
 		"Error from GenerateToken": {
 			setupMock: func() {
-				suite.svc.On("LoginUser", sampleDtoUser.Email, sampleDtoUser.Password).Return(sampleModelUser, nil)
-				suite.svc.On("GenerateToken", sampleModelUser).Return("", errcode.ErrGenerateToken)
-			},
-			requestViewmodel: &viewmodel.LoginUserRequest{
-				Body: struct {
-					Email    string "json:\"email\" binding:\"required,email\""
-					Password string "json:\"password\" binding:\"required,min=8,max=64\""
-				}{
-					Email:    sampleDtoUser.Email,
-					Password: sampleDtoUser.Password,
-				},
-			},
-			expected: controllerTestExpected{isError: true},
-		},
-		"Error from GenerateRefreshToken": {
-			setupMock: func() {
-				suite.svc.On("LoginUser", sampleDtoUser.Email, sampleDtoUser.Password).Return(sampleModelUser, nil)
-				suite.svc.On("GenerateToken", sampleModelUser).Return("token", nil)
-				suite.svc.On("GenerateRefreshToken", sampleModelUser).Return("", errcode.ErrGenerateToken)
+				suite.svc.On("LoginUser", mock.Anything, sampleDtoUser.Email, sampleDtoUser.Password).Return(sampleModelUser, nil)
+				suite.svc.On("GenerateToken", mock.Anything, sampleModelUser).Return("", errcode.ErrGenerateToken)
 			},
 			requestViewmodel: &viewmodel.LoginUserRequest{
 				Body: struct {
@@ -157,5 +128,5 @@ func (suite *ControllerSuiteTest) TestLoginController() {
 		},
 	}
 
-	suite.executeTestTable(tests, loginController)
+	suite.executeTestTable(tests, handle(loginController(suite.svc)))
 }
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/spf13/viper"
+)
+
+// Tag of the error of a bulk request with too many items
+const TagBulkMax = "bulk_max"
+
+// bulkMaxItems is BULK_MAX_ITEMS, the maximum number of items of a bulk request (1000 by default)
+func bulkMaxItems() int {
+	if viper.GetInt("BULK_MAX_ITEMS") <= 0 {
+		return 1000
+	}
+	return viper.GetInt("BULK_MAX_ITEMS")
+}
+
+// bulk is a bulk request whose items are validated one by one
+type bulk struct {
+	mode string
+	// Error of each item
+	errs []error
+	// Invalid fields of each invalid item
+	fields []map[string]string
+	// Indexes of the items to run
+	valid []int
+}
+
+// newBulk checks the number of items, and validates each item
+// In atomic mode, an invalid item aborts all the items
+func newBulk[T any](ctx *gin.Context, mode string, items []T) (*bulk, error) {
+	if maxItems := bulkMaxItems(); len(items) > maxItems {
+		setInvalidField(ctx, "items", TagBulkMax, strconv.Itoa(maxItems), fmt.Sprintf("items can't contain more than %d items", maxItems))
+		return nil, fmt.Errorf("%w: %d items", errcode.ErrInvalidParameters, len(items))
+	}
+
+	b := &bulk{
+		mode:   mode,
+		errs:   make([]error, len(items)),
+		fields: make([]map[string]string, len(items)),
+	}
+	invalid := -1
+	for i := range items {
+		err := binding.Validator.ValidateStruct(&items[i])
+		if err == nil {
+			b.valid = append(b.valid, i)
+			continue
+		}
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			b.fields[i] = invalidFields(ctx, verr)
+		}
+		b.errs[i] = fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+		if invalid == -1 {
+			invalid = i
+		}
+	}
+
+	if invalid != -1 && mode != dto.BulkBestEffort {
+		for _, i := range b.valid {
+			b.errs[i] = fmt.Errorf("%w: item %d is invalid", errcode.ErrBulkAborted, invalid)
+		}
+		b.valid = nil
+	}
+	return b, nil
+}
+
+// run executes the valid items, fn receives their indexes and returns their errors in the same order
+func (b *bulk) run(fn func(valid []int) (errs []error, err error)) error {
+	if len(b.valid) == 0 {
+		return nil
+	}
+	errs, err := fn(b.valid)
+	if err != nil {
+		return err
+	}
+	for j, i := range b.valid {
+		b.errs[i] = errs[j]
+	}
+	return nil
+}
+
+// response returns the result of each item, result completes the result of a succeeded item
+// The status code is 200 if all the items succeeded, 207 otherwise
+func (b *bulk) response(result func(i int, result *viewmodel.BulkResult)) (*viewmodel.BulkResponse, int) {
+	response := &viewmodel.BulkResponse{}
+	response.Body.Results = make([]*viewmodel.BulkResult, 0, len(b.errs))
+	for i, err := range b.errs {
+		itemResult := &viewmodel.BulkResult{Index: i, Status: http.StatusOK}
+		if err == nil {
+			result(i, itemResult)
+			response.Body.Succeeded++
+		} else {
+			statusCode, message, context := errorDetails(err, b.fields[i])
+			itemResult.Status = statusCode
+			itemResult.Error = &viewmodel.BulkError{Message: message, Context: context}
+			response.Body.Failed++
+		}
+		response.Body.Results = append(response.Body.Results, itemResult)
+	}
+
+	statusCode := http.StatusOK
+	if response.Body.Failed != 0 {
+		statusCode = http.StatusMultiStatus
+	}
+	return response, statusCode
+}
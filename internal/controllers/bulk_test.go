@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+)
+
+func bulkCreateArtistsRequest(mode string, names ...string) *viewmodel.BulkCreateArtistsRequest {
+	request := &viewmodel.BulkCreateArtistsRequest{}
+	request.Body.Mode = mode
+	for _, name := range names {
+		request.Body.Items = append(request.Body.Items, viewmodel.BulkCreateArtistItem{Name: name})
+	}
+	return request
+}
+
+func (suite *ControllerSuiteTest) TestBulkCreateArtistsController() {
+	created := func(args mock.Arguments) {
+		for i, artist := range args.Get(1).([]*models.Artist) {
+			artist.ID = uint(i + 1)
+			artist.Version = 1
+		}
+	}
+
+	tests := map[string]struct {
+		request        *viewmodel.BulkCreateArtistsRequest
+		setupMock      func()
+		expectedStatus int
+		// Status of each item
+		expected []int
+	}{
+		"Success": {
+			request: bulkCreateArtistsRequest("", "Eminem", "Dr. Dre"),
+			setupMock: func() {
+				suite.svc.On("BulkCreateArtists", mock.Anything, mock.Anything, "").Run(created).Return([]error{nil, nil}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expected:       []int{http.StatusOK, http.StatusOK},
+		},
+		"Failed item": {
+			request: bulkCreateArtistsRequest(dto.BulkAtomic, "Eminem", "Dr. Dre"),
+			setupMock: func() {
+				suite.svc.On("BulkCreateArtists", mock.Anything, mock.Anything, dto.BulkAtomic).Return([]error{errcode.ErrBulkAborted, errcode.ErrConflict}, nil)
+			},
+			expectedStatus: http.StatusMultiStatus,
+			expected:       []int{http.StatusFailedDependency, http.StatusConflict},
+		},
+		"Invalid item in atomic mode": {
+			request:        bulkCreateArtistsRequest(dto.BulkAtomic, "Eminem", ""),
+			setupMock:      func() {},
+			expectedStatus: http.StatusMultiStatus,
+			expected:       []int{http.StatusFailedDependency, http.StatusBadRequest},
+		},
+		"Invalid item in best effort mode": {
+			request: bulkCreateArtistsRequest(dto.BulkBestEffort, "", "Eminem"),
+			setupMock: func() {
+				suite.svc.On("BulkCreateArtists", mock.Anything, mock.MatchedBy(func(artists []*models.Artist) bool {
+					return len(artists) == 1 && artists[0].Name == "Eminem"
+				}), dto.BulkBestEffort).Run(created).Return([]error{nil}, nil)
+			},
+			expectedStatus: http.StatusMultiStatus,
+			expected:       []int{http.StatusBadRequest, http.StatusOK},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+			suite.ctx.Set(ContextKeyRequestViewmodel, test.request)
+
+			handle(bulkCreateArtistsController(suite.svc))(suite.ctx)
+
+			suite.svc.AssertExpectations(suite.T())
+			suite.Require().Empty(suite.ctx.Errors, "No error should have occurred")
+			suite.Assert().Equal(test.expectedStatus, suite.ctx.GetInt(ContextKeyStatusCode))
+			response := suite.ctx.MustGet(ContextKeyResponseViewmodel).(*viewmodel.BulkResponse)
+			suite.Require().Len(response.Body.Results, len(test.expected))
+			failed := 0
+			for i, status := range test.expected {
+				result := response.Body.Results[i]
+				suite.Assert().Equal(i, result.Index)
+				suite.Assert().Equal(status, result.Status, "Status of item %d should match", i)
+				if status == http.StatusOK {
+					suite.Assert().NotZero(result.ID, "Item %d should have an id", i)
+					suite.Assert().Nil(result.Error)
+				} else {
+					failed++
+					suite.Require().NotNil(result.Error, "Item %d should have an error", i)
+					suite.Assert().NotEmpty(result.Error.Message)
+				}
+			}
+			suite.Assert().Equal(failed, response.Body.Failed)
+			suite.Assert().Equal(len(test.expected)-failed, response.Body.Succeeded)
+		})
+	}
+
+	suite.Run("Too many items", func() {
+		viper.Set("BULK_MAX_ITEMS", 1)
+		defer viper.Set("BULK_MAX_ITEMS", nil)
+		suite.ctx.Set(ContextKeyRequestViewmodel, bulkCreateArtistsRequest("", "Eminem", "Dr. Dre"))
+
+		handle(bulkCreateArtistsController(suite.svc))(suite.ctx)
+
+		suite.Require().NotEmpty(suite.ctx.Errors, "Error expected")
+		suite.Assert().ErrorIs(suite.ctx.Errors.Last().Err, errcode.ErrInvalidParameters)
+		suite.Assert().Contains(suite.ctx.GetStringMapString(ContextKeyInvalidFields), "items")
+	})
+}
+
+func (suite *ControllerSuiteTest) TestBulkDeleteArtistsController() {
+	request := &viewmodel.BulkDeleteArtistsRequest{}
+	request.Body.Items = []viewmodel.BulkDeleteArtistItem{{ID: 1, Version: 2, Strategy: dto.DeleteArtistReassign, ReassignTo: 3}}
+	response := &viewmodel.BulkResponse{}
+	response.Body.Results = []*viewmodel.BulkResult{{Index: 0, Status: http.StatusOK, ID: 1}}
+	response.Body.Succeeded = 1
+	failedResponse := &viewmodel.BulkResponse{}
+	failedResponse.Body.Results = []*viewmodel.BulkResult{{
+		Index:  0,
+		Status: http.StatusPreconditionFailed,
+		Error:  &viewmodel.BulkError{Message: errcode.ErrPreconditionFailed.Error()},
+	}}
+	failedResponse.Body.Failed = 1
+	deleteArtists := []*dto.DeleteArtist{{ID: 1, Version: 2, Strategy: dto.DeleteArtistReassign, ReassignTo: 3}}
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("BulkDeleteArtists", mock.Anything, deleteArtists, "").Return([]error{nil}, nil)
+			},
+			requestViewmodel: request,
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: response,
+			},
+		},
+		"Failed item": {
+			setupMock: func() {
+				suite.svc.On("BulkDeleteArtists", mock.Anything, deleteArtists, "").Return([]error{errcode.ErrPreconditionFailed}, nil)
+			},
+			requestViewmodel: request,
+			expected: controllerTestExpected{
+				status:            http.StatusMultiStatus,
+				responseViewmodel: failedResponse,
+			},
+		},
+		"Error from BulkDeleteArtists": {
+			setupMock: func() {
+				suite.svc.On("BulkDeleteArtists", mock.Anything, deleteArtists, "").Return(nil, errcode.ErrInvalidParameters)
+			},
+			requestViewmodel: request,
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(bulkDeleteArtistsController(suite.svc)))
+}
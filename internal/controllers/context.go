@@ -22,4 +22,25 @@ const (
 
 	// Error
 	ContextKeyInvalidFields = "invalid_fields"
+
+	// Status codes of the errors of a group of routes, see errorStatusCodesMiddleware
+	ContextKeyErrorStatusCodes = "error_status_codes"
+
+	// Authenticated user
+	ContextKeyUser = "user"
+
+	// Request ID
+	ContextKeyRequestID = "request_id"
+
+	// Version of the entity of the response, sent as ETag
+	ContextKeyVersion = "version"
+
+	// Version of the If-Match header
+	ContextKeyIfMatchVersion = "if_match_version"
+
+	// Fields selected in the response body
+	ContextKeyFields = "fields"
+
+	// Codec of the response body, negotiated from the Accept header
+	ContextKeyCodec = "codec"
 )
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/docs"
+	"github.com/sarrooo/go-clean/internal/codec"
+	appconfig "github.com/sarrooo/go-clean/internal/config"
+	"github.com/sarrooo/go-clean/internal/openapi"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+)
+
+// Information of the OpenAPI document
+var openAPIInfo = openapi.Info{
+	Title:       "Go-Clean",
+	Description: "Documentation of Go-Clean API.",
+	Version:     "1.0.0",
+}
+
+// Paths of the documentation
+const (
+	openAPIPath    = "/openapi.json"
+	docsPath       = "/docs"
+	docsAssetsPath = "/docs/assets"
+)
+
+// The documentation page loads only the assets and the document of the server
+// Swagger UI sets inline styles and its stylesheet has data: icons
+const docsContentSecurityPolicy = "default-src 'self'; style-src 'self' 'unsafe-inline'; img-src 'self' data:"
+
+// OpenAPI returns the OpenAPI 3 document of the routes of the router
+func (rtr *Router) OpenAPI() *openapi.Document {
+	return openAPIDocument(rtr.engine)
+}
+
+// OpenAPIJSON returns the OpenAPI 3 document of the routes of the router, indented like docs/openapi.json
+func (rtr *Router) OpenAPIJSON() ([]byte, error) {
+	document, err := json.MarshalIndent(rtr.OpenAPI(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(document, '\n'), nil
+}
+
+// committedOpenAPI returns the committed OpenAPI document, docs/openapi.json
+// The contract is this document, not the one generated from the routes: a view model changed without
+// regenerating the document is a mismatch
+func committedOpenAPI() (*openapi.Document, error) {
+	document := &openapi.Document{}
+	if err := json.Unmarshal(docs.OpenAPI, document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// openAPIDocument returns the document of the routes of the engine
+// The routes registered by Handle have their view models, the others, e.g. the exports, only their path
+func openAPIDocument(engine *gin.Engine) *openapi.Document {
+	var routes []openapi.Route
+	for _, info := range engine.Routes() {
+		if info.Path == openAPIPath || info.Path == docsPath || strings.HasPrefix(info.Path, docsAssetsPath+"/") {
+			continue
+		}
+		if route, ok := operations.Load(info.Method + " " + info.Path); ok {
+			routes = append(routes, route.(openapi.Route))
+			continue
+		}
+		routes = append(routes, openapi.Route{Method: info.Method, Path: info.Path, OperationID: operationID(info.Handler)})
+	}
+	return openapi.Generate(&openapi.Options{
+		Info:       openAPIInfo,
+		MediaTypes: codec.MediaTypes(),
+		Error:      reflect.TypeOf(viewmodel.BadRequestErrorResponse{}),
+	}, routes)
+}
+
+// registerDocsRoutes serves the OpenAPI document of the routes, and its documentation page, outside production
+// It must be called after the other routes are registered, with their document
+func (rtr *Router) registerDocsRoutes(openAPI *openapi.Document) error {
+	if appconfig.IsProduction() {
+		return nil
+	}
+	document, err := json.Marshal(openAPI)
+	if err != nil {
+		return err
+	}
+	page, err := openapi.UI(openAPIInfo.Title, openAPIPath, docsAssetsPath)
+	if err != nil {
+		return err
+	}
+
+	rtr.engine.GET(openAPIPath, func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "application/json; charset=utf-8", document)
+	})
+	rtr.engine.GET(docsPath, func(ctx *gin.Context) {
+		ctx.Header("Content-Security-Policy", docsContentSecurityPolicy)
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", page)
+	})
+	rtr.engine.StaticFS(docsAssetsPath, http.FS(openapi.UIAssets()))
+	return nil
+}
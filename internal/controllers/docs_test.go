@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sarrooo/go-clean/docs"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDocsRoutes(t *testing.T) {
+	tests := map[string]struct {
+		path             string
+		expectedStatus   int
+		expectedType     string
+		expectedContains []string
+	}{
+		"OpenAPI document": {
+			path:             openAPIPath,
+			expectedStatus:   http.StatusOK,
+			expectedType:     "application/json; charset=utf-8",
+			expectedContains: []string{`"openapi":"3.0.3"`, `"/albums/{id}"`, `"operationId":"getArtistController"`, `"#/components/schemas/`},
+		},
+		"Documentation page": {
+			path:             docsPath,
+			expectedStatus:   http.StatusOK,
+			expectedType:     "text/html; charset=utf-8",
+			expectedContains: []string{"<title>Go-Clean</title>", `data-spec-url="/openapi.json"`, `src="/docs/assets/swagger-ui-bundle.js"`, `src="/docs/assets/docs.js"`},
+		},
+		"Documentation script": {
+			path:             docsAssetsPath + "/docs.js",
+			expectedStatus:   http.StatusOK,
+			expectedContains: []string{"SwaggerUIBundle(", "dataset.specUrl"},
+		},
+		"Swagger UI script": {
+			path:             docsAssetsPath + "/swagger-ui-bundle.js",
+			expectedStatus:   http.StatusOK,
+			expectedContains: []string{"SwaggerUIBundle"},
+		},
+		"Swagger UI stylesheet": {
+			path:           docsAssetsPath + "/swagger-ui.css",
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/css; charset=utf-8",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			NewRouter(zap.NewNop(), nil, nil).engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			if test.expectedType != "" {
+				assert.Equal(t, test.expectedType, recorder.Header().Get("Content-Type"))
+			}
+			for _, expected := range test.expectedContains {
+				assert.Contains(t, recorder.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestDocsRoutesProduction(t *testing.T) {
+	viper.Set("ENV", "production")
+	defer viper.Set("ENV", nil)
+
+	for _, route := range NewRouter(zap.NewNop(), nil, nil).engine.Routes() {
+		assert.NotContains(t, []string{openAPIPath, docsPath, docsAssetsPath + "/*filepath"}, route.Path, "Documentation should not be served in production")
+	}
+}
+
+// The committed document is the contract of the routes, it must be regenerated with them
+func TestCommittedOpenAPI(t *testing.T) {
+	generated, err := NewRouter(zap.NewNop(), nil, nil).OpenAPIJSON()
+	require.NoError(t, err)
+
+	assert.Equal(t, string(docs.OpenAPI), string(generated), "docs/openapi.json should be regenerated with make openapi")
+
+	_, err = committedOpenAPI()
+	assert.NoError(t, err, "docs/openapi.json should be a valid document")
+}
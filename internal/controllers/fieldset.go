@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/fieldset"
+)
+
+// fieldsetParams validates the fields and the expansions of a GET request
+// The selected fields are set in the context, responseViewmodelMiddleware keeps only them in the response
+// An invalid parameter is translated and set in the context, like the binding errors
+func fieldsetParams(ctx *gin.Context, request *fieldset.Request, body interface{}, expandable fieldset.Expandable) (*fieldset.Params, error) {
+	params, err := request.Params(body, expandable)
+	if err != nil {
+		return nil, fieldsetError(ctx, err)
+	}
+	if len(params.Fields) != 0 {
+		ctx.Set(ContextKeyFields, params.Fields)
+	}
+	return params, nil
+}
+
+// itemsFieldsetParams validates the fields and the expansions of a GET request answering a list
+// The fields are the ones of an item, e.g. fields=id,name keeps the id and the name of each item of the items field,
+// and the other fields of the body, e.g. the pagination, are kept
+func itemsFieldsetParams(ctx *gin.Context, request *fieldset.Request, body interface{}, items string, item interface{}, expandable fieldset.Expandable) (*fieldset.Params, error) {
+	params, err := request.Params(item, expandable)
+	if err != nil {
+		return nil, fieldsetError(ctx, err)
+	}
+	if len(params.Fields) != 0 {
+		var fields []string
+		for _, field := range fieldset.Fields(body) {
+			if field != items && !strings.Contains(field, ".") {
+				fields = append(fields, field)
+			}
+		}
+		for _, field := range params.Fields {
+			fields = append(fields, items+"."+field)
+		}
+		ctx.Set(ContextKeyFields, fields)
+	}
+	return params, nil
+}
+
+// fieldsetError sets the translated invalid parameter in the context and returns the error
+func fieldsetError(ctx *gin.Context, err error) error {
+	var fieldError *fieldset.FieldError
+	if errors.As(err, &fieldError) {
+		setInvalidField(ctx, fieldError.Field, fieldError.Tag, fieldError.Param, fieldError.Error())
+	}
+	return fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+}
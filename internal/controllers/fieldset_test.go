@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/sarrooo/go-clean/internal/fieldset"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsFieldsetParams(t *testing.T) {
+	ctx, _ := setupGinContext(http.MethodGet, "/artists", "", "")
+	response := &viewmodel.ListArtistsResponse{}
+
+	params, err := itemsFieldsetParams(ctx, &fieldset.Request{Fields: "id,albums.name", Expand: "albums"}, response.Body, "artists", viewmodel.Artist{}, artistExpandable)
+
+	require.NoError(t, err, "No error should have occurred")
+	assert.Equal(t, []string{"Albums"}, params.Preloads)
+	assert.Equal(t, []string{"pagination", "artists.id", "artists.albums.name"}, ctx.GetStringSlice(ContextKeyFields), "The fields should be selected in each artist, with the pagination")
+
+	ctx, _ = setupGinContext(http.MethodGet, "/artists", "", "")
+	_, err = itemsFieldsetParams(ctx, &fieldset.Request{Fields: "albums.name"}, response.Body, "artists", viewmodel.Artist{}, artistExpandable)
+
+	assert.Error(t, err, "A nested field should not be selected without its expansion")
+	assert.Contains(t, ctx.GetStringMapString(ContextKeyInvalidFields), "fields", "The invalid parameter should be set in the context")
+}
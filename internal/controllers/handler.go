@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/openapi"
+)
+
+// HandlerFunc is a controller of a request view model Req, it returns the response view model and its status code
+// A returned error is handled by the error handler middleware, e.g. errcode.ErrConflict is answered 409
+type HandlerFunc[Req, Resp any] func(ctx *gin.Context, request *Req) (response *Resp, statusCode int, err error)
+
+// Handle registers the controller of a route, its request view model is bound and validated before it is called,
+// see requestViewmodelMiddleware, and its response view model is sent by responseViewmodelMiddleware
+// The middlewares of the route, e.g. ifMatchMiddleware, are called before the binding
+// The view models of the route are recorded for its OpenAPI document, see openAPIDocument
+// A controller writing its response itself, e.g. a streamed file, is registered as a gin.HandlerFunc
+func Handle[Req, Resp any](group gin.IRoutes, method, relativePath string, handler HandlerFunc[Req, Resp], middlewares ...gin.HandlerFunc) {
+	if group, ok := group.(interface{ BasePath() string }); ok {
+		fullPath := joinPaths(group.BasePath(), relativePath)
+		operations.Store(method+" "+fullPath, openapi.Route{
+			Method:      method,
+			Path:        fullPath,
+			OperationID: operationID(runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()),
+			Request:     reflect.TypeOf(new(Req)),
+			Response:    reflect.TypeOf(new(Resp)),
+		})
+	}
+
+	handlers := make([]gin.HandlerFunc, 0, len(middlewares)+2)
+	handlers = append(handlers, middlewares...)
+	handlers = append(handlers, requestViewmodelMiddleware(new(Req)), handle(handler))
+	group.Handle(method, relativePath, handlers...)
+}
+
+// handle returns the gin handler of a controller, it is called with the view model bound by requestViewmodelMiddleware
+func handle[Req, Resp any](handler HandlerFunc[Req, Resp]) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		request := ctx.MustGet(ContextKeyRequestViewmodel).(*Req)
+
+		response, statusCode, err := handler(ctx, request)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		ctx.Set(ContextKeyStatusCode, statusCode)
+		ctx.Set(ContextKeyResponseViewmodel, response)
+	}
+}
+
+// operations are the routes registered by Handle with their view models, by method and full path, e.g. GET /albums/:id
+var operations sync.Map
+
+// joinPaths returns the full path of a route of a group, like gin it keeps the trailing slash of the route
+func joinPaths(basePath, relativePath string) string {
+	if relativePath == "" {
+		return basePath
+	}
+	fullPath := path.Join(basePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(fullPath, "/") {
+		return fullPath + "/"
+	}
+	return fullPath
+}
+
+// operationID returns the name of the controller of a handler from its function name,
+// e.g. getArtistController for github.com/sarrooo/go-clean/internal/controllers.getArtistController.func1
+func operationID(funcName string) string {
+	name := funcName[strings.LastIndex(funcName, "/")+1:]
+	if parts := strings.Split(name, "."); len(parts) > 1 {
+		return parts[1]
+	}
+	return name
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	tests := map[string]struct {
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"Success": {
+			path:           "/items/4?ids=1,2",
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"field":"4 [1 2]"}`,
+		},
+		"Invalid parameters": {
+			path:           "/items/4?limit=ten",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid parameters","context":{"limit":"limit must be a valid integer"}}`,
+		},
+		"Error of the controller": {
+			path:           "/items/409",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"conflict with an existing entity"}`,
+		},
+		"Error of a middleware": {
+			path:           "/items/4?forbidden=true",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"forbidden"}`,
+		},
+	}
+
+	engine := gin.New()
+	engine.Use(router.responseViewmodelMiddleware(), router.errorHandlerMiddleware())
+	forbidden := func(ctx *gin.Context) {
+		if ctx.Query("forbidden") != "" {
+			ctx.Error(errcode.ErrForbidden)
+			ctx.Abort()
+		}
+	}
+	Handle(engine, http.MethodGet, "/items/:id", func(ctx *gin.Context, request *viewmodel.TestParamsViewModelRequest) (*viewmodel.TestViewModelResponse, int, error) {
+		if request.ID == 409 {
+			return nil, 0, fmt.Errorf("%w: item %d", errcode.ErrConflict, request.ID)
+		}
+		response := &viewmodel.TestViewModelResponse{}
+		response.Body.Field = strconv.FormatUint(uint64(request.ID), 10) + " " + fmt.Sprint(request.IDs)
+		return response, http.StatusCreated, nil
+	}, forbidden)
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.JSONEq(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+)
+
+// ReadinessProbe tells if a dependency is ready to serve requests
+type ReadinessProbe interface {
+	Ready(ctx context.Context) error
+}
+
+func registerHealthRoutes(group *gin.RouterGroup, probes map[string]ReadinessProbe) {
+	Handle(group, http.MethodGet, "/live", livenessController())
+	Handle(group, http.MethodGet, "/ready", readinessController(probes))
+}
+
+// swagger:route GET /health/live health livenessController
+//
+// Endpoint for liveness probes, the server is up.
+//
+// responses:
+//
+//	200: livenessController
+func livenessController() HandlerFunc[struct{}, viewmodel.LivenessResponse] {
+	return func(ctx *gin.Context, _ *struct{}) (*viewmodel.LivenessResponse, int, error) {
+		response := &viewmodel.LivenessResponse{}
+		response.Body.Status = "ok"
+
+		return response, http.StatusOK, nil
+	}
+}
+
+// swagger:route GET /health/ready health readinessController
+//
+// Endpoint for readiness probes, all dependencies are ready.
+// Error details are not sent to the client, they are logged by the dependency.
+//
+// responses:
+//
+//	200: readinessController
+//	503: readinessController
+func readinessController(probes map[string]ReadinessProbe) HandlerFunc[struct{}, viewmodel.ReadinessResponse] {
+	return func(ctx *gin.Context, _ *struct{}) (*viewmodel.ReadinessResponse, int, error) {
+		response := &viewmodel.ReadinessResponse{}
+		response.Body.Status = "ok"
+		response.Body.Checks = make(map[string]string, len(probes))
+		statusCode := http.StatusOK
+
+		for name, probe := range probes {
+			response.Body.Checks[name] = "ok"
+			if err := probe.Ready(ctx.Request.Context()); err != nil {
+				response.Body.Checks[name] = "unavailable"
+				response.Body.Status = "unavailable"
+				statusCode = http.StatusServiceUnavailable
+			}
+		}
+
+		return response, statusCode, nil
+	}
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/sarrooo/go-clean/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ControllerSuiteTest) TestReadinessController() {
+	databaseProbe := &mocks.ReadinessProbe{}
+
+	readyResponse := &viewmodel.ReadinessResponse{}
+	readyResponse.Body.Status = "ok"
+	readyResponse.Body.Checks = map[string]string{"database": "ok"}
+
+	unavailableResponse := &viewmodel.ReadinessResponse{}
+	unavailableResponse.Body.Status = "unavailable"
+	unavailableResponse.Body.Checks = map[string]string{"database": "unavailable"}
+
+	tests := map[string]struct {
+		probeErr error
+		expected controllerTestExpected
+	}{
+		"Ready": {
+			probeErr: nil,
+			expected: controllerTestExpected{
+				responseViewmodel: readyResponse,
+				status:            http.StatusOK,
+			},
+		},
+		"Database unavailable": {
+			probeErr: errors.New("connection refused"),
+			expected: controllerTestExpected{
+				responseViewmodel: unavailableResponse,
+				status:            http.StatusServiceUnavailable,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			databaseProbe.ExpectedCalls = nil
+			databaseProbe.EXPECT().Ready(mock.Anything).Return(test.probeErr).Once()
+
+			response, statusCode, err := readinessController(map[string]ReadinessProbe{"database": databaseProbe})(suite.ctx, &struct{}{})
+
+			databaseProbe.AssertExpectations(suite.T())
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expected.status, statusCode)
+			suite.Assert().Equal(test.expected.responseViewmodel, response)
+		})
+	}
+}
@@ -2,12 +2,19 @@ package controllers
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -15,79 +22,108 @@ import (
 	"github.com/go-playground/validator/v10"
 	en_translations "github.com/go-playground/validator/v10/translations/en"
 	fr_translations "github.com/go-playground/validator/v10/translations/fr"
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/codec"
+	appconfig "github.com/sarrooo/go-clean/internal/config"
+	"github.com/sarrooo/go-clean/internal/database"
+	"github.com/sarrooo/go-clean/internal/dto"
 	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/fieldset"
+	"github.com/sarrooo/go-clean/internal/filter"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/openapi"
+	"github.com/sarrooo/go-clean/internal/params"
+	"github.com/sarrooo/go-clean/internal/services"
 	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"golang.org/x/text/language"
 )
 
 // Bind request view model and pass it to the next handler
 // It use the gin method to bind, please check `ShouldBind` documentation
-// WARNING : It not handle URI parameters
+// The filterable fields (see filter.Field) are bound from the query string
+// The bodies of the other codecs (see codec.ForContentType) are converted to JSON, then bound like JSON
+// The fields tagged with uri, query or header are bound from the parameters of the request, see params.Bind
 func requestViewmodelMiddleware(requestViewmodel interface{}) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// Read the body and rewrite it with body key
 		// It's because we use go-swagger annotation
 		// and the body annotation must be in Body struct
+		// A multipart form is bound field by field with the form tags, it is not rewritten
 		requestBody, err := io.ReadAll(ctx.Request.Body)
 		if err != nil {
-			ctx.Error(fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err))
+			ctx.Error(bodyError(err))
 			ctx.Abort()
 			return
 		}
-		transformedBody := []byte(`{"body":` + string(requestBody) + `}`)
-		ctx.Request.Body = io.NopCloser(bytes.NewReader(transformedBody))
+		contentType := ctx.GetHeader("Content-Type")
+		if ctx.ContentType() == binding.MIMEMultipartPOSTForm {
+			ctx.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+		} else {
+			jsonBody, err := requestJSONBody(ctx, requestBody, requestViewmodel)
+			if err != nil {
+				ctx.Error(err)
+				ctx.Abort()
+				return
+			}
+			transformedBody := []byte(`{"body":` + string(jsonBody) + `}`)
+			ctx.Request.Body = io.NopCloser(bytes.NewReader(transformedBody))
+		}
 
 		// Create a new instance of requestViewmodel and bind it
 		requestViewmodelInstance := reflect.New(reflect.TypeOf(requestViewmodel).Elem()).Interface()
 
-		// Bind URI tagged fields
-		// These will be validated by the validator in Gin binding methods
-		err = bindURITaggedFields(ctx, requestViewmodelInstance)
+		// Bind the fields tagged with uri, query or header
+		// These will be validated by the validator in Gin binding methods, the values which can't be parsed are reported with them
+		paramsErr := params.Bind(&params.Request{
+			URI:    uriParams(ctx),
+			Query:  ctx.Request.URL.Query(),
+			Header: ctx.Request.Header,
+		}, requestViewmodelInstance)
+
+		// Bind the filterable fields of the query string
+		err = filter.Bind(ctx.Request.URL.Query(), requestViewmodelInstance)
 		if err != nil {
-			ctx.Error(err)
+			var fieldError *filter.FieldError
+			if errors.As(err, &fieldError) {
+				setInvalidField(ctx, fieldError.Field, fieldError.Tag, fieldError.Param, fieldError.Error())
+			}
+			ctx.Error(fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err))
 			ctx.Abort()
 			return
 		}
 
 		// Use Gin binding methods
 		// It choose the binding method according to the method and "Content-Type" header
-		if err := ctx.ShouldBind(requestViewmodelInstance); err != nil {
-			// Rewrite the body with the original body
-			ctx.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+		err = ctx.ShouldBind(requestViewmodelInstance)
+
+		// Rewrite the body with the original body
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+		ctx.Request.Header.Set("Content-Type", contentType)
 
-			// Check if the error is a validation error
+		if err != nil || paramsErr != nil {
+			// Set the failed fields in the context, it will be used by the error handler middleware
+			failedFields := map[string]string{}
 			var verr validator.ValidationErrors
 			if errors.As(err, &verr) {
-				// Get the translator from the context and translate the errors messages
-				translatedErrors := make(map[string]string, len(verr))
-				trans, exists := ctx.Get(ContextKeyTranslator)
-				if exists {
-					trans := trans.(ut.Translator)
-					translatedErrors = verr.Translate(trans)
-				}
-
-				// Create a map to hold the failed fields
-				failedFields := make(map[string]string, len(verr))
-				for _, fieldError := range verr {
-					// Get the translated error message
-					// If there is no translation, use the default error message
-					validationMessage := translatedErrors[fieldError.Namespace()]
-					if validationMessage == "" {
-						validationMessage = fieldError.Error()
-					}
-					failedFields[fieldError.Field()] = validationMessage
+				failedFields = invalidFields(ctx, verr)
+			}
+			// A parameter which can't be parsed has no other error, e.g. required
+			var paramsErrs params.Errors
+			if errors.As(paramsErr, &paramsErrs) {
+				for _, fieldError := range paramsErrs {
+					message := fmt.Sprintf("%s must be a valid %s", fieldError.Field, fieldError.Param)
+					failedFields[fieldError.Field] = translateField(ctx, fieldError.Field, fieldError.Tag, fieldError.Param, message)
 				}
-				// Set the failed fields in the context, it will be used by the error handler middleware
+			}
+			if len(failedFields) != 0 {
 				ctx.Set(ContextKeyInvalidFields, failedFields)
 			}
-			err = fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
-			ctx.Error(err)
+			ctx.Error(fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, errors.Join(paramsErr, err)))
 			ctx.Abort()
 			return
 		}
-		// Rewrite the body with the original body
-		ctx.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
 
 		// Set binding result in Gin context
 		ctx.Set(ContextKeyRequestViewmodel, requestViewmodelInstance)
@@ -95,75 +131,369 @@ func requestViewmodelMiddleware(requestViewmodel interface{}) gin.HandlerFunc {
 	}
 }
 
-// Bind URI tagged fields
-// It use the Gin params to get the URI parameters
-func bindURITaggedFields(ctx *gin.Context, data interface{}) error {
-	val := reflect.ValueOf(data).Elem()
-	typ := val.Type()
+// bodyError returns the error of a request body which can't be read, a body larger than its limit is too large
+func bodyError(err error) error {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return fmt.Errorf("%w: the body is larger than %d bytes", errcode.ErrRequestTooLarge, maxBytesError.Limit)
+	}
+	return fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+}
 
-	for i := 0; i < val.NumField(); i++ {
-		field := val.Field(i)
-		tag := typ.Field(i).Tag.Get("uri")
+// requestJSONBody returns the JSON of a request body, bound to the Body field of the request view model
+// A body of another codec is converted to JSON, and its Content-Type is JSON until the end of the binding
+func requestJSONBody(ctx *gin.Context, requestBody []byte, requestViewmodel interface{}) ([]byte, error) {
+	contentType := ctx.ContentType()
+	if len(requestBody) == 0 || contentType == "" || contentType == binding.MIMEJSON {
+		return requestBody, nil
+	}
+	c, ok := codec.ForContentType(contentType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errcode.ErrUnsupportedMediaType, contentType)
+	}
 
-		if tag == "" {
-			continue
-		}
+	var bodyType reflect.Type
+	if field, ok := reflect.TypeOf(requestViewmodel).Elem().FieldByName("Body"); ok {
+		bodyType = field.Type
+	}
+	jsonBody, err := codec.ToJSON(c, requestBody, bodyType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+	}
+	ctx.Request.Header.Set("Content-Type", binding.MIMEJSON)
+	return jsonBody, nil
+}
 
-		paramValue, exists := ctx.Params.Get(tag)
-		if !exists {
-			continue
-		}
+// invalidFields returns the translated message of each failed field of the validation errors
+func invalidFields(ctx *gin.Context, verr validator.ValidationErrors) map[string]string {
+	// Get the translator from the context and translate the errors messages
+	translatedErrors := make(map[string]string, len(verr))
+	trans, exists := ctx.Get(ContextKeyTranslator)
+	if exists {
+		trans := trans.(ut.Translator)
+		translatedErrors = verr.Translate(trans)
+	}
 
-		// You might need additional type conversion logic based on the actual field type
-		switch field.Kind() {
-		case reflect.String:
-			field.SetString(paramValue)
-		case reflect.Uint:
-			// Assuming the parameter is an uinteger
-			paramInt, err := strconv.ParseUint(paramValue, 10, 32)
-			if err != nil {
-				return errcode.ErrInvalidParameters
-			}
-			field.SetUint(paramInt)
-		case reflect.Int:
-			// Assuming the parameter is an integer
-			paramInt, err := strconv.Atoi(paramValue)
-			if err != nil {
-				return errcode.ErrInvalidParameters
-			}
-			field.SetInt(int64(paramInt))
-		default:
-			continue
+	// Create a map to hold the failed fields
+	failedFields := make(map[string]string, len(verr))
+	for _, fieldError := range verr {
+		// Get the translated error message
+		// If there is no translation, use the default error message
+		validationMessage := translatedErrors[fieldError.Namespace()]
+		if validationMessage == "" {
+			validationMessage = fieldError.Error()
 		}
+		failedFields[fieldError.Field()] = validationMessage
 	}
-	return nil
+	return failedFields
 }
 
-// This middleware get the response view model from the Gin context and send it
+// uriParams returns the URI parameters of the route, e.g. id of /albums/:id
+func uriParams(ctx *gin.Context) map[string]string {
+	uri := make(map[string]string, len(ctx.Params))
+	for _, param := range ctx.Params {
+		uri[param.Key] = param.Value
+	}
+	return uri
+}
+
+// This middleware get the response view model from the Gin context and send it, unless the controller wrote the response
+// The body is encoded with the codec negotiated by negotiationMiddleware, JSON by default
+// Successful responses have an ETag: the version set by the controller in the context,
+// or a hash of the body for GET requests. A GET matching If-None-Match is answered 304 Not Modified
+// Only the fields selected by the controller (ContextKeyFields) are sent
 func (rtr *Router) responseViewmodelMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		ctx.Next()
 
+		// A streamed response is already sent by the controller
+		if ctx.Writer.Written() {
+			return
+		}
+
 		// Check if there is a responseViewmodel in the context
 		if responseViewmodel, exist := ctx.Get(ContextKeyResponseViewmodel); exist {
 			statusCode := ctx.GetInt(ContextKeyStatusCode)
+			success := statusCode >= 200 && statusCode < 300
+
+			// The errors of a request without negotiated codec, e.g. 406 Not Acceptable, are sent in JSON
+			responseCodec := codec.JSON
+			if c, ok := ctx.Get(ContextKeyCodec); ok {
+				responseCodec = c.(*codec.Codec)
+			}
 
-			value := reflect.ValueOf(responseViewmodel)
-			if value.Kind() == reflect.Ptr && !value.IsNil() {
-				// Check if responseViewmodel has a Body field
-				bodyField := value.Elem().FieldByName("Body")
-				if bodyField.IsValid() {
-					ctx.JSON(statusCode, bodyField.Interface())
-					return
+			body, hasBody, err := responseBody(responseViewmodel)
+			if err == nil && hasBody && success {
+				if fields, ok := ctx.Get(ContextKeyFields); ok {
+					body, err = fieldset.Select(body, fields.([]string))
 				}
-				// If responseViewmodel has no Body field, send just the status code
-				ctx.Status(statusCode)
 			}
+			if err == nil && hasBody {
+				body, err = codec.FromJSON(responseCodec, body)
+			}
+			if err != nil {
+				rtr.logger.Error("response marshaling failed", zap.Error(err))
+				ctx.Status(http.StatusInternalServerError)
+				return
+			}
+			if hasBody {
+				if success {
+					tag := responseETag(ctx, body)
+					if tag != "" {
+						ctx.Header("ETag", tag)
+					}
+					if tag != "" && ctx.Request.Method == http.MethodGet && etagMatches(ctx.GetHeader("If-None-Match"), tag) {
+						ctx.Status(http.StatusNotModified)
+						return
+					}
+				}
+				ctx.Data(statusCode, responseCodec.ContentType, body)
+				return
+			}
+			// If responseViewmodel has no Body field, send just the status code
+			ctx.Status(statusCode)
 		}
 		ctx.Status(http.StatusBadRequest)
 	}
 }
 
+// responseBody returns the JSON of the Body field of a response view model
+// hasBody is false if the view model is not a pointer to a struct with a Body field
+func responseBody(responseViewmodel interface{}) (body []byte, hasBody bool, err error) {
+	value := reflect.ValueOf(responseViewmodel)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil, false, nil
+	}
+	bodyField := value.Elem().FieldByName("Body")
+	if !bodyField.IsValid() {
+		return nil, false, nil
+	}
+	body, err = json.Marshal(bodyField.Interface())
+	if err != nil {
+		return nil, true, err
+	}
+	return body, true, nil
+}
+
+// etag returns the entity tag of a version
+func etag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// responseETag returns the ETag of a successful response, empty if it has none
+func responseETag(ctx *gin.Context, body []byte) string {
+	if version, ok := ctx.Get(ContextKeyVersion); ok {
+		return etag(version.(uint))
+	}
+	if ctx.Request.Method == http.MethodGet {
+		hash := sha256.Sum256(body)
+		return `"` + hex.EncodeToString(hash[:16]) + `"`
+	}
+	return ""
+}
+
+// etagMatches tells if the tag is one of the entity tags of an If-None-Match header
+// It is the weak comparison, W/ prefixes are ignored
+func etagMatches(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate the codec of the response body from the Accept header, see codec.Negotiate
+// A request accepting none of the codecs is answered 406 Not Acceptable, in JSON
+func negotiationMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Writer.Header().Add("Vary", "Accept")
+		responseCodec, err := codec.Negotiate(ctx.GetHeader("Accept"))
+		if err != nil {
+			ctx.Error(fmt.Errorf("%w: %v", errcode.ErrNotAcceptable, err))
+			ctx.Abort()
+			return
+		}
+		ctx.Set(ContextKeyCodec, responseCodec)
+		ctx.Next()
+	}
+}
+
+// Require the If-Match header on the writes of a versioned entity
+// The version of its entity tag is set in the context for the controller, 0 for `If-Match: *`
+func ifMatchMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+		if header == "" {
+			ctx.Error(fmt.Errorf("%w", errcode.ErrPreconditionRequired))
+			ctx.Abort()
+			return
+		}
+		if header == "*" {
+			ctx.Set(ContextKeyIfMatchVersion, uint(0))
+			ctx.Next()
+			return
+		}
+
+		// A version is a strong entity tag, e.g. "3"
+		version, err := strconv.ParseUint(strings.Trim(header, `"`), 10, 32)
+		if err != nil || version == 0 || header != etag(uint(version)) {
+			ctx.Error(fmt.Errorf("%w: If-Match %s is not a version", errcode.ErrPreconditionFailed, header))
+			ctx.Abort()
+			return
+		}
+		ctx.Set(ContextKeyIfMatchVersion, uint(version))
+		ctx.Next()
+	}
+}
+
+// Headers of idempotent requests
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+)
+
+// Maximum length of an idempotency key
+const idempotencyKeyMaxLength = 255
+
+// Make POST requests sent with an Idempotency-Key header idempotent
+// The successful response of the first request is stored and replayed to its retries,
+// a retry sent while the first request is in progress waits for its response
+// A failed request releases its key, so it can be retried
+// It must be used after the authentication middlewares: keys are scoped by endpoint and actor
+// The anonymous callers share their keys, so the key of an anonymous request is also scoped by its fingerprint:
+// only a retry with the same payload is replayed
+func (rtr *Router) idempotencyMiddleware(svc services.ServiceInterface) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := strings.TrimSpace(ctx.GetHeader(idempotencyKeyHeader))
+		if ctx.Request.Method != http.MethodPost || key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > idempotencyKeyMaxLength {
+			ctx.Error(fmt.Errorf("%w: %s is longer than %d characters", errcode.ErrInvalidParameters, idempotencyKeyHeader, idempotencyKeyMaxLength))
+			ctx.Abort()
+			return
+		}
+
+		requestBody, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.Error(bodyError(err))
+			ctx.Abort()
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+
+		fingerprint := requestFingerprint(ctx.Request.Method, ctx.Request.URL.RequestURI(), requestBody)
+		actor := audit.FromContext(ctx.Request.Context()).Actor
+		owner := actor.Type + ":" + actor.ID
+		if actor.Type == audit.ActorAnonymous {
+			owner = actor.Type + ":" + fingerprint
+		}
+		request := &dto.IdempotentRequest{
+			Scope:       ctx.Request.Method + " " + ctx.FullPath() + " " + owner,
+			Key:         key,
+			Fingerprint: fingerprint,
+		}
+		replay, err := svc.BeginIdempotentRequest(ctx.Request.Context(), request)
+		if err != nil {
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		if replay != nil {
+			ctx.Header(idempotencyReplayedHeader, "true")
+			ctx.Set(ContextKeyStatusCode, replay.StatusCode)
+			ctx.Set(ContextKeyResponseViewmodel, &viewmodel.ReplayedResponse{Body: replay.Body})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+
+		// The key is released or completed even if the client is gone, a retry must not find it in progress
+		storeCtx := context.WithoutCancel(ctx.Request.Context())
+		responseViewmodel, _ := ctx.Get(ContextKeyResponseViewmodel)
+		body, hasBody, err := responseBody(responseViewmodel)
+		if len(ctx.Errors) != 0 || !hasBody || err != nil {
+			if err := svc.ReleaseIdempotentRequest(storeCtx, request); err != nil {
+				rtr.logger.Error("idempotency key release failed", zap.Error(err), zap.String("key", key))
+			}
+			return
+		}
+		err = svc.CompleteIdempotentRequest(storeCtx, request, &dto.IdempotentResponse{
+			StatusCode: ctx.GetInt(ContextKeyStatusCode),
+			Body:       body,
+		})
+		if err != nil {
+			rtr.logger.Error("idempotency key completion failed", zap.Error(err), zap.String("key", key))
+		}
+	}
+}
+
+// requestFingerprint returns the hash of a request, a key reused with another fingerprint is refused
+func requestFingerprint(method, uri string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + uri + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// HTTP status codes of errors, other GoCleanErrors are bad requests
+var errorStatusCodes = map[error]int{
+	errcode.ErrUnauthorized:   http.StatusUnauthorized,
+	errcode.ErrInvalidToken:   http.StatusUnauthorized,
+	errcode.ErrTokenExpirated: http.StatusUnauthorized,
+	errcode.ErrForbidden:      http.StatusForbidden,
+	errcode.ErrConflict:       http.StatusConflict,
+
+	errcode.ErrIdempotencyKeyReused: http.StatusConflict,
+	errcode.ErrIdempotencyInFlight:  http.StatusConflict,
+
+	errcode.ErrBulkAborted: http.StatusFailedDependency,
+
+	errcode.ErrNotAcceptable:        http.StatusNotAcceptable,
+	errcode.ErrUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	errcode.ErrRequestTooLarge:      http.StatusRequestEntityTooLarge,
+
+	errcode.ErrPreconditionFailed:   http.StatusPreconditionFailed,
+	errcode.ErrPreconditionRequired: http.StatusPreconditionRequired,
+}
+
+func errorStatusCode(err error) int {
+	for target, statusCode := range errorStatusCodes {
+		if errors.Is(err, target) {
+			return statusCode
+		}
+	}
+	return http.StatusBadRequest
+}
+
+// errorStatusCodesMiddleware answers errors of a group of routes with their own status codes, instead of errorStatusCodes
+// e.g. the trash routes answer 404 to a missing entity, which the other routes answer 400
+func errorStatusCodesMiddleware(statusCodes map[error]int) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(ContextKeyErrorStatusCodes, statusCodes)
+		ctx.Next()
+	}
+}
+
+// routeErrorStatusCode returns the status code of the error set by errorStatusCodesMiddleware, statusCode otherwise
+// An internal error keeps its status code
+func routeErrorStatusCode(ctx *gin.Context, err error, statusCode int) int {
+	statusCodes, ok := ctx.Get(ContextKeyErrorStatusCodes)
+	if !ok || statusCode == http.StatusInternalServerError {
+		return statusCode
+	}
+	for target, routeStatusCode := range statusCodes.(map[error]int) {
+		if errors.Is(err, target) {
+			return routeStatusCode
+		}
+	}
+	return statusCode
+}
+
 // It the centralized error handling middleware
 // When an error occured in handler, just set the error with `c.Error(...)` and return
 // The middleware will handle error, log it, answer to the client
@@ -180,44 +510,110 @@ func (rtr *Router) errorHandlerMiddleware() gin.HandlerFunc {
 
 			rtr.logger.Error("middleware error",
 				zap.Error(err.Err),
+				zap.String("request_id", ctx.GetString(ContextKeyRequestID)),
 				zap.String("path", ctx.Request.URL.Path),
 				zap.String("method", ctx.Request.Method),
 				zap.String("ip", ctx.ClientIP()),
 				zap.String("body", string(body)))
 
-			// check if the error contain a GoCleanError
-			// if yes, return the first GoCleanError to the client
-			// if no, return a generic error
-			// This strategy limit the informations given to the client
-			var GoCleanError errcode.GoCleanError
-			if errors.As(err.Err, &GoCleanError) {
-				// Define the response viewmodel
-				response := &viewmodel.BadRequestErrorResponse{}
-				response.Body.Message = GoCleanError.Error()
-
-				// Check if the error is an invalid parameters error
-				if errors.Is(GoCleanError, errcode.ErrInvalidParameters) {
-					// Extract the failed fields from the error message
-					failedFields := ctx.GetStringMapString(ContextKeyInvalidFields)
-					if len(failedFields) != 0 {
-						response.Body.Context = failedFields
-					}
-				}
-				ctx.Set(ContextKeyStatusCode, http.StatusBadRequest)
+			statusCode, message, context := errorDetails(err.Err, ctx.GetStringMapString(ContextKeyInvalidFields))
+			statusCode = routeErrorStatusCode(ctx, err.Err, statusCode)
+			if statusCode == http.StatusInternalServerError {
+				response := &viewmodel.InternalServerErrorResponse{}
+				response.Body.Message = message
+				ctx.Set(ContextKeyStatusCode, statusCode)
 				ctx.Set(ContextKeyResponseViewmodel, response)
 				return
 			}
 
-			// If the error is not a GoCleanError, return a generic error
-			response := &viewmodel.InternalServerErrorResponse{}
-			response.Body.Message = "internal error"
-			ctx.Set(ContextKeyStatusCode, http.StatusInternalServerError)
+			// Define the response viewmodel
+			response := &viewmodel.BadRequestErrorResponse{}
+			response.Body.Message = message
+			response.Body.Context = context
+			ctx.Set(ContextKeyStatusCode, statusCode)
 			ctx.Set(ContextKeyResponseViewmodel, response)
 			return
 		}
 	}
 }
 
+// errorDetails returns the HTTP status code, the message and the context of an error sent to the client
+// invalidFields is the context of an invalid parameters error
+func errorDetails(err error, invalidFields map[string]string) (statusCode int, message string, context map[string]string) {
+	// check if the error contain a GoCleanError
+	// if yes, return the first GoCleanError to the client
+	// if no, return a generic error
+	// This strategy limit the informations given to the client
+	var GoCleanError errcode.GoCleanError
+	if !errors.As(err, &GoCleanError) {
+		return http.StatusInternalServerError, "internal error", nil
+	}
+
+	// Check if the error is an invalid parameters error
+	if errors.Is(GoCleanError, errcode.ErrInvalidParameters) && len(invalidFields) != 0 {
+		context = invalidFields
+	}
+	var contextError *errcode.ContextError
+	if errors.As(err, &contextError) {
+		context = contextError.Context
+	}
+	return errorStatusCode(GoCleanError), GoCleanError.Error(), context
+}
+
+// Validate the requests, and outside production the responses of the controllers, against the OpenAPI document
+// It is enabled by OPENAPI_VALIDATION, a mismatch is logged as a warning and the request is handled anyway
+func (rtr *Router) contractValidationMiddleware() gin.HandlerFunc {
+	validateResponses := !appconfig.IsProduction()
+	return func(ctx *gin.Context) {
+		if rtr.contract == nil || ctx.FullPath() == "" {
+			ctx.Next()
+			return
+		}
+
+		request := &openapi.Request{
+			Method: ctx.Request.Method,
+			Path:   ctx.FullPath(),
+			URI:    uriParams(ctx),
+			Query:  ctx.Request.URL.Query(),
+			Header: ctx.Request.Header,
+		}
+		// Only a JSON body is read, not an imported file
+		if ctx.ContentType() == binding.MIMEJSON {
+			request.Body, _ = io.ReadAll(ctx.Request.Body)
+			ctx.Request.Body = io.NopCloser(bytes.NewReader(request.Body))
+		}
+		if err := rtr.contract.ValidateRequest(request); err != nil {
+			rtr.logContractMismatch(ctx, "request does not match the OpenAPI document", err)
+		}
+
+		ctx.Next()
+
+		// The errors are answered by the error handler, and the streamed responses are already sent
+		responseViewmodel, exist := ctx.Get(ContextKeyResponseViewmodel)
+		if !validateResponses || !exist || ctx.Writer.Written() {
+			return
+		}
+		body, hasBody, err := responseBody(responseViewmodel)
+		if err != nil {
+			return
+		}
+		if !hasBody {
+			body = nil
+		}
+		if err := rtr.contract.ValidateResponse(ctx.Request.Method, ctx.FullPath(), ctx.GetInt(ContextKeyStatusCode), body); err != nil {
+			rtr.logContractMismatch(ctx, "response does not match the OpenAPI document", err)
+		}
+	}
+}
+
+func (rtr *Router) logContractMismatch(ctx *gin.Context, message string, err error) {
+	rtr.logger.Warn(message,
+		zap.Error(err),
+		zap.String("request_id", ctx.GetString(ContextKeyRequestID)),
+		zap.String("route", ctx.FullPath()),
+		zap.String("method", ctx.Request.Method))
+}
+
 // Set the CORS rules
 func (rtr *Router) corsMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -257,6 +653,7 @@ func (rtr *Router) handleLanguageMiddleware() gin.HandlerFunc {
 				en_translations.RegisterDefaultTranslations(v, trans)
 			}
 		}
+		registerFieldTranslations(trans, locale)
 
 		// Set the language in the context
 		ctx.Set(ContextKeyLocale, locale)
@@ -264,3 +661,122 @@ func (rtr *Router) handleLanguageMiddleware() gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// Reads following a write in the request are sent to the primary database,
+// so the request reads its own writes even if the replicas lag behind
+func (rtr *Router) readYourWritesMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(database.WithReadYourWrites(ctx.Request.Context()))
+		ctx.Next()
+	}
+}
+
+// Header of the request ID, a request ID given by the client (or a proxy) is kept
+const requestIDHeader = "X-Request-ID"
+
+// Identify the request and set the audit metadata of its context
+// The request is anonymous until authMiddleware sets the user
+func (rtr *Router) auditMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(requestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		ctx.Set(ContextKeyRequestID, requestID)
+		ctx.Header(requestIDHeader, requestID)
+
+		ctx.Request = ctx.Request.WithContext(audit.WithMetadata(ctx.Request.Context(), audit.Metadata{
+			Actor:     audit.Actor{Type: audit.ActorAnonymous},
+			RequestID: requestID,
+			IP:        ctx.ClientIP(),
+		}))
+		ctx.Next()
+	}
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// Authenticate the user with the bearer token of the Authorization header
+// The user is set in the context for the next handlers
+func authMiddleware(svc services.ServiceInterface) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token, found := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !found || token == "" {
+			ctx.Error(fmt.Errorf("%w: missing bearer token", errcode.ErrUnauthorized))
+			ctx.Abort()
+			return
+		}
+
+		user, err := svc.AuthenticateToken(ctx.Request.Context(), token)
+		if err != nil {
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(ContextKeyUser, user)
+		ctx.Request = ctx.Request.WithContext(audit.WithActor(ctx.Request.Context(), audit.Actor{
+			Type: audit.ActorUser,
+			ID:   strconv.FormatUint(uint64(user.ID), 10),
+		}))
+		ctx.Next()
+	}
+}
+
+// Header of the API key of a client, see API_KEYS
+const apiKeyHeader = "Api-Key"
+
+// apiKeys returns the secret of the API keys by ID, API_KEYS is a list of id:secret separated by commas
+func apiKeys() map[string]string {
+	keys := map[string]string{}
+	for _, pair := range strings.Split(viper.GetString("API_KEYS"), ",") {
+		id, secret, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if found && id != "" && secret != "" {
+			keys[id] = secret
+		}
+	}
+	return keys
+}
+
+// Authenticate the client with the API key of the Api-Key header, the request stays anonymous without it
+// The key ID is set as the actor, authMiddleware replaces it by the user of a bearer token
+func apiKeyMiddleware() gin.HandlerFunc {
+	keys := apiKeys()
+	return func(ctx *gin.Context) {
+		secret := ctx.GetHeader(apiKeyHeader)
+		if secret == "" {
+			ctx.Next()
+			return
+		}
+
+		for id, key := range keys {
+			if subtle.ConstantTimeCompare([]byte(secret), []byte(key)) == 1 {
+				ctx.Request = ctx.Request.WithContext(audit.WithActor(ctx.Request.Context(), audit.Actor{
+					Type: audit.ActorAPIKey,
+					ID:   id,
+				}))
+				ctx.Next()
+				return
+			}
+		}
+		ctx.Error(fmt.Errorf("%w: unknown API key", errcode.ErrUnauthorized))
+		ctx.Abort()
+	}
+}
+
+// Allow only administrators, it must be used after authMiddleware
+func adminMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user := ctx.MustGet(ContextKeyUser).(*models.User)
+		if !user.IsAdmin {
+			ctx.Error(fmt.Errorf("%w: user %d is not an administrator", errcode.ErrForbidden, user.ID))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -1,22 +1,38 @@
 package controllers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/codec"
+	"github.com/sarrooo/go-clean/internal/dto"
 	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/filter"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/openapi"
+	"github.com/sarrooo/go-clean/internal/params"
 	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/sarrooo/go-clean/mocks"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 var (
-	router = NewRouter(zap.NewNop(), nil)
+	router = NewRouter(zap.NewNop(), nil, nil)
 )
 
 func TestRequestViewmodelMiddleware(t *testing.T) {
@@ -67,6 +83,50 @@ func TestRequestViewmodelMiddleware(t *testing.T) {
 			expectedViewmodel: nil,
 			expectedError:     errcode.ErrInvalidParameters,
 		},
+		"POST Valid XML Request": {
+			method:          "POST",
+			contentType:     "application/xml",
+			requestBody:     `<request><field>value</field></request>`,
+			paramsViewmodel: &viewmodel.TestBodyViewModelRequest{},
+			expectedViewmodel: &viewmodel.TestBodyViewModelRequest{
+				Body: struct {
+					Field string `json:"field" binding:"required,min=1"`
+				}{
+					Field: "value",
+				},
+			},
+			expectedError: nil,
+		},
+		"POST Valid YAML Request": {
+			method:          "POST",
+			contentType:     "application/yaml",
+			requestBody:     "field: 42",
+			paramsViewmodel: &viewmodel.TestBodyViewModelRequest{},
+			expectedViewmodel: &viewmodel.TestBodyViewModelRequest{
+				Body: struct {
+					Field string `json:"field" binding:"required,min=1"`
+				}{
+					Field: "42",
+				},
+			},
+			expectedError: nil,
+		},
+		"POST Invalid XML": {
+			method:            "POST",
+			contentType:       "application/xml",
+			requestBody:       `<request><field>`,
+			paramsViewmodel:   &viewmodel.TestBodyViewModelRequest{},
+			expectedViewmodel: nil,
+			expectedError:     errcode.ErrInvalidParameters,
+		},
+		"POST Unsupported Media Type": {
+			method:            "POST",
+			contentType:       "text/plain",
+			requestBody:       "value",
+			paramsViewmodel:   &viewmodel.TestBodyViewModelRequest{},
+			expectedViewmodel: nil,
+			expectedError:     errcode.ErrUnsupportedMediaType,
+		},
 		"GET Valid Request": {
 			method:          "GET",
 			contentType:     "",
@@ -78,6 +138,20 @@ func TestRequestViewmodelMiddleware(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		"GET Unknown Filter": {
+			method:            "GET",
+			requestParams:     "?password[contains]=a",
+			paramsViewmodel:   &viewmodel.TestFilterViewModelRequest{},
+			expectedViewmodel: nil,
+			expectedError:     errcode.ErrInvalidParameters,
+		},
+		"GET Filter Operator Not Allowed": {
+			method:            "GET",
+			requestParams:     "?name[in]=a,b",
+			paramsViewmodel:   &viewmodel.TestFilterViewModelRequest{},
+			expectedViewmodel: nil,
+			expectedError:     errcode.ErrInvalidParameters,
+		},
 		"GET No Required": {
 			method:            "GET",
 			contentType:       "",
@@ -111,6 +185,102 @@ func TestRequestViewmodelMiddleware(t *testing.T) {
 	}
 }
 
+func TestRequestViewmodelMiddlewareFilters(t *testing.T) {
+	ctx, _ := setupGinContext(http.MethodGet, "/?name[contains]=em&name=Eminem&limit=2", "", "")
+
+	requestViewmodelMiddleware(&viewmodel.TestFilterViewModelRequest{})(ctx)
+
+	assert.Empty(t, ctx.Errors)
+	request := ctx.MustGet(ContextKeyRequestViewmodel).(*viewmodel.TestFilterViewModelRequest)
+	assert.ElementsMatch(t, []filter.Condition{
+		{Column: "name", Operator: filter.Eq, Value: "Eminem"},
+		{Column: "name", Operator: filter.Contains, Value: "em"},
+	}, filter.Conditions(request), "Filters should be bound")
+}
+
+func TestRequestViewmodelMiddlewareParams(t *testing.T) {
+	tests := map[string]struct {
+		query                 string
+		header                string
+		language              string
+		expectedViewmodel     *viewmodel.TestParamsViewModelRequest
+		expectedInvalidFields map[string]string
+	}{
+		"Valid": {
+			query:  "?limit=10&ids=1,2&ids=3",
+			header: "123e4567-e89b-12d3-a456-426614174000",
+			expectedViewmodel: &viewmodel.TestParamsViewModelRequest{
+				ID:      4,
+				Limit:   func() *int { limit := 10; return &limit }(),
+				IDs:     []uint{1, 2, 3},
+				Version: params.UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00},
+			},
+		},
+		"Invalid values": {
+			query:  "?limit=ten&ids=1,a",
+			header: "v2",
+			expectedInvalidFields: map[string]string{
+				"limit":     "limit must be a valid integer",
+				"ids":       "ids must be a valid positive integer",
+				"X-Version": "X-Version must be a valid UUID",
+			},
+		},
+		"Validation error and invalid value": {
+			query: "?limit=1000&ids=a",
+			expectedInvalidFields: map[string]string{
+				"limit": "limit must be 100 or less",
+				"ids":   "ids must be a valid positive integer",
+			},
+		},
+		"French": {
+			query:    "?limit=ten",
+			language: "fr",
+			expectedInvalidFields: map[string]string{
+				"limit": "limit doit être une valeur valide de type integer",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, _ := setupGinContext(http.MethodGet, "/"+test.query, "", "")
+			ctx.Params = gin.Params{{Key: "id", Value: "4"}}
+			if test.header != "" {
+				ctx.Request.Header.Set("X-Version", test.header)
+			}
+			ctx.Request.Header.Set("Accept-Language", test.language)
+			router.handleLanguageMiddleware()(ctx)
+
+			requestViewmodelMiddleware(&viewmodel.TestParamsViewModelRequest{})(ctx)
+
+			if test.expectedInvalidFields != nil {
+				assert.True(t, errors.Is(ctx.Errors.Last(), errcode.ErrInvalidParameters), "Error should be invalid parameters")
+				assert.Equal(t, test.expectedInvalidFields, ctx.MustGet(ContextKeyInvalidFields))
+				return
+			}
+			assert.Empty(t, ctx.Errors)
+			assert.Equal(t, test.expectedViewmodel, ctx.MustGet(ContextKeyRequestViewmodel))
+		})
+	}
+}
+
+func TestRequestViewmodelMiddlewareMultipart(t *testing.T) {
+	body, contentType := multipartForm("artists.csv", "name\nEminem\n", map[string]string{
+		"mapping": `{"Artist":"name"}`,
+		"dry_run": "true",
+	})
+	ctx, _ := setupGinContext(http.MethodPost, "/", body.String(), contentType)
+
+	requestViewmodelMiddleware(&viewmodel.ImportArtistsRequest{})(ctx)
+
+	assert.Empty(t, ctx.Errors)
+	request := ctx.MustGet(ContextKeyRequestViewmodel).(*viewmodel.ImportArtistsRequest)
+	assert.Equal(t, "artists.csv", request.File.Filename)
+	assert.Equal(t, map[string]string{"Artist": "name"}, request.Mapping)
+	assert.True(t, request.DryRun)
+	assert.False(t, request.Async)
+}
+
 func TestResponseViewmodelMiddleware(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -163,6 +333,305 @@ func TestResponseViewmodelMiddleware(t *testing.T) {
 	}
 }
 
+func TestResponseViewmodelMiddlewareCodec(t *testing.T) {
+	response := &viewmodel.TestViewModelResponse{}
+	response.Body.Field = "value"
+
+	tests := map[string]struct {
+		codec        *codec.Codec
+		expectedType string
+		expectedBody string
+	}{
+		"No negotiated codec": {
+			expectedType: "application/json; charset=utf-8",
+			expectedBody: `{"field":"value"}`,
+		},
+		"XML": {
+			codec:        codec.XML,
+			expectedType: "application/xml; charset=utf-8",
+			expectedBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><field>value</field></response>`,
+		},
+		"YAML": {
+			codec:        codec.YAML,
+			expectedType: "application/yaml; charset=utf-8",
+			expectedBody: "field: value\n",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, recorder := setupGinContext(http.MethodPost, "/", "", "")
+			ctx.Set(ContextKeyStatusCode, http.StatusCreated)
+			ctx.Set(ContextKeyResponseViewmodel, response)
+			if test.codec != nil {
+				ctx.Set(ContextKeyCodec, test.codec)
+			}
+
+			router.responseViewmodelMiddleware()(ctx)
+
+			assert.Equal(t, http.StatusCreated, recorder.Code)
+			assert.Equal(t, test.expectedType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestNegotiationMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		accept        string
+		expectedCodec *codec.Codec
+		expectedError error
+	}{
+		"Default": {
+			expectedCodec: codec.JSON,
+		},
+		"MessagePack": {
+			accept:        "application/msgpack",
+			expectedCodec: codec.MsgPack,
+		},
+		"Not Acceptable": {
+			accept:        "text/html",
+			expectedError: errcode.ErrNotAcceptable,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, _ := setupGinContext(http.MethodGet, "/", "", "")
+			ctx.Request.Header.Set("Accept", test.accept)
+
+			negotiationMiddleware()(ctx)
+
+			assert.Equal(t, "Accept", ctx.Writer.Header().Get("Vary"))
+			if test.expectedError != nil {
+				assert.ErrorIs(t, ctx.Errors.Last().Err, test.expectedError)
+				assert.True(t, ctx.IsAborted())
+				return
+			}
+			assert.Empty(t, ctx.Errors)
+			assert.Same(t, test.expectedCodec, ctx.MustGet(ContextKeyCodec))
+		})
+	}
+}
+
+func TestNegotiationRoutes(t *testing.T) {
+	tests := map[string]struct {
+		accept         string
+		expectedStatus int
+		expectedType   string
+		expectedBody   string
+	}{
+		"XML": {
+			accept:         "application/xml",
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/xml; charset=utf-8",
+			expectedBody:   `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><status>ok</status></response>`,
+		},
+		"Not Acceptable is answered in JSON": {
+			accept:         "text/html",
+			expectedStatus: http.StatusNotAcceptable,
+			expectedType:   "application/json; charset=utf-8",
+			expectedBody:   `{"message":"no acceptable media type is supported"}`,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/health/live", nil)
+			request.Header.Set("Accept", test.accept)
+
+			router.engine.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.Equal(t, test.expectedType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestResponseViewmodelMiddlewareWritten(t *testing.T) {
+	ctx, recorder := setupGinContext(http.MethodGet, "/", "", "")
+	_, _ = ctx.Writer.WriteString("id,name\n")
+
+	router.responseViewmodelMiddleware()(ctx)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "A streamed response should be kept")
+	assert.Equal(t, "id,name\n", recorder.Body.String())
+}
+
+func TestResponseViewmodelMiddlewareFields(t *testing.T) {
+	response := &viewmodel.GetArtistResponse{}
+	response.Body.ID = 1
+	response.Body.Name = "Eminem"
+	response.Body.Version = 3
+	response.Body.Albums = []*viewmodel.Album{{ID: 4, Name: "Recovery"}}
+
+	tests := map[string]struct {
+		statusCode   int
+		fields       []string
+		expectedBody string
+	}{
+		"All fields": {
+			statusCode:   http.StatusOK,
+			expectedBody: `{"id":1,"name":"Eminem","version":3,"albums":[{"id":4,"name":"Recovery"}]}`,
+		},
+		"Selected fields": {
+			statusCode:   http.StatusOK,
+			fields:       []string{"name", "albums.name"},
+			expectedBody: `{"name":"Eminem","albums":[{"name":"Recovery"}]}`,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, recorder := setupGinContext(http.MethodGet, "/", "", "")
+			ctx.Set(ContextKeyStatusCode, test.statusCode)
+			ctx.Set(ContextKeyResponseViewmodel, response)
+			if test.fields != nil {
+				ctx.Set(ContextKeyFields, test.fields)
+			}
+
+			router.responseViewmodelMiddleware()(ctx)
+
+			assert.Equal(t, test.statusCode, recorder.Code)
+			assert.JSONEq(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestResponseETag(t *testing.T) {
+	response := &viewmodel.TestViewModelResponse{}
+	response.Body.Field = "value"
+	body, _ := json.Marshal(response.Body)
+	hash := sha256.Sum256(body)
+	bodyETag := `"` + hex.EncodeToString(hash[:16]) + `"`
+
+	tests := map[string]struct {
+		method         string
+		version        uint
+		statusCode     int
+		ifNoneMatch    string
+		expectedETag   string
+		expectedStatus int
+	}{
+		"Version of the entity": {
+			method:         http.MethodGet,
+			version:        3,
+			statusCode:     http.StatusOK,
+			expectedETag:   `"3"`,
+			expectedStatus: http.StatusOK,
+		},
+		"Hash of the body": {
+			method:         http.MethodGet,
+			statusCode:     http.StatusOK,
+			expectedETag:   bodyETag,
+			expectedStatus: http.StatusOK,
+		},
+		"Not modified": {
+			method:         http.MethodGet,
+			version:        3,
+			statusCode:     http.StatusOK,
+			ifNoneMatch:    `"2", W/"3"`,
+			expectedETag:   `"3"`,
+			expectedStatus: http.StatusNotModified,
+		},
+		"Modified": {
+			method:         http.MethodGet,
+			version:        3,
+			statusCode:     http.StatusOK,
+			ifNoneMatch:    `"2"`,
+			expectedETag:   `"3"`,
+			expectedStatus: http.StatusOK,
+		},
+		"Write of an entity": {
+			method:         http.MethodPut,
+			version:        4,
+			statusCode:     http.StatusOK,
+			expectedETag:   `"4"`,
+			expectedStatus: http.StatusOK,
+		},
+		"Write without entity": {
+			method:         http.MethodPost,
+			statusCode:     http.StatusOK,
+			expectedStatus: http.StatusOK,
+		},
+		"Error": {
+			method:         http.MethodGet,
+			version:        3,
+			statusCode:     http.StatusBadRequest,
+			ifNoneMatch:    `"3"`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, recorder := setupGinContext(test.method, "/", "", "")
+			ctx.Request.Header.Set("If-None-Match", test.ifNoneMatch)
+			if test.version != 0 {
+				ctx.Set(ContextKeyVersion, test.version)
+			}
+			ctx.Set(ContextKeyStatusCode, test.statusCode)
+			ctx.Set(ContextKeyResponseViewmodel, response)
+
+			router.responseViewmodelMiddleware()(ctx)
+
+			assert.Equal(t, test.expectedStatus, ctx.Writer.Status(), "Status should match")
+			assert.Equal(t, test.expectedETag, recorder.Header().Get("ETag"), "ETag should match")
+			if test.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, recorder.Body.String(), "Not modified response should have no body")
+			}
+		})
+	}
+}
+
+func TestIfMatchMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		ifMatch         string
+		expectedVersion uint
+		expectedError   error
+	}{
+		"Version": {
+			ifMatch:         `"3"`,
+			expectedVersion: 3,
+		},
+		"Any version": {
+			ifMatch:         "*",
+			expectedVersion: 0,
+		},
+		"Missing header": {
+			ifMatch:       "",
+			expectedError: errcode.ErrPreconditionRequired,
+		},
+		"Weak entity tag": {
+			ifMatch:       `W/"3"`,
+			expectedError: errcode.ErrPreconditionFailed,
+		},
+		"Not a version": {
+			ifMatch:       `"a1b2"`,
+			expectedError: errcode.ErrPreconditionFailed,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, _ := setupGinContext(http.MethodPut, "/", "", "")
+			ctx.Request.Header.Set("If-Match", test.ifMatch)
+
+			ifMatchMiddleware()(ctx)
+
+			if test.expectedError != nil {
+				assert.True(t, ctx.IsAborted(), "Request should be aborted")
+				assert.True(t, errors.Is(ctx.Errors.Last().Err, test.expectedError), "Error type should match")
+				return
+			}
+			assert.False(t, ctx.IsAborted(), "Request should not be aborted")
+			assert.Equal(t, test.expectedVersion, ctx.GetUint(ContextKeyIfMatchVersion), "Version should match")
+		})
+	}
+}
+
 func TestErrorHandlerMiddleware(t *testing.T) {
 	tests := map[string]struct {
 		err             error
@@ -170,6 +639,8 @@ func TestErrorHandlerMiddleware(t *testing.T) {
 		expectedStatus  int
 		expectedMessage string
 		expectedContext map[string]string
+		// Set by errorStatusCodesMiddleware
+		statusCodes map[error]int
 	}{
 		"GoCleanError with InvalidParameters": {
 			err:             errcode.ErrInvalidParameters,
@@ -187,6 +658,44 @@ func TestErrorHandlerMiddleware(t *testing.T) {
 			expectedMessage: "not found",
 			expectedContext: nil,
 		},
+		"GoCleanError with the status of its routes": {
+			err:             fmt.Errorf("%w: artists 1", errcode.ErrNotFound),
+			statusCodes:     trashErrorStatusCodes,
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "not found",
+			expectedContext: nil,
+		},
+		"Internal error with the status codes of its routes": {
+			err:             errors.New("generic error"),
+			statusCodes:     trashErrorStatusCodes,
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "internal error",
+			expectedContext: nil,
+		},
+		"GoCleanError with its own status": {
+			err:             fmt.Errorf("%w: artists 1", errcode.ErrConflict),
+			expectedStatus:  http.StatusConflict,
+			expectedMessage: "conflict with an existing entity",
+			expectedContext: nil,
+		},
+		"GoCleanError with context": {
+			err:             errcode.WithContext(fmt.Errorf("%w: artist 1 has 2 albums", errcode.ErrConflict), map[string]string{"albums": "2"}),
+			expectedStatus:  http.StatusConflict,
+			expectedMessage: "conflict with an existing entity",
+			expectedContext: map[string]string{"albums": "2"},
+		},
+		"Unauthorized": {
+			err:             errcode.ErrTokenExpirated,
+			expectedStatus:  http.StatusUnauthorized,
+			expectedMessage: "invalid token",
+			expectedContext: nil,
+		},
+		"Forbidden": {
+			err:             errcode.ErrForbidden,
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: "forbidden",
+			expectedContext: nil,
+		},
 		"Non-GoCleanError": {
 			err:             errors.New("generic error"),
 			expectedStatus:  http.StatusInternalServerError,
@@ -203,6 +712,9 @@ func TestErrorHandlerMiddleware(t *testing.T) {
 			// Set the error in context
 			ctx.Error(test.err)
 			ctx.Set(ContextKeyInvalidFields, test.failedFields)
+			if test.statusCodes != nil {
+				ctx.Set(ContextKeyErrorStatusCodes, test.statusCodes)
+			}
 
 			// Call middleware
 			router.errorHandlerMiddleware()(ctx)
@@ -225,6 +737,69 @@ func TestErrorHandlerMiddleware(t *testing.T) {
 	}
 }
 
+func TestContractValidationMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		env              string
+		path             string
+		expectedStatus   int
+		expectedWarnings map[string]string
+	}{
+		"Valid request and response": {
+			path:           "/contract/1",
+			expectedStatus: http.StatusOK,
+		},
+		"Invalid request": {
+			path:           "/contract/1?limit=1000",
+			expectedStatus: http.StatusBadRequest,
+			expectedWarnings: map[string]string{
+				"request does not match the OpenAPI document": "query limit must be at most 100",
+			},
+		},
+		"Invalid response": {
+			path:           "/contract/2",
+			expectedStatus: http.StatusOK,
+			expectedWarnings: map[string]string{
+				"response does not match the OpenAPI document": "body/status must be one of [active archived]",
+			},
+		},
+		"Invalid response in production": {
+			env:            "production",
+			path:           "/contract/2",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			viper.Set("ENV", test.env)
+			defer viper.Set("ENV", nil)
+			core, logs := observer.New(zap.WarnLevel)
+			rtr := &Router{logger: zap.New(core)}
+			engine := gin.New()
+			engine.Use(rtr.responseViewmodelMiddleware(), rtr.errorHandlerMiddleware(), rtr.contractValidationMiddleware())
+			Handle(engine, http.MethodGet, "/contract/:id", func(ctx *gin.Context, request *viewmodel.TestParamsViewModelRequest) (*viewmodel.TestContractViewModelResponse, int, error) {
+				response := &viewmodel.TestContractViewModelResponse{}
+				response.Body.Status = map[uint]string{1: "active", 2: "deleted"}[request.ID]
+				return response, http.StatusOK, nil
+			})
+			rtr.contract = openapi.NewValidator(openAPIDocument(engine))
+			recorder := httptest.NewRecorder()
+
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			warnings := map[string]string{}
+			for _, entry := range logs.FilterLevelExact(zapcore.WarnLevel).All() {
+				warnings[entry.Message] = entry.ContextMap()["error"].(string)
+			}
+			if test.expectedWarnings == nil {
+				test.expectedWarnings = map[string]string{}
+			}
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
+
 func TestCorsMiddleware(t *testing.T) {
 	tests := map[string]struct {
 		method       string
@@ -298,3 +873,315 @@ func setupGinContext(method, url, requestBody string, contentType string) (*gin.
 
 	return ctx, recorder
 }
+
+func TestAuthMiddleware(t *testing.T) {
+	admin := &models.User{Model: models.Model{ID: 1}, IsAdmin: true}
+	user := &models.User{Model: models.Model{ID: 2}}
+
+	tests := map[string]struct {
+		authorization string
+		setupMock     func(svc *mocks.ServiceInterface)
+		expectedUser  *models.User
+		expectedError error
+	}{
+		"Valid token": {
+			authorization: "Bearer token",
+			setupMock: func(svc *mocks.ServiceInterface) {
+				svc.On("AuthenticateToken", mock.Anything, "token").Return(admin, nil)
+			},
+			expectedUser: admin,
+		},
+		"Missing header": {
+			authorization: "",
+			setupMock:     func(svc *mocks.ServiceInterface) {},
+			expectedError: errcode.ErrUnauthorized,
+		},
+		"Not a bearer token": {
+			authorization: "Basic dXNlcjpwYXNz",
+			setupMock:     func(svc *mocks.ServiceInterface) {},
+			expectedError: errcode.ErrUnauthorized,
+		},
+		"Invalid token": {
+			authorization: "Bearer token",
+			setupMock: func(svc *mocks.ServiceInterface) {
+				svc.On("AuthenticateToken", mock.Anything, "token").Return(nil, errcode.ErrInvalidToken)
+			},
+			expectedError: errcode.ErrInvalidToken,
+		},
+		"Not an administrator": {
+			authorization: "Bearer token",
+			setupMock: func(svc *mocks.ServiceInterface) {
+				svc.On("AuthenticateToken", mock.Anything, "token").Return(user, nil)
+			},
+			expectedUser:  user,
+			expectedError: errcode.ErrForbidden,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			svc := &mocks.ServiceInterface{}
+			test.setupMock(svc)
+			ctx, _ := setupGinContext(http.MethodGet, "/", "", "")
+			ctx.Request.Header.Set("Authorization", test.authorization)
+
+			authMiddleware(svc)(ctx)
+			if !ctx.IsAborted() {
+				adminMiddleware()(ctx)
+			}
+
+			svc.AssertExpectations(t)
+			if test.expectedError != nil {
+				assert.True(t, ctx.IsAborted(), "Request should be aborted")
+				assert.True(t, errors.Is(ctx.Errors.Last().Err, test.expectedError), "Error type should match")
+			} else {
+				assert.False(t, ctx.IsAborted(), "Request should not be aborted")
+			}
+			if test.expectedUser != nil {
+				assert.Equal(t, test.expectedUser, ctx.MustGet(ContextKeyUser))
+				assert.Equal(t, audit.Actor{Type: audit.ActorUser, ID: strconv.FormatUint(uint64(test.expectedUser.ID), 10)},
+					audit.FromContext(ctx.Request.Context()).Actor, "User should be the audit actor")
+			}
+		})
+	}
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	viper.Set("API_KEYS", "billing:s3cr3t, mobile:an0ther,invalid")
+	defer viper.Set("API_KEYS", nil)
+	middleware := apiKeyMiddleware()
+
+	tests := map[string]struct {
+		apiKey        string
+		expectedActor audit.Actor
+		expectedError error
+	}{
+		"Without key": {
+			apiKey:        "",
+			expectedActor: audit.Actor{Type: audit.ActorAnonymous},
+		},
+		"Valid key": {
+			apiKey:        "an0ther",
+			expectedActor: audit.Actor{Type: audit.ActorAPIKey, ID: "mobile"},
+		},
+		"Unknown key": {
+			apiKey:        "unknown",
+			expectedError: errcode.ErrUnauthorized,
+		},
+		"Key ID instead of its secret": {
+			apiKey:        "billing",
+			expectedError: errcode.ErrUnauthorized,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, _ := setupGinContext(http.MethodPost, "/", "", "")
+			ctx.Request = ctx.Request.WithContext(audit.WithMetadata(ctx.Request.Context(), audit.Metadata{
+				Actor: audit.Actor{Type: audit.ActorAnonymous},
+			}))
+			ctx.Request.Header.Set(apiKeyHeader, test.apiKey)
+
+			middleware(ctx)
+
+			if test.expectedError != nil {
+				assert.True(t, ctx.IsAborted(), "Request should be aborted")
+				assert.True(t, errors.Is(ctx.Errors.Last().Err, test.expectedError), "Error type should match")
+				return
+			}
+			assert.False(t, ctx.IsAborted(), "Request should not be aborted")
+			assert.Equal(t, test.expectedActor, audit.FromContext(ctx.Request.Context()).Actor)
+		})
+	}
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		requestID string
+	}{
+		"Generated request ID": {
+			requestID: "",
+		},
+		"Request ID given by the client": {
+			requestID: "client-request-id",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, recorder := setupGinContext(http.MethodGet, "/", "", "")
+			ctx.Request.Header.Set(requestIDHeader, test.requestID)
+
+			router.auditMiddleware()(ctx)
+
+			metadata := audit.FromContext(ctx.Request.Context())
+			assert.Equal(t, audit.Actor{Type: audit.ActorAnonymous}, metadata.Actor, "Request should be anonymous")
+			assert.Equal(t, "192.0.2.1", metadata.IP, "IP should be the client IP")
+			assert.NotEmpty(t, metadata.RequestID, "Request ID should be set")
+			if test.requestID != "" {
+				assert.Equal(t, test.requestID, metadata.RequestID, "Request ID of the client should be kept")
+			}
+			assert.Equal(t, metadata.RequestID, recorder.Header().Get(requestIDHeader), "Request ID should be answered")
+		})
+	}
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	fingerprint := requestFingerprint(http.MethodPost, "/artists", []byte(`{"name":"Eminem"}`))
+	isRequest := func(request *dto.IdempotentRequest) bool {
+		return request.Key == "key" && request.Scope == "POST /artists user:2" && request.Fingerprint == fingerprint
+	}
+	// The key of an anonymous caller is scoped by the fingerprint, a key sent with another payload is another key
+	isAnonymousRequest := func(request *dto.IdempotentRequest) bool {
+		return request.Key == "key" && request.Scope == "POST /artists anonymous:"+fingerprint && request.Fingerprint == fingerprint
+	}
+
+	tests := map[string]struct {
+		method           string
+		idempotencyKey   string
+		anonymous        bool
+		handlerError     error
+		setupMock        func(svc *mocks.ServiceInterface)
+		expectedStatus   int
+		expectedBody     string
+		expectedReplayed bool
+		expectedHandled  bool
+	}{
+		"Without key": {
+			method:          http.MethodPost,
+			setupMock:       func(svc *mocks.ServiceInterface) {},
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"field":"created"}`,
+			expectedHandled: true,
+		},
+		"Anonymous first request": {
+			method:         http.MethodPost,
+			idempotencyKey: "key",
+			anonymous:      true,
+			setupMock: func(svc *mocks.ServiceInterface) {
+				svc.On("BeginIdempotentRequest", mock.Anything, mock.MatchedBy(isAnonymousRequest)).Return(nil, nil)
+				svc.On("CompleteIdempotentRequest", mock.Anything, mock.MatchedBy(isAnonymousRequest), mock.Anything).Return(nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"field":"created"}`,
+			expectedHandled: true,
+		},
+		"Anonymous retry": {
+			method:         http.MethodPost,
+			idempotencyKey: "key",
+			anonymous:      true,
+			setupMock: func(svc *mocks.ServiceInterface) {
+				svc.On("BeginIdempotentRequest", mock.Anything, mock.MatchedBy(isAnonymousRequest)).Return(&dto.IdempotentResponse{
+					StatusCode: http.StatusOK,
+					Body:       []byte(`{"field":"stored"}`),
+				}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedBody:     `{"field":"stored"}`,
+			expectedReplayed: true,
+		},
+		"Not a POST": {
+			method:          http.MethodPut,
+			idempotencyKey:  "key",
+			setupMock:       func(svc *mocks.ServiceInterface) {},
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"field":"created"}`,
+			expectedHandled: true,
+		},
+		"First request": {
+			method:         http.MethodPost,
+			idempotencyKey: "key",
+			setupMock: func(svc *mocks.ServiceInterface) {
+				svc.On("BeginIdempotentRequest", mock.Anything, mock.MatchedBy(isRequest)).Return(nil, nil)
+				svc.On("CompleteIdempotentRequest", mock.Anything, mock.MatchedBy(isRequest), &dto.IdempotentResponse{
+					StatusCode: http.StatusOK,
+					Body:       []byte(`{"field":"created"}`),
+				}).Return(nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"field":"created"}`,
+			expectedHandled: true,
+		},
+		"Retry": {
+			method:         http.MethodPost,
+			idempotencyKey: "key",
+			setupMock: func(svc *mocks.ServiceInterface) {
+				svc.On("BeginIdempotentRequest", mock.Anything, mock.MatchedBy(isRequest)).Return(&dto.IdempotentResponse{
+					StatusCode: http.StatusOK,
+					Body:       []byte(`{"field":"stored"}`),
+				}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedBody:     `{"field":"stored"}`,
+			expectedReplayed: true,
+		},
+		"Key reused": {
+			method:         http.MethodPost,
+			idempotencyKey: "key",
+			setupMock: func(svc *mocks.ServiceInterface) {
+				svc.On("BeginIdempotentRequest", mock.Anything, mock.Anything).Return(nil, errcode.ErrIdempotencyKeyReused)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		"Key too long": {
+			method:         http.MethodPost,
+			idempotencyKey: strings.Repeat("k", idempotencyKeyMaxLength+1),
+			setupMock:      func(svc *mocks.ServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		"Failed request": {
+			method:         http.MethodPost,
+			idempotencyKey: "key",
+			handlerError:   errcode.ErrConflict,
+			setupMock: func(svc *mocks.ServiceInterface) {
+				svc.On("BeginIdempotentRequest", mock.Anything, mock.Anything).Return(nil, nil)
+				svc.On("ReleaseIdempotentRequest", mock.Anything, mock.MatchedBy(isRequest)).Return(nil)
+			},
+			expectedStatus:  http.StatusConflict,
+			expectedHandled: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			svc := &mocks.ServiceInterface{}
+			test.setupMock(svc)
+			handled := false
+
+			engine := gin.New()
+			engine.Use(router.auditMiddleware(), router.responseViewmodelMiddleware(), router.errorHandlerMiddleware())
+			// Like authMiddleware, the user is the actor
+			engine.Use(func(ctx *gin.Context) {
+				if !test.anonymous {
+					ctx.Request = ctx.Request.WithContext(audit.WithActor(ctx.Request.Context(), audit.Actor{Type: audit.ActorUser, ID: "2"}))
+				}
+			})
+			engine.Handle(test.method, "/artists", router.idempotencyMiddleware(svc), func(ctx *gin.Context) {
+				handled = true
+				if test.handlerError != nil {
+					ctx.Error(test.handlerError)
+					return
+				}
+				response := &viewmodel.TestViewModelResponse{}
+				response.Body.Field = "created"
+				ctx.Set(ContextKeyStatusCode, http.StatusOK)
+				ctx.Set(ContextKeyResponseViewmodel, response)
+			})
+
+			request := httptest.NewRequest(test.method, "/artists", strings.NewReader(`{"name":"Eminem"}`))
+			request.Header.Set("Idempotency-Key", test.idempotencyKey)
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, request)
+
+			svc.AssertExpectations(t)
+			assert.Equal(t, test.expectedStatus, recorder.Code, "Status should match")
+			assert.Equal(t, test.expectedHandled, handled, "Handler call should match")
+			if test.expectedBody != "" {
+				assert.JSONEq(t, test.expectedBody, recorder.Body.String(), "Body should match")
+			}
+			if test.expectedReplayed {
+				assert.Equal(t, "true", recorder.Header().Get("Idempotent-Replayed"), "Response should be marked as replayed")
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"net/http"
 	"reflect"
 	"strings"
 
@@ -10,7 +11,10 @@ import (
 	"github.com/go-playground/locales/fr"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	"github.com/sarrooo/go-clean/internal/openapi"
+	"github.com/sarrooo/go-clean/internal/params"
 	"github.com/sarrooo/go-clean/internal/services"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"golang.org/x/text/language"
 )
@@ -20,9 +24,12 @@ type Router struct {
 	logger              *zap.Logger
 	languageMatcher     language.Matcher
 	universalTranslator *ut.UniversalTranslator
+	// contract validates the requests and the responses against the committed OpenAPI document, see committedOpenAPI
+	contract *openapi.Validator
 }
 
-func NewRouter(logger *zap.Logger, svc services.ServiceInterface) *Router {
+// NewRouter creates the router, probes are the dependencies checked by the readiness endpoint
+func NewRouter(logger *zap.Logger, svc services.ServiceInterface, probes map[string]ReadinessProbe) *Router {
 	router := &Router{}
 
 	router.logger = logger
@@ -42,26 +49,71 @@ func NewRouter(logger *zap.Logger, svc services.ServiceInterface) *Router {
 	/* Middleware */
 	router.engine.Use(router.corsMiddleware())
 	router.engine.Use(router.handleLanguageMiddleware())
+	router.engine.Use(router.readYourWritesMiddleware())
+	router.engine.Use(router.auditMiddleware())
 	router.engine.Use(router.responseViewmodelMiddleware())
 	router.engine.Use(router.errorHandlerMiddleware())
+	// After the error handler, the responses of the controllers are validated, not the errors
+	if viper.GetBool("OPENAPI_VALIDATION") {
+		router.engine.Use(router.contractValidationMiddleware())
+	}
+
+	router.registerRoutes(svc, probes)
 
-	router.registerRoutes(svc)
+	contract, err := committedOpenAPI()
+	if err != nil {
+		logger.Error("OpenAPI document loading failed", zap.Error(err))
+	} else {
+		router.contract = openapi.NewValidator(contract)
+	}
+
+	// After the other routes, they are documented
+	if err := router.registerDocsRoutes(router.OpenAPI()); err != nil {
+		logger.Error("OpenAPI document generation failed", zap.Error(err))
+	}
 
 	return router
 }
 
-func (rtr *Router) Run(addr string) error {
-	return rtr.engine.Run(addr)
+// Handler returns the HTTP handler of the routes
+func (rtr *Router) Handler() http.Handler {
+	return rtr.engine
 }
 
-func (rtr *Router) registerRoutes(svc services.ServiceInterface) {
+func (rtr *Router) registerRoutes(svc services.ServiceInterface, probes map[string]ReadinessProbe) {
+	// The responses of the API are encoded with the codec negotiated from the Accept header
+	// and its clients may be identified by an API key
+	api := rtr.engine.Group("", negotiationMiddleware(), apiKeyMiddleware())
+
+	/* Health */
+	health := api.Group("/health")
+	registerHealthRoutes(health, probes)
+
 	/* Auth */
-	auth := rtr.engine.Group("/auth")
-	registerAuthRoutes(auth, svc)
+	auth := api.Group("/auth")
+	registerAuthRoutes(auth, svc, rtr.idempotencyMiddleware(svc))
 
 	/* Albums */
-	albums := rtr.engine.Group("/albums")
+	albums := api.Group("/albums", rtr.idempotencyMiddleware(svc))
 	registerArtistesRoutes(albums, svc)
+
+	/* Search */
+	search := api.Group("/search")
+	registerSearchRoutes(search, svc)
+
+	/* Admin */
+	admin := api.Group("/admin", authMiddleware(svc), adminMiddleware(), rtr.idempotencyMiddleware(svc))
+	trash := admin.Group("/trash", errorStatusCodesMiddleware(trashErrorStatusCodes))
+	registerTrashRoutes(trash, svc)
+	audit := admin.Group("/audit")
+	registerAuditRoutes(audit, svc)
+
+	/* Import & Export */
+	// The exported files have their own media type, they are not negotiated
+	export := rtr.engine.Group("/export", authMiddleware(svc), adminMiddleware())
+	registerExportRoutes(export, svc)
+	imports := api.Group("/import", authMiddleware(svc), adminMiddleware(), importSizeMiddleware(), rtr.idempotencyMiddleware(svc))
+	registerImportRoutes(imports, svc)
 }
 
 func config(router *gin.Engine) {
@@ -70,10 +122,19 @@ func config(router *gin.Engine) {
 	// Custom validator
 	// This is used to be able to get the json tag name instead of the struct field name
 	// to send it to the client, and the client can use it to display the error message
+	// A field without json tag is named by its parameter, e.g. its query tag
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterTagNameFunc(func(fld reflect.StructField) string {
 			name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
-			if name == "-" {
+			for _, tag := range []string{params.TagURI, params.TagQuery, params.TagHeader, "form"} {
+				if name != "" {
+					break
+				}
+				if parameter := strings.SplitN(fld.Tag.Get(tag), ",", 2)[0]; parameter != "-" {
+					name = parameter
+				}
+			}
+			if name == "-" || name == "" {
 				return fld.Name
 			}
 			return name
@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/pagination"
+)
+
+// paginationParams validates the pagination of a list request
+// An invalid field is translated and set in the context, like the binding errors
+func paginationParams(ctx *gin.Context, request *pagination.Request, sortable pagination.Sortable, defaultSort string) (*pagination.Params, error) {
+	params, err := request.Params(sortable, defaultSort)
+	if err != nil {
+		var fieldError *pagination.FieldError
+		if errors.As(err, &fieldError) {
+			setInvalidField(ctx, fieldError.Field, fieldError.Tag, fieldError.Param, fieldError.Error())
+		}
+		return nil, fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+	}
+	return params, nil
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginationParams(t *testing.T) {
+	sortable := pagination.Sortable{"id": "id", "name": "name"}
+
+	tests := map[string]struct {
+		language       string
+		request        *pagination.Request
+		expectedFields map[string]string
+	}{
+		"Valid": {
+			language: "en",
+			request:  &pagination.Request{Sort: "-name"},
+		},
+		"English": {
+			language:       "en",
+			request:        &pagination.Request{Sort: "password"},
+			expectedFields: map[string]string{"sort": "sort can only contain the fields id, name"},
+		},
+		"French": {
+			language:       "fr",
+			request:        &pagination.Request{Cursor: "forged"},
+			expectedFields: map[string]string{"cursor": "cursor doit être le curseur d'une page avec le même tri"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, _ := setupGinContext(http.MethodGet, "/", "", "")
+			ctx.Request.Header.Set("Accept-Language", test.language)
+			router.handleLanguageMiddleware()(ctx)
+
+			params, err := paginationParams(ctx, test.request, sortable, "id")
+
+			if test.expectedFields != nil {
+				assert.True(t, errors.Is(err, errcode.ErrInvalidParameters), "Error type should match")
+				assert.Equal(t, test.expectedFields, ctx.GetStringMapString(ContextKeyInvalidFields), "Invalid fields should be translated")
+				return
+			}
+			assert.NoError(t, err, "No error should have occurred")
+			assert.Equal(t, []pagination.Order{{Column: "name", Desc: true}}, params.Sort, "Sort should match")
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"github.com/sarrooo/go-clean/internal/services"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+)
+
+func registerSearchRoutes(group *gin.RouterGroup, svc services.ServiceInterface) {
+	Handle(group, http.MethodGet, "", searchController(svc))
+}
+
+// swagger:route GET /search search searchController
+//
+// Endpoint for searching artists and albums by name.
+// Every word must match the start of a word of the name, or a part of it with Postgres, accents and case are ignored.
+// The results are ranked by relevance, and their pages are found by number.
+// The fields of each result are selected with fields, e.g. fields=type,id,highlight.
+//
+// responses:
+//
+//	200: searchController
+//	400: errorResponse
+func searchController(svc services.ServiceInterface) HandlerFunc[viewmodel.SearchRequest, viewmodel.SearchResponse] {
+	return func(ctx *gin.Context, request *viewmodel.SearchRequest) (*viewmodel.SearchResponse, int, error) {
+		response := &viewmodel.SearchResponse{}
+
+		// The results have no relation to expand
+		_, err := itemsFieldsetParams(ctx, &request.Request, response.Body, "results", viewmodel.SearchResult{}, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		params := &pagination.Params{Limit: request.Limit, Page: request.Page}
+		if params.Limit == 0 {
+			params.Limit = pagination.DefaultLimit
+		}
+		if params.Page == 0 {
+			params.Page = 1
+		}
+		var types []string
+		if request.Type != "" {
+			types = []string{request.Type}
+		}
+		page, err := svc.Search(ctx.Request.Context(), request.Q, types, params)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		response.Body.Results = make([]*viewmodel.SearchResult, 0, len(page.Items))
+		for _, result := range page.Items {
+			response.Body.Results = append(response.Body.Results, &viewmodel.SearchResult{
+				Type:       result.Type,
+				ID:         result.ID,
+				Name:       result.Name,
+				Highlight:  result.Highlight,
+				Score:      result.Score,
+				ArtistID:   result.ArtistID,
+				ArtistName: result.ArtistName,
+			})
+		}
+		response.Body.Pagination = pagination.NewEnvelope(ctx.Request.URL, page.Info)
+
+		return response, 200, nil
+	}
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ControllerSuiteTest) TestSearchController() {
+	artistID, artistName := uint(1), "Stromae"
+	total := int64(3)
+	page := &pagination.Page[*dto.SearchResult]{
+		Items: []*dto.SearchResult{
+			{Type: dto.SearchTypeAlbums, ID: 4, Name: "Multitude", ArtistID: &artistID, ArtistName: &artistName, Score: 2, Highlight: "<mark>Multi</mark>tude"},
+		},
+		Info: pagination.Info{Limit: 1, Number: 2, NextNumber: 3, PrevNumber: 1, Total: &total},
+	}
+	response := &viewmodel.SearchResponse{}
+	response.Body.Results = []*viewmodel.SearchResult{
+		{Type: dto.SearchTypeAlbums, ID: 4, Name: "Multitude", ArtistID: &artistID, ArtistName: &artistName, Score: 2, Highlight: "<mark>Multi</mark>tude"},
+	}
+	response.Body.Pagination = pagination.Envelope{Limit: 1, Next: "/?page=3", Prev: "/?page=1", Page: 2, Total: &total}
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("Search", mock.Anything, "multi", []string{dto.SearchTypeAlbums}, &pagination.Params{Limit: 1, Page: 2}).Return(page, nil)
+			},
+			requestViewmodel: &viewmodel.SearchRequest{Q: "multi", Type: dto.SearchTypeAlbums, Limit: 1, Page: 2},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: response,
+			},
+		},
+		"Error from Search": {
+			setupMock: func() {
+				suite.svc.On("Search", mock.Anything, "?", []string(nil), &pagination.Params{Limit: pagination.DefaultLimit, Page: 1}).Return(nil, errcode.ErrInvalidParameters)
+			},
+			requestViewmodel: &viewmodel.SearchRequest{Q: "?"},
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(searchController(suite.svc)))
+}
@@ -1,11 +1,13 @@
 package controllers
 
 import (
+	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/gin-gonic/gin"
-	"github.com/sarrooo/go-clean/internal/services"
+	"github.com/sarrooo/go-clean/internal/openapi"
 	"github.com/sarrooo/go-clean/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -22,13 +24,17 @@ type controllerTestTable = map[string]controllerTest
 type controllerTest struct {
 	setupMock        func()
 	requestViewmodel interface{}
-	expected         controllerTestExpected
+	// Values set in the context by the middlewares, e.g. the If-Match version
+	context  map[string]interface{}
+	expected controllerTestExpected
 }
 
 type controllerTestExpected struct {
 	responseViewmodel interface{}
 	status            int
 	isError           bool
+	// Version set in the context for the ETag, if not 0
+	version uint
 }
 
 func (suite *ControllerSuiteTest) SetupSuite() {
@@ -41,23 +47,28 @@ func (suite *ControllerSuiteTest) SetupSuite() {
 func (suite *ControllerSuiteTest) SetupSubTest() {
 	// Before each sub test, reset the context
 	suite.ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	suite.ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
 
 	// Reset mocks calls
 	suite.svc.ExpectedCalls = nil
 }
 
-func (suite *ControllerSuiteTest) executeTestTable(tests controllerTestTable, controller func(svc services.ServiceInterface) gin.HandlerFunc) {
+// executeTestTable calls the controller with the request view model of each test, see handle
+func (suite *ControllerSuiteTest) executeTestTable(tests controllerTestTable, controller gin.HandlerFunc) {
 	for key, test := range tests {
 		suite.Run(key, func() {
 
 			// Setup mocks calls
 			test.setupMock()
 
-			// Set request viewmodel
+			// Set request viewmodel and middlewares values
 			suite.ctx.Set(ContextKeyRequestViewmodel, test.requestViewmodel)
+			for key, value := range test.context {
+				suite.ctx.Set(key, value)
+			}
 
 			// Call controller
-			controller(suite.svc)(suite.ctx)
+			controller(suite.ctx)
 
 			// Check mocks
 			suite.svc.AssertExpectations(suite.T())
@@ -65,6 +76,13 @@ func (suite *ControllerSuiteTest) executeTestTable(tests controllerTestTable, co
 			if test.expected.isError {
 				if (suite.ctx.Errors.Last() == nil) || (suite.ctx.Errors.Last().Err == nil) {
 					assert.Fail(suite.T(), "Error expected")
+					return
+				}
+				// The status code answered by the error handler, if expected
+				if test.expected.status != 0 {
+					statusCode, _, _ := errorDetails(suite.ctx.Errors.Last().Err, nil)
+					statusCode = routeErrorStatusCode(suite.ctx, suite.ctx.Errors.Last().Err, statusCode)
+					assert.Equal(suite.T(), test.expected.status, statusCode)
 				}
 				return
 			}
@@ -73,10 +91,32 @@ func (suite *ControllerSuiteTest) executeTestTable(tests controllerTestTable, co
 			responseViewmodel := suite.ctx.MustGet(ContextKeyResponseViewmodel)
 			assert.Equal(suite.T(), test.expected.status, suite.ctx.GetInt(ContextKeyStatusCode))
 			assert.Equal(suite.T(), test.expected.responseViewmodel, responseViewmodel)
+			if test.expected.version != 0 {
+				assert.Equal(suite.T(), test.expected.version, suite.ctx.GetUint(ContextKeyVersion))
+			}
+			suite.assertContract(responseViewmodel, suite.ctx.GetInt(ContextKeyStatusCode))
 		})
 	}
 }
 
+// assertContract checks the response view model against the committed OpenAPI document of the routes which respond it
+// A mismatch is a response which doesn't respect the document, e.g. a custom JSON encoding or a view model changed without it
+func (suite *ControllerSuiteTest) assertContract(responseViewmodel interface{}, statusCode int) {
+	body, hasBody, err := responseBody(responseViewmodel)
+	suite.Require().NoError(err)
+	if !hasBody {
+		body = nil
+	}
+	for _, info := range router.engine.Routes() {
+		route, ok := operations.Load(info.Method + " " + info.Path)
+		if !ok || route.(openapi.Route).Response != reflect.TypeOf(responseViewmodel) {
+			continue
+		}
+		err := router.contract.ValidateResponse(info.Method, info.Path, statusCode, body)
+		assert.NoError(suite.T(), err, "Response should match the OpenAPI document of %s %s", info.Method, info.Path)
+	}
+}
+
 func TestControllerSuite(t *testing.T) {
 	suite.Run(t, new(ControllerSuiteTest))
 }
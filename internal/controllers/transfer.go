@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/services"
+	"github.com/sarrooo/go-clean/internal/transfer"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/spf13/viper"
+)
+
+// Tags of the errors of an imported file
+const (
+	TagImportSize   = "import_size"
+	TagImportFormat = "import_format"
+)
+
+// importMaxSize is IMPORT_MAX_SIZE, the maximum size in bytes of an imported file (32 MiB by default)
+func importMaxSize() int64 {
+	if viper.GetInt64("IMPORT_MAX_SIZE") <= 0 {
+		return 32 << 20
+	}
+	return viper.GetInt64("IMPORT_MAX_SIZE")
+}
+
+// Size allowed in an import body for the other fields of the form and the multipart boundaries
+const importFormOverhead = 1 << 20
+
+// importSizeMiddleware limits the body of an import, a larger body is refused while it is read, before it is buffered
+// It must be called before the middlewares which read the body, e.g. the idempotency middleware
+func importSizeMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, importMaxSize()+importFormOverhead)
+		ctx.Next()
+	}
+}
+
+// importAsyncSize is IMPORT_ASYNC_SIZE, the size in bytes from which a file is imported in background (1 MiB by default)
+func importAsyncSize() int64 {
+	if viper.GetInt64("IMPORT_ASYNC_SIZE") <= 0 {
+		return 1 << 20
+	}
+	return viper.GetInt64("IMPORT_ASYNC_SIZE")
+}
+
+func registerExportRoutes(group *gin.RouterGroup, svc services.ServiceInterface) {
+	group.GET("/artists.csv", exportArtistsController(svc, transfer.FormatCSV))
+	group.GET("/artists.jsonl", exportArtistsController(svc, transfer.FormatJSONL))
+}
+
+func registerImportRoutes(group *gin.RouterGroup, svc services.ServiceInterface) {
+	Handle(group, http.MethodPost, "/artists", importArtistsController(svc))
+	Handle(group, http.MethodGet, "/jobs/:id", getImportJobController(svc))
+}
+
+// swagger:route GET /export/artists.csv export exportArtistsCSVController
+//
+// Endpoint for exporting the artists and their albums as CSV, the file is streamed.
+//
+// produces:
+// - text/csv
+//
+// responses:
+//
+//	200: exportArtistsResponse
+//	401: errorResponse
+//	403: errorResponse
+
+// swagger:route GET /export/artists.jsonl export exportArtistsJSONLController
+//
+// Endpoint for exporting the artists and their albums as JSON Lines, the file is streamed.
+//
+// produces:
+// - application/x-ndjson
+//
+// responses:
+//
+//	200: exportArtistsResponse
+//	401: errorResponse
+//	403: errorResponse
+func exportArtistsController(svc services.ServiceInterface, format string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Content-Type", transfer.ContentType(format))
+		ctx.Header("Content-Disposition", `attachment; filename="artists.`+format+`"`)
+
+		// The status is sent with the first batch, an error before it is answered like the other errors
+		// An error after it can only truncate the file
+		err := svc.ExportArtists(ctx.Request.Context(), format, ctx.Writer)
+		if err != nil {
+			if !ctx.Writer.Written() {
+				ctx.Writer.Header().Del("Content-Disposition")
+			}
+			ctx.Error(err)
+			return
+		}
+		ctx.Writer.WriteHeaderNow()
+	}
+}
+
+// swagger:route POST /import/artists import importArtistsController
+//
+// Endpoint for importing artists and their albums from a CSV or JSON Lines file.
+// Artists are upserted by name, and their missing albums are added.
+// The invalid lines are reported and skipped.
+// A large file, or a file sent with async, is imported in background: the job is answered with 202 and polled with its id.
+//
+// consumes:
+// - multipart/form-data
+//
+// responses:
+//
+//	200: importArtistsController
+//	202: importArtistsController
+//	400: errorResponse
+//	401: errorResponse
+//	403: errorResponse
+//	413: errorResponse
+func importArtistsController(svc services.ServiceInterface) HandlerFunc[viewmodel.ImportArtistsRequest, viewmodel.ImportArtistsResponse] {
+	return func(ctx *gin.Context, request *viewmodel.ImportArtistsRequest) (*viewmodel.ImportArtistsResponse, int, error) {
+		response := &viewmodel.ImportArtistsResponse{}
+
+		if maxSize := importMaxSize(); request.File.Size > maxSize {
+			setInvalidField(ctx, "file", TagImportSize, strconv.FormatInt(maxSize, 10), fmt.Sprintf("file can't be larger than %d bytes", maxSize))
+			return nil, 0, fmt.Errorf("%w: the file has %d bytes", errcode.ErrInvalidParameters, request.File.Size)
+		}
+		format := request.Format
+		if format == "" {
+			format = transfer.FormatOf(request.File.Filename)
+		}
+		if format == "" {
+			setInvalidField(ctx, "format", TagImportFormat, "", "format must be csv or jsonl, or the file must have a .csv or .jsonl extension")
+			return nil, 0, fmt.Errorf("%w: unknown format of %s", errcode.ErrInvalidParameters, request.File.Filename)
+		}
+
+		content, err := readFile(request)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+		}
+		imp := &dto.Import{
+			Format:  format,
+			Mapping: request.Mapping,
+			DryRun:  request.DryRun,
+			Content: content,
+		}
+
+		if request.Async || request.File.Size > importAsyncSize() {
+			job, err := svc.StartImportArtists(ctx.Request.Context(), imp)
+			if err != nil {
+				return nil, 0, err
+			}
+			response.Body = importJob(ctx, job, nil)
+
+			ctx.Header("Location", fmt.Sprintf("/import/jobs/%d", job.ID))
+			return response, http.StatusAccepted, nil
+		}
+
+		report, err := svc.ImportArtists(ctx.Request.Context(), imp)
+		if err != nil {
+			return nil, 0, err
+		}
+		response.Body = viewmodel.ImportJob{
+			Status: dto.ImportJobSucceeded,
+			DryRun: request.DryRun,
+			Report: importReport(ctx, report),
+		}
+
+		return response, http.StatusOK, nil
+	}
+}
+
+// readFile returns the content of the uploaded file
+func readFile(request *viewmodel.ImportArtistsRequest) ([]byte, error) {
+	file, err := request.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// swagger:route GET /import/jobs/{id} import getImportJobController
+//
+// Endpoint for polling an import job, its report is given once it succeeded.
+//
+// responses:
+//
+//	200: getImportJobController
+//	400: errorResponse
+//	401: errorResponse
+//	403: errorResponse
+func getImportJobController(svc services.ServiceInterface) HandlerFunc[viewmodel.GetImportJobRequest, viewmodel.GetImportJobResponse] {
+	return func(ctx *gin.Context, request *viewmodel.GetImportJobRequest) (*viewmodel.GetImportJobResponse, int, error) {
+		response := &viewmodel.GetImportJobResponse{}
+
+		job, err := svc.GetImportJob(ctx.Request.Context(), request.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var report *dto.ImportReport
+		if job.Report != "" {
+			if err := json.Unmarshal([]byte(job.Report), &report); err != nil {
+				return nil, 0, fmt.Errorf("import job %d report: %v", job.ID, err)
+			}
+		}
+		response.Body = importJob(ctx, job, report)
+
+		return response, http.StatusOK, nil
+	}
+}
+
+// importJob returns the view model of an import job, report is its decoded report
+func importJob(ctx *gin.Context, job *models.ImportJob, report *dto.ImportReport) viewmodel.ImportJob {
+	return viewmodel.ImportJob{
+		ID:         job.ID,
+		Status:     job.Status,
+		DryRun:     job.DryRun,
+		Report:     importReport(ctx, report),
+		Error:      job.Error,
+		FinishedAt: job.FinishedAt,
+	}
+}
+
+// importReport returns the view model of an import report, the validation errors of the lines are translated
+func importReport(ctx *gin.Context, report *dto.ImportReport) *viewmodel.ImportReport {
+	if report == nil {
+		return nil
+	}
+	lineErrors := make([]*viewmodel.ImportLineError, 0, len(report.Errors))
+	for _, lineError := range report.Errors {
+		message := lineError.Message
+		if lineError.Field != "" {
+			message = translateField(ctx, lineError.Field, lineError.Tag, lineError.Param, message)
+		}
+		lineErrors = append(lineErrors, &viewmodel.ImportLineError{
+			Line:    lineError.Line,
+			Field:   lineError.Field,
+			Message: message,
+		})
+	}
+	return &viewmodel.ImportReport{
+		Total:     report.Total,
+		Created:   report.Created,
+		Updated:   report.Updated,
+		Unchanged: report.Unchanged,
+		Failed:    report.Failed,
+		Errors:    lineErrors,
+	}
+}
@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/transfer"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// multipartForm returns the body and the content type of a form uploading a file with the fields
+func multipartForm(fileName, content string, fields map[string]string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		_ = writer.WriteField(name, value)
+	}
+	part, _ := writer.CreateFormFile("file", fileName)
+	_, _ = part.Write([]byte(content))
+	_ = writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+// fileHeader returns the header of an uploaded file
+func fileHeader(fileName, content string) *multipart.FileHeader {
+	body, contentType := multipartForm(fileName, content, nil)
+	request := httptest.NewRequest(http.MethodPost, "/", body)
+	request.Header.Set("Content-Type", contentType)
+	_ = request.ParseMultipartForm(1 << 20)
+	return request.MultipartForm.File["file"][0]
+}
+
+func (suite *ControllerSuiteTest) TestExportArtistsController() {
+	tests := map[string]struct {
+		format              string
+		exportErr           error
+		expectedStatus      int
+		expectedBody        string
+		expectedType        string
+		expectedDisposition string
+		isError             bool
+	}{
+		"CSV": {
+			format:              transfer.FormatCSV,
+			expectedStatus:      http.StatusOK,
+			expectedBody:        "id,name\n1,Eminem\n",
+			expectedType:        "text/csv; charset=utf-8",
+			expectedDisposition: `attachment; filename="artists.csv"`,
+		},
+		"Empty JSON Lines": {
+			format:              transfer.FormatJSONL,
+			expectedStatus:      http.StatusOK,
+			expectedType:        "application/x-ndjson",
+			expectedDisposition: `attachment; filename="artists.jsonl"`,
+		},
+		"Error before the first batch": {
+			format:    transfer.FormatCSV,
+			exportErr: errcode.ErrDatabase,
+			isError:   true,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			recorder := httptest.NewRecorder()
+			suite.ctx, _ = gin.CreateTestContext(recorder)
+			suite.ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			suite.svc.On("ExportArtists", mock.Anything, test.format, mock.Anything).
+				Run(func(args mock.Arguments) {
+					if test.expectedBody != "" {
+						_, _ = io.WriteString(args.Get(2).(io.Writer), test.expectedBody)
+					}
+				}).
+				Return(test.exportErr)
+
+			exportArtistsController(suite.svc, test.format)(suite.ctx)
+
+			suite.svc.AssertExpectations(suite.T())
+			if test.isError {
+				assert.ErrorIs(suite.T(), suite.ctx.Errors.Last().Err, test.exportErr)
+				assert.False(suite.T(), suite.ctx.Writer.Written(), "The error should be answered by the middlewares")
+				assert.Empty(suite.T(), suite.ctx.Writer.Header().Get("Content-Disposition"))
+				return
+			}
+			assert.Empty(suite.T(), suite.ctx.Errors)
+			assert.Equal(suite.T(), test.expectedStatus, recorder.Code)
+			assert.Equal(suite.T(), test.expectedBody, recorder.Body.String())
+			assert.Equal(suite.T(), test.expectedType, recorder.Header().Get("Content-Type"))
+			assert.Equal(suite.T(), test.expectedDisposition, recorder.Header().Get("Content-Disposition"))
+		})
+	}
+}
+
+func (suite *ControllerSuiteTest) TestImportArtistsController() {
+	content := "name,albums\nEminem,Recovery\n,Kamikaze\n"
+	file := fileHeader("artists.csv", content)
+	imp := &dto.Import{Format: transfer.FormatCSV, Mapping: map[string]string{"Artist": "name"}, Content: []byte(content)}
+	report := &dto.ImportReport{
+		Total:   2,
+		Created: 1,
+		Failed:  1,
+		Errors:  []*dto.ImportLineError{{Line: 3, Field: "name", Tag: "required", Message: "name is required"}},
+	}
+
+	success := &viewmodel.ImportArtistsResponse{}
+	success.Body = viewmodel.ImportJob{
+		Status: dto.ImportJobSucceeded,
+		Report: &viewmodel.ImportReport{
+			Total:   2,
+			Created: 1,
+			Failed:  1,
+			Errors:  []*viewmodel.ImportLineError{{Line: 3, Field: "name", Message: "name is required"}},
+		},
+	}
+	accepted := &viewmodel.ImportArtistsResponse{}
+	accepted.Body = viewmodel.ImportJob{ID: 7, Status: dto.ImportJobPending, DryRun: true}
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("ImportArtists", mock.Anything, imp).Return(report, nil)
+			},
+			requestViewmodel: &viewmodel.ImportArtistsRequest{File: file, Mapping: imp.Mapping},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: success,
+			},
+		},
+		"Async": {
+			setupMock: func() {
+				suite.svc.On("StartImportArtists", mock.Anything, &dto.Import{Format: transfer.FormatCSV, DryRun: true, Content: []byte(content)}).
+					Return(&models.ImportJob{ID: 7, Status: dto.ImportJobPending, DryRun: true}, nil)
+			},
+			requestViewmodel: &viewmodel.ImportArtistsRequest{File: file, DryRun: true, Async: true},
+			expected: controllerTestExpected{
+				status:            http.StatusAccepted,
+				responseViewmodel: accepted,
+			},
+		},
+		"Format of the request": {
+			setupMock: func() {
+				suite.svc.On("ImportArtists", mock.Anything, &dto.Import{Format: transfer.FormatJSONL, Content: []byte(`{"name":"Eminem"}`)}).
+					Return(nil, errcode.ErrInvalidParameters)
+			},
+			requestViewmodel: &viewmodel.ImportArtistsRequest{File: fileHeader("artists.txt", `{"name":"Eminem"}`), Format: transfer.FormatJSONL},
+			expected:         controllerTestExpected{isError: true},
+		},
+		"Unknown format": {
+			setupMock:        func() {},
+			requestViewmodel: &viewmodel.ImportArtistsRequest{File: fileHeader("artists.txt", content)},
+			expected:         controllerTestExpected{isError: true},
+		},
+		"Error from StartImportArtists": {
+			setupMock: func() {
+				suite.svc.On("StartImportArtists", mock.Anything, mock.Anything).Return(nil, errcode.ErrDatabase)
+			},
+			requestViewmodel: &viewmodel.ImportArtistsRequest{File: file, Async: true},
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(importArtistsController(suite.svc)))
+
+	suite.Run("Large file imported in background", func() {
+		viper.Set("IMPORT_ASYNC_SIZE", 8)
+		defer viper.Set("IMPORT_ASYNC_SIZE", nil)
+		suite.svc.On("StartImportArtists", mock.Anything, mock.Anything).Return(&models.ImportJob{ID: 7, Status: dto.ImportJobPending}, nil)
+		suite.ctx.Set(ContextKeyRequestViewmodel, &viewmodel.ImportArtistsRequest{File: file})
+
+		handle(importArtistsController(suite.svc))(suite.ctx)
+
+		suite.svc.AssertExpectations(suite.T())
+		assert.Equal(suite.T(), http.StatusAccepted, suite.ctx.GetInt(ContextKeyStatusCode))
+		assert.Equal(suite.T(), "/import/jobs/7", suite.ctx.Writer.Header().Get("Location"))
+	})
+
+	suite.Run("File too large", func() {
+		viper.Set("IMPORT_MAX_SIZE", 8)
+		defer viper.Set("IMPORT_MAX_SIZE", nil)
+		suite.ctx.Set(ContextKeyRequestViewmodel, &viewmodel.ImportArtistsRequest{File: file})
+
+		handle(importArtistsController(suite.svc))(suite.ctx)
+
+		suite.svc.AssertExpectations(suite.T())
+		assert.ErrorIs(suite.T(), suite.ctx.Errors.Last().Err, errcode.ErrInvalidParameters)
+		assert.Equal(suite.T(), map[string]string{"file": "file can't be larger than 8 bytes"}, suite.ctx.GetStringMapString(ContextKeyInvalidFields))
+	})
+}
+
+func (suite *ControllerSuiteTest) TestGetImportJobController() {
+	finishedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	succeeded := &viewmodel.GetImportJobResponse{}
+	succeeded.Body = viewmodel.ImportJob{
+		ID:         1,
+		Status:     dto.ImportJobSucceeded,
+		Report:     &viewmodel.ImportReport{Total: 1, Updated: 1, Errors: []*viewmodel.ImportLineError{}},
+		FinishedAt: &finishedAt,
+	}
+	failed := &viewmodel.GetImportJobResponse{}
+	failed.Body = viewmodel.ImportJob{ID: 1, Status: dto.ImportJobFailed, Error: "the header is missing", FinishedAt: &finishedAt}
+
+	tests := controllerTestTable{
+		"Succeeded": {
+			setupMock: func() {
+				suite.svc.On("GetImportJob", mock.Anything, uint(1)).Return(&models.ImportJob{
+					ID:         1,
+					Status:     dto.ImportJobSucceeded,
+					Report:     `{"total":1,"created":0,"updated":1,"unchanged":0,"failed":0,"errors":[]}`,
+					FinishedAt: &finishedAt,
+				}, nil)
+			},
+			requestViewmodel: &viewmodel.GetImportJobRequest{ID: 1},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: succeeded,
+			},
+		},
+		"Failed": {
+			setupMock: func() {
+				suite.svc.On("GetImportJob", mock.Anything, uint(1)).Return(&models.ImportJob{
+					ID:         1,
+					Status:     dto.ImportJobFailed,
+					Error:      "the header is missing",
+					FinishedAt: &finishedAt,
+				}, nil)
+			},
+			requestViewmodel: &viewmodel.GetImportJobRequest{ID: 1},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: failed,
+			},
+		},
+		"Error from GetImportJob": {
+			setupMock: func() {
+				suite.svc.On("GetImportJob", mock.Anything, uint(1)).Return(nil, errcode.ErrNotFound)
+			},
+			requestViewmodel: &viewmodel.GetImportJobRequest{ID: 1},
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(getImportJobController(suite.svc)))
+}
+
+func TestImportSizeMiddleware(t *testing.T) {
+	viper.Set("IMPORT_MAX_SIZE", 8)
+	defer viper.Set("IMPORT_MAX_SIZE", nil)
+
+	tests := map[string]struct {
+		content       string
+		expectedError error
+	}{
+		"Within the limit": {
+			content: "name\nEminem\n",
+		},
+		"Too large": {
+			content:       strings.Repeat("a", importFormOverhead+8),
+			expectedError: errcode.ErrRequestTooLarge,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			body, contentType := multipartForm("artists.csv", test.content, nil)
+			ctx, _ := setupGinContext(http.MethodPost, "/import/artists", body.String(), contentType)
+
+			importSizeMiddleware()(ctx)
+			requestViewmodelMiddleware(&viewmodel.ImportArtistsRequest{})(ctx)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, ctx.Errors.Last().Err, test.expectedError)
+				assert.Equal(t, http.StatusRequestEntityTooLarge, errorStatusCode(ctx.Errors.Last().Err))
+				return
+			}
+			assert.Empty(t, ctx.Errors)
+		})
+	}
+}
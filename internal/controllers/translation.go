@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/sarrooo/go-clean/internal/fieldset"
+	"github.com/sarrooo/go-clean/internal/filter"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"github.com/sarrooo/go-clean/internal/params"
+)
+
+// Translations of the field errors which are not validation errors, by locale and tag
+// {0} is the field and {1} the parameter of the rule
+var fieldTranslations = map[string]map[string]string{
+	"en": {
+		pagination.TagSort:       "{0} must be a list of fields separated by commas, e.g. -created_at,name",
+		pagination.TagSortable:   "{0} can only contain the fields {1}",
+		pagination.TagCursor:     "{0} must be the cursor of a page with the same sort",
+		filter.TagFilter:         "{0} can't be filtered",
+		filter.TagOperator:       "{0} can only be filtered with the operators {1}",
+		filter.TagValue:          "{0} must be a valid {1}",
+		filter.TagValues:         "{0} can't have more than {1} values",
+		fieldset.TagFields:       "{0} can't contain the unknown field {1}",
+		fieldset.TagExpand:       "{0} can only contain the relations {1}",
+		fieldset.TagExpandDepth:  "{0} can't contain relations deeper than {1} levels",
+		fieldset.TagFieldsExpand: "{0} can only contain the fields of {1} with expand={1}",
+		TagBulkMax:               "{0} can't contain more than {1} items",
+		TagImportSize:            "{0} can't be larger than {1} bytes",
+		TagImportFormat:          "{0} must be csv or jsonl, or the file must have a .csv or .jsonl extension",
+		params.TagValue:          "{0} must be a valid {1}",
+	},
+	"fr": {
+		pagination.TagSort:       "{0} doit être une liste de champs séparés par des virgules, par exemple -created_at,name",
+		pagination.TagSortable:   "{0} ne peut contenir que les champs {1}",
+		pagination.TagCursor:     "{0} doit être le curseur d'une page avec le même tri",
+		filter.TagFilter:         "{0} ne peut pas être filtré",
+		filter.TagOperator:       "{0} ne peut être filtré qu'avec les opérateurs {1}",
+		filter.TagValue:          "{0} doit être une valeur valide de type {1}",
+		filter.TagValues:         "{0} ne peut pas avoir plus de {1} valeurs",
+		fieldset.TagFields:       "{0} ne peut pas contenir le champ inconnu {1}",
+		fieldset.TagExpand:       "{0} ne peut contenir que les relations {1}",
+		fieldset.TagExpandDepth:  "{0} ne peut pas contenir de relations sur plus de {1} niveaux",
+		fieldset.TagFieldsExpand: "{0} ne peut contenir les champs de {1} qu'avec expand={1}",
+		TagBulkMax:               "{0} ne peut pas contenir plus de {1} éléments",
+		TagImportSize:            "{0} ne peut pas dépasser {1} octets",
+		TagImportFormat:          "{0} doit être csv ou jsonl, ou le fichier doit avoir l'extension .csv ou .jsonl",
+		params.TagValue:          "{0} doit être une valeur valide de type {1}",
+	},
+}
+
+func registerFieldTranslations(trans ut.Translator, locale string) {
+	translations, ok := fieldTranslations[locale]
+	if !ok {
+		translations = fieldTranslations["en"]
+	}
+	for tag, text := range translations {
+		_ = trans.Add(tag, text, true)
+	}
+}
+
+// setInvalidField translates the error of a field, and sets it in the context like the binding errors
+// message is used if there is no translation
+func setInvalidField(ctx *gin.Context, field, tag, param, message string) {
+	ctx.Set(ContextKeyInvalidFields, map[string]string{field: translateField(ctx, field, tag, param, message)})
+}
+
+// translateField returns the translated error of a field, message if there is no translation
+func translateField(ctx *gin.Context, field, tag, param, message string) string {
+	if trans, exists := ctx.Get(ContextKeyTranslator); exists {
+		if translated, err := trans.(ut.Translator).T(tag, field, param); err == nil {
+			return translated
+		}
+	}
+	return message
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/services"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+)
+
+// The trash routes answer 404 to an entity which is not in the trash
+var trashErrorStatusCodes = map[error]int{
+	errcode.ErrNotFound: http.StatusNotFound,
+}
+
+func registerTrashRoutes(group *gin.RouterGroup, svc services.ServiceInterface) {
+	Handle(group, http.MethodGet, "/:entity", listTrashController(svc))
+	Handle(group, http.MethodPost, "/:entity/:id/restore", restoreTrashController(svc))
+	Handle(group, http.MethodDelete, "/:entity/:id", purgeTrashController(svc))
+}
+
+// swagger:route GET /admin/trash/{entity} admin listTrashController
+//
+// Endpoint for listing soft deleted entities.
+//
+// responses:
+//
+//	200: listTrashController
+//	400: errorResponse
+//	401: errorResponse
+//	403: errorResponse
+func listTrashController(svc services.ServiceInterface) HandlerFunc[viewmodel.ListTrashRequest, viewmodel.ListTrashResponse] {
+	return func(ctx *gin.Context, request *viewmodel.ListTrashRequest) (*viewmodel.ListTrashResponse, int, error) {
+		response := &viewmodel.ListTrashResponse{}
+
+		items, err := svc.ListTrash(ctx.Request.Context(), request.Entity)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		response.Body.Items = items
+
+		return response, http.StatusOK, nil
+	}
+}
+
+// swagger:route POST /admin/trash/{entity}/{id}/restore admin restoreTrashController
+//
+// Endpoint for restoring a soft deleted entity.
+//
+// responses:
+//
+//	200: restoreTrashController
+//	400: errorResponse
+//	401: errorResponse
+//	403: errorResponse
+//	409: errorResponse
+func restoreTrashController(svc services.ServiceInterface) HandlerFunc[viewmodel.RestoreTrashRequest, viewmodel.RestoreTrashResponse] {
+	return func(ctx *gin.Context, request *viewmodel.RestoreTrashRequest) (*viewmodel.RestoreTrashResponse, int, error) {
+		response := &viewmodel.RestoreTrashResponse{}
+
+		err := svc.RestoreTrash(ctx.Request.Context(), request.Entity, request.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		response.Body.ID = request.ID
+
+		return response, http.StatusOK, nil
+	}
+}
+
+// swagger:route DELETE /admin/trash/{entity}/{id} admin purgeTrashController
+//
+// Endpoint for permanently deleting a soft deleted entity.
+//
+// responses:
+//
+//	200: purgeTrashController
+//	400: errorResponse
+//	401: errorResponse
+//	403: errorResponse
+//	409: errorResponse
+func purgeTrashController(svc services.ServiceInterface) HandlerFunc[viewmodel.PurgeTrashRequest, viewmodel.PurgeTrashResponse] {
+	return func(ctx *gin.Context, request *viewmodel.PurgeTrashRequest) (*viewmodel.PurgeTrashResponse, int, error) {
+		response := &viewmodel.PurgeTrashResponse{}
+
+		err := svc.PurgeTrash(ctx.Request.Context(), request.Entity, request.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		response.Body.ID = request.ID
+
+		return response, http.StatusOK, nil
+	}
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/viewmodel"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ControllerSuiteTest) TestListTrashController() {
+	items := []*dto.TrashItem{{ID: 1, Name: "Eminem", DeletedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	response := &viewmodel.ListTrashResponse{}
+	response.Body.Items = items
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("ListTrash", mock.Anything, dto.TrashEntityArtists).Return(items, nil)
+			},
+			requestViewmodel: &viewmodel.ListTrashRequest{Entity: dto.TrashEntityArtists},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: response,
+			},
+		},
+		"Error from ListTrash": {
+			setupMock: func() {
+				suite.svc.On("ListTrash", mock.Anything, dto.TrashEntityArtists).Return(nil, errcode.ErrDatabase)
+			},
+			requestViewmodel: &viewmodel.ListTrashRequest{Entity: dto.TrashEntityArtists},
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(listTrashController(suite.svc)))
+}
+
+func (suite *ControllerSuiteTest) TestRestoreTrashController() {
+	response := &viewmodel.RestoreTrashResponse{}
+	response.Body.ID = 1
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("RestoreTrash", mock.Anything, dto.TrashEntityAlbums, uint(1)).Return(nil)
+			},
+			requestViewmodel: &viewmodel.RestoreTrashRequest{Entity: dto.TrashEntityAlbums, ID: 1},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: response,
+			},
+		},
+		"Error from RestoreTrash": {
+			setupMock: func() {
+				suite.svc.On("RestoreTrash", mock.Anything, dto.TrashEntityAlbums, uint(1)).Return(errcode.ErrConflict)
+			},
+			requestViewmodel: &viewmodel.RestoreTrashRequest{Entity: dto.TrashEntityAlbums, ID: 1},
+			expected:         controllerTestExpected{isError: true},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(restoreTrashController(suite.svc)))
+}
+
+func (suite *ControllerSuiteTest) TestPurgeTrashController() {
+	response := &viewmodel.PurgeTrashResponse{}
+	response.Body.ID = 1
+
+	tests := controllerTestTable{
+		"Success": {
+			setupMock: func() {
+				suite.svc.On("PurgeTrash", mock.Anything, dto.TrashEntityArtists, uint(1)).Return(nil)
+			},
+			requestViewmodel: &viewmodel.PurgeTrashRequest{Entity: dto.TrashEntityArtists, ID: 1},
+			expected: controllerTestExpected{
+				status:            http.StatusOK,
+				responseViewmodel: response,
+			},
+		},
+		"Error from PurgeTrash": {
+			setupMock: func() {
+				suite.svc.On("PurgeTrash", mock.Anything, dto.TrashEntityArtists, uint(1)).Return(errcode.ErrNotFound)
+			},
+			requestViewmodel: &viewmodel.PurgeTrashRequest{Entity: dto.TrashEntityArtists, ID: 1},
+			context:          map[string]interface{}{ContextKeyErrorStatusCodes: trashErrorStatusCodes},
+			expected:         controllerTestExpected{isError: true, status: http.StatusNotFound},
+		},
+	}
+
+	suite.executeTestTable(tests, handle(purgeTrashController(suite.svc)))
+}
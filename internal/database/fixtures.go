@@ -0,0 +1,225 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sarrooo/go-clean/internal/models"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+//go:embed fixtures
+var fixtureFiles embed.FS
+
+// Prefix of a reference to another fixture record, e.g. `$Artist.eminem`
+const fixtureRefPrefix = "$"
+
+// Name of the record field holding the reference name of the record
+const fixtureRefField = "_ref"
+
+// Column of the User password, hashed before it is stored
+const fixturePasswordColumn = "password"
+
+// Fixture is the content of a fixture file (YAML or JSON)
+//
+//	model: Album
+//	key: [name, artist_id]
+//	records:
+//	  - _ref: kamikaze
+//	    name: Kamikaze
+//	    artist_id: $Artist.eminem
+//
+// Records are upserted by their natural key: a record whose key columns match an existing row updates it,
+// a soft deleted row is restored
+// A value starting with `$` references the ID of a record loaded before, by `<model>.<_ref>`
+// The password of a User is hashed like the password of a registered user
+type Fixture struct {
+	Model   string                   `json:"model" yaml:"model"`
+	Key     []string                 `json:"key" yaml:"key"`
+	Records []map[string]interface{} `json:"records" yaml:"records"`
+}
+
+// FixtureRefs maps fixture references (`<model>.<_ref>`) to the ID of the loaded record
+type FixtureRefs map[string]uint
+
+// fixtureModels lists models which can be loaded from fixtures
+func fixtureModels() map[string]interface{} {
+	return map[string]interface{}{
+		"User":      models.User{},
+		"Artist":    models.Artist{},
+		"Album":     models.Album{},
+		"UserAlbum": models.UserAlbum{},
+	}
+}
+
+// FixtureSets returns the names of the embedded fixture sets (dev, demo, test, ...)
+func FixtureSets() ([]string, error) {
+	entries, err := fs.ReadDir(fixtureFiles, "fixtures")
+	if err != nil {
+		return nil, err
+	}
+	sets := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			sets = append(sets, entry.Name())
+		}
+	}
+	return sets, nil
+}
+
+// LoadFixtures loads an embedded fixture set
+func LoadFixtures(ctx context.Context, db *gorm.DB, set string) (FixtureRefs, error) {
+	return LoadFixturesFS(ctx, db, fixtureFiles, path.Join("fixtures", set))
+}
+
+// LoadFixturesFS loads all fixture files of a directory, in the order of their names, in one transaction
+func LoadFixturesFS(ctx context.Context, db *gorm.DB, fsys fs.FS, dir string) (refs FixtureRefs, err error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("fixtures %s: %w", dir, err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	fixtures := make([]*Fixture, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		fixture, err := readFixture(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, fixture)
+	}
+
+	refs = FixtureRefs{}
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, fixture := range fixtures {
+			if err := loadFixture(tx, fixture, refs); err != nil {
+				return fmt.Errorf("fixture %s: %w", fixture.Model, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+func readFixture(fsys fs.FS, fileName string) (*Fixture, error) {
+	content, err := fs.ReadFile(fsys, fileName)
+	if err != nil {
+		return nil, err
+	}
+	fixture := &Fixture{}
+	switch path.Ext(fileName) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, fixture)
+	case ".json":
+		err = json.Unmarshal(content, fixture)
+	default:
+		return nil, fmt.Errorf("fixture %s: unknown file extension", fileName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fixture %s: %w", fileName, err)
+	}
+	if len(fixture.Key) == 0 {
+		return nil, fmt.Errorf("fixture %s: a natural key is required", fileName)
+	}
+	return fixture, nil
+}
+
+func loadFixture(tx *gorm.DB, fixture *Fixture, refs FixtureRefs) error {
+	model, ok := fixtureModels()[fixture.Model]
+	if !ok {
+		return fmt.Errorf("unknown model")
+	}
+	modelType := reflect.TypeOf(model)
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+
+	for i, record := range fixture.Records {
+		ref, _ := record[fixtureRefField].(string)
+		delete(record, fixtureRefField)
+
+		// Resolve references and check columns
+		for column, value := range record {
+			if stmt.Schema.LookUpField(column) == nil {
+				return fmt.Errorf("record %d: unknown column %s", i, column)
+			}
+			if refName, isRef := value.(string); isRef && strings.HasPrefix(refName, fixtureRefPrefix) {
+				id, ok := refs[strings.TrimPrefix(refName, fixtureRefPrefix)]
+				if !ok {
+					return fmt.Errorf("record %d: unknown reference %s", i, refName)
+				}
+				record[column] = id
+			}
+		}
+
+		if password, ok := record[fixturePasswordColumn].(string); ok && fixture.Model == "User" {
+			hash, err := models.HashPassword(password)
+			if err != nil {
+				return fmt.Errorf("record %d: %w", i, err)
+			}
+			record[fixturePasswordColumn] = hash
+		}
+
+		// Find the existing row by natural key, even soft deleted
+		naturalKey := make(map[string]interface{}, len(fixture.Key))
+		for _, column := range fixture.Key {
+			value, ok := record[column]
+			if !ok {
+				return fmt.Errorf("record %d: missing key column %s", i, column)
+			}
+			naturalKey[column] = value
+		}
+		row := reflect.New(modelType)
+		res := tx.Unscoped().Where(naturalKey).Limit(1).Find(row.Interface())
+		if res.Error != nil {
+			return res.Error
+		}
+
+		// Upsert the record, and restore it
+		if field := stmt.Schema.LookUpField("deleted_at"); field != nil {
+			if err := field.Set(tx.Statement.Context, row.Elem(), gorm.DeletedAt{}); err != nil {
+				return fmt.Errorf("record %d: %w", i, err)
+			}
+		}
+		for column, value := range record {
+			field := stmt.Schema.LookUpField(column)
+			if err := field.Set(tx.Statement.Context, row.Elem(), value); err != nil {
+				return fmt.Errorf("record %d: column %s: %w", i, column, err)
+			}
+		}
+		if res.RowsAffected == 0 {
+			err := tx.Create(row.Interface()).Error
+			if err != nil {
+				return fmt.Errorf("record %d: %w", i, err)
+			}
+		} else {
+			err := tx.Unscoped().Save(row.Interface()).Error
+			if err != nil {
+				return fmt.Errorf("record %d: %w", i, err)
+			}
+		}
+
+		if ref != "" {
+			id, _ := stmt.Schema.PrioritizedPrimaryField.ValueOf(tx.Statement.Context, row.Elem())
+			refs[fixture.Model+"."+ref] = uint(reflect.ValueOf(id).Uint())
+		}
+	}
+	return nil
+}
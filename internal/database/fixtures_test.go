@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"testing/fstest"
+
+	"github.com/sarrooo/go-clean/internal/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (suite *DatabaseSuiteTest) TestLoadFixtures() {
+	jsonFixtures := fstest.MapFS{
+		"fixtures/01_artists.json": {Data: []byte(`{
+			"model": "Artist",
+			"key": ["name"],
+			"records": [{"_ref": "stromae", "name": "Stromae"}]
+		}`)},
+		"fixtures/02_albums.json": {Data: []byte(`{
+			"model": "Album",
+			"key": ["name", "artist_id"],
+			"records": [{"name": "Multitude", "artist_id": "$Artist.stromae"}]
+		}`)},
+	}
+	unknownRefFixtures := fstest.MapFS{
+		"fixtures/01_albums.yaml": {Data: []byte("model: Album\nkey: [name]\nrecords:\n  - name: Anti\n    artist_id: $Artist.unknown\n")},
+	}
+
+	type expectedType struct {
+		isError bool
+		artists int64
+		albums  int64
+	}
+
+	tests := map[string]struct {
+		load     func() error
+		expected expectedType
+	}{
+		"Embedded set": {
+			load: func() error {
+				refs, err := LoadFixtures(context.Background(), suite.db, "test")
+				suite.Assert().Contains(refs, "Album.kamikaze")
+				return err
+			},
+			expected: expectedType{artists: 2, albums: 2},
+		},
+		"Loading twice is idempotent": {
+			load: func() error {
+				if _, err := LoadFixtures(context.Background(), suite.db, "test"); err != nil {
+					return err
+				}
+				_, err := LoadFixtures(context.Background(), suite.db, "test")
+				return err
+			},
+			expected: expectedType{artists: 2, albums: 2},
+		},
+		"JSON files with references": {
+			load: func() error {
+				refs, err := LoadFixturesFS(context.Background(), suite.db, jsonFixtures, "fixtures")
+				var album models.Album
+				suite.db.Where("name = ?", "Multitude").First(&album)
+				suite.Assert().Equal(refs["Artist.stromae"], album.ArtistID)
+				return err
+			},
+			expected: expectedType{artists: 1, albums: 1},
+		},
+		"Soft deleted record is restored": {
+			load: func() error {
+				if _, err := LoadFixtures(context.Background(), suite.db, "test"); err != nil {
+					return err
+				}
+				suite.Require().NoError(suite.db.Where("email = ?", "jhon.doe@mail.com").Delete(&models.User{}).Error)
+				_, err := LoadFixtures(context.Background(), suite.db, "test")
+				var users int64
+				suite.db.Model(&models.User{}).Where("email = ?", "jhon.doe@mail.com").Count(&users)
+				suite.Assert().Equal(int64(1), users, "User should be restored")
+				return err
+			},
+			expected: expectedType{artists: 2, albums: 2},
+		},
+		"Password is hashed": {
+			load: func() error {
+				_, err := LoadFixtures(context.Background(), suite.db, "test")
+				var user models.User
+				suite.db.Where("email = ?", "jhon.doe@mail.com").First(&user)
+				suite.Assert().NoError(bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("password")), "Seeded user should log in")
+				return err
+			},
+			expected: expectedType{artists: 2, albums: 2},
+		},
+		"Unknown reference": {
+			load: func() error {
+				_, err := LoadFixturesFS(context.Background(), suite.db, unknownRefFixtures, "fixtures")
+				return err
+			},
+			expected: expectedType{isError: true},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			migrator, err := NewMigrator(suite.db)
+			suite.Require().NoError(err)
+			_, err = migrator.Up(context.Background())
+			suite.Require().NoError(err)
+
+			err = test.load()
+			if test.expected.isError {
+				suite.Assert().Error(err, "Error should have occurred")
+				return
+			}
+			suite.Assert().NoError(err, "No error should have occurred")
+
+			var artists, albums int64
+			suite.db.Model(&models.Artist{}).Count(&artists)
+			suite.db.Model(&models.Album{}).Count(&albums)
+			suite.Assert().Equal(test.expected.artists, artists)
+			suite.Assert().Equal(test.expected.albums, albums)
+		})
+	}
+}
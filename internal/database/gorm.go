@@ -1,42 +1,208 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 
 	"gorm.io/gorm/logger"
 
+	"github.com/sarrooo/go-clean/internal/config"
 	"github.com/sarrooo/go-clean/internal/errcode"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-func dsnBuilder() string {
-	host := viper.GetString("DATABASE_HOST")
-	port := viper.GetString("POSTGRES_PORT")
-	user := viper.GetString("POSTGRES_USER")
-	password := viper.GetString("POSTGRES_PASSWORD")
-	dbName := viper.GetString("POSTGRES_DB")
-	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=Europe/Paris", host, port, user, password, dbName)
+// Values of DATABASE_DRIVER
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+)
+
+// setting returns the DATABASE_* setting, or the legacy POSTGRES_* one if not defined
+func setting(key, legacyKey, defaultValue string) string {
+	if value := viper.GetString(key); value != "" {
+		return value
+	}
+	if legacyKey != "" {
+		if value := viper.GetString(legacyKey); value != "" {
+			return value
+		}
+	}
+	return defaultValue
+}
+
+// Driver returns the database driver, postgres by default
+func Driver() string {
+	return setting("DATABASE_DRIVER", "", DriverPostgres)
+}
+
+func dsnBuilder(driver string) (string, error) {
+	host := setting("DATABASE_HOST", "", "localhost")
+	user := setting("DATABASE_USER", "POSTGRES_USER", "")
+	password := setting("DATABASE_PASSWORD", "POSTGRES_PASSWORD", "")
+	dbName := setting("DATABASE_NAME", "POSTGRES_DB", "")
+	sslMode := setting("DATABASE_SSLMODE", "", "disable")
+	timeZone := setting("DATABASE_TIMEZONE", "", "Europe/Paris")
+
+	switch driver {
+	case DriverPostgres:
+		port := setting("DATABASE_PORT", "POSTGRES_PORT", "5432")
+		return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=%s", host, port, user, password, dbName, sslMode, timeZone), nil
+	case DriverMySQL:
+		// The legacy POSTGRES_PORT is the port of PostgreSQL, not MySQL
+		port := setting("DATABASE_PORT", "", "3306")
+		// MySQL has no sslmode, map it to the tls parameter
+		tls := map[string]string{
+			"disable":     "false",
+			"allow":       "preferred",
+			"prefer":      "preferred",
+			"require":     "skip-verify",
+			"verify-ca":   "true",
+			"verify-full": "true",
+		}[sslMode]
+		if tls == "" {
+			return "", fmt.Errorf("%w: unknown DATABASE_SSLMODE %s", errcode.ErrConfigurationFailed, sslMode)
+		}
+		params := url.Values{}
+		params.Set("charset", "utf8mb4")
+		params.Set("parseTime", "true")
+		params.Set("loc", timeZone)
+		params.Set("tls", tls)
+		// SQL migrations can contain several statements
+		params.Set("multiStatements", "true")
+		return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?%s", user, password, host, port, dbName, params.Encode()), nil
+	case DriverSQLite:
+		path := setting("DATABASE_PATH", "", "go-clean.db")
+		return fmt.Sprintf("file:%s?_foreign_keys=1&_busy_timeout=5000", path), nil
+	default:
+		return "", fmt.Errorf("%w: unknown DATABASE_DRIVER %s", errcode.ErrConfigurationFailed, driver)
+	}
 }
 
-func NewGormClient() (*gorm.DB, error) {
-	dsn := dsnBuilder()
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+// dialector returns the GORM dialector of the configured driver
+func dialector() (gorm.Dialector, error) {
+	driver := Driver()
+	dsn, err := dsnBuilder(driver)
+	if err != nil {
+		return nil, err
+	}
+	switch driver {
+	case DriverMySQL:
+		return mysql.Open(dsn), nil
+	case DriverSQLite:
+		return sqlite.Open(dsn), nil
+	default:
+		return postgres.Open(dsn), nil
+	}
+}
+
+// SeedSet returns the fixture set used to seed the database
+// It is SEED_SET if defined, the current environment otherwise
+func SeedSet() string {
+	if set := viper.GetString("SEED_SET"); set != "" {
+		return set
+	}
+	return config.Env()
+}
+
+// Open opens the database connection with the DATABASE_DRIVER driver, without migrating nor seeding it
+// The connection is retried while the database is not reachable, and the pool is configured
+// Reads are routed to DATABASE_REPLICAS if defined
+func Open(log *zap.Logger) (*gorm.DB, error) {
+	dial, err := dialector()
+	if err != nil {
+		return nil, err
+	}
+	db, err := connect(log, dial, &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// Constraint violations are returned as gorm.ErrDuplicatedKey whatever the driver
+		TranslateError: true,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", errcode.ErrConfigurationFailed, err)
+		return nil, err
+	}
+	err = configurePool(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Route reads to DATABASE_REPLICAS
+	if dsns := replicaDSNs(); len(dsns) != 0 {
+		replicas, err := openReplicas(Driver(), dsns)
+		if err != nil {
+			return nil, err
+		}
+		err = db.Use(NewReplicaSet(log, replicas))
+		if err != nil {
+			return nil, fmt.Errorf("%w: replicas: %v", errcode.ErrConfigurationFailed, err)
+		}
+	}
+	return db, nil
+}
+
+// NewGormClient opens the database and prepares it for the server
+// Pending migrations are handled according to MIGRATE_MODE, and the fixtures are loaded if SEED_DB is true
+func NewGormClient(log *zap.Logger) (*gorm.DB, error) {
+	db, err := Open(log)
+	if err != nil {
+		return nil, err
 	}
 
-	err = migrate(db)
+	err = migrateOnBoot(db)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabaseMigrate, err)
+		return nil, err
 	}
 
 	if viper.GetBool("SEED_DB") {
-		CreateTestingEntities(db)
+		_, err = LoadFixtures(context.Background(), db, SeedSet())
+		if err != nil {
+			return nil, fmt.Errorf("%w: seed: %v", errcode.ErrDatabase, err)
+		}
 	}
 
 	return db, nil
 }
+
+// migrateOnBoot handles pending migrations according to MIGRATE_MODE
+// - auto: apply pending migrations (default outside production)
+// - check: refuse to start if there are pending migrations (default in production)
+// - off: do nothing
+func migrateOnBoot(db *gorm.DB) error {
+	mode := viper.GetString("MIGRATE_MODE")
+	if mode == "" {
+		mode = "auto"
+		if config.IsProduction() {
+			mode = "check"
+		}
+	}
+	if mode == "off" {
+		return nil
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	switch mode {
+	case "auto":
+		_, err = migrator.Up(context.Background())
+		return err
+	case "check":
+		pending, err := migrator.Pending(context.Background())
+		if err != nil {
+			return err
+		}
+		if len(pending) != 0 {
+			return fmt.Errorf("%w: %d pending migrations, run the migrate command", errcode.ErrPendingMigration, len(pending))
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown MIGRATE_MODE %s", errcode.ErrConfigurationFailed, mode)
+	}
+}
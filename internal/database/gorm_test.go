@@ -0,0 +1,116 @@
+package database
+
+import (
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/spf13/viper"
+)
+
+func (suite *DatabaseSuiteTest) TestDsnBuilder() {
+	type expectedType struct {
+		dsn string
+		err error
+	}
+
+	tests := map[string]struct {
+		driver   string
+		settings map[string]string
+		expected expectedType
+	}{
+		"Postgres with legacy settings": {
+			driver: DriverPostgres,
+			settings: map[string]string{
+				"DATABASE_HOST":     "db",
+				"POSTGRES_USER":     "user",
+				"POSTGRES_PASSWORD": "secret",
+				"POSTGRES_DB":       "go-clean",
+				"POSTGRES_PORT":     "5433",
+			},
+			expected: expectedType{
+				dsn: "host=db port=5433 user=user password=secret dbname=go-clean sslmode=disable TimeZone=Europe/Paris",
+			},
+		},
+		"Postgres with sslmode and timezone": {
+			driver: DriverPostgres,
+			settings: map[string]string{
+				"DATABASE_HOST":     "db",
+				"DATABASE_USER":     "user",
+				"DATABASE_PASSWORD": "secret",
+				"DATABASE_NAME":     "go-clean",
+				"DATABASE_SSLMODE":  "verify-full",
+				"DATABASE_TIMEZONE": "UTC",
+			},
+			expected: expectedType{
+				dsn: "host=db port=5432 user=user password=secret dbname=go-clean sslmode=verify-full TimeZone=UTC",
+			},
+		},
+		"MySQL": {
+			driver: DriverMySQL,
+			settings: map[string]string{
+				"DATABASE_HOST":     "db",
+				"DATABASE_USER":     "user",
+				"DATABASE_PASSWORD": "secret",
+				"DATABASE_NAME":     "go-clean",
+				"DATABASE_SSLMODE":  "require",
+				"DATABASE_TIMEZONE": "UTC",
+			},
+			expected: expectedType{
+				dsn: "user:secret@tcp(db:3306)/go-clean?charset=utf8mb4&loc=UTC&multiStatements=true&parseTime=true&tls=skip-verify",
+			},
+		},
+		"MySQL ignores the legacy Postgres port": {
+			driver: DriverMySQL,
+			settings: map[string]string{
+				"DATABASE_HOST":     "db",
+				"DATABASE_USER":     "user",
+				"DATABASE_PASSWORD": "secret",
+				"DATABASE_NAME":     "go-clean",
+				"POSTGRES_PORT":     "5432",
+			},
+			expected: expectedType{
+				dsn: "user:secret@tcp(db:3306)/go-clean?charset=utf8mb4&loc=Europe%2FParis&multiStatements=true&parseTime=true&tls=false",
+			},
+		},
+		"MySQL with unknown sslmode": {
+			driver: DriverMySQL,
+			settings: map[string]string{
+				"DATABASE_SSLMODE": "unknown",
+			},
+			expected: expectedType{
+				err: errcode.ErrConfigurationFailed,
+			},
+		},
+		"SQLite": {
+			driver: DriverSQLite,
+			settings: map[string]string{
+				"DATABASE_PATH": "/tmp/go-clean.db",
+			},
+			expected: expectedType{
+				dsn: "file:/tmp/go-clean.db?_foreign_keys=1&_busy_timeout=5000",
+			},
+		},
+		"Unknown driver": {
+			driver: "oracle",
+			expected: expectedType{
+				err: errcode.ErrConfigurationFailed,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			viper.Reset()
+			defer viper.Reset()
+			for key, value := range test.settings {
+				viper.Set(key, value)
+			}
+
+			dsn, err := dsnBuilder(test.driver)
+			if test.expected.err != nil {
+				suite.Assert().ErrorIs(err, test.expected.err, "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expected.dsn, dsn, "DSN should match")
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Maximum duration of a health check ping
+const healthCheckTimeout = 2 * time.Second
+
+// HealthStatus is the result of the last database health check
+type HealthStatus struct {
+	Healthy   bool
+	CheckedAt time.Time
+	Err       error
+	Stats     sql.DBStats
+}
+
+// HealthChecker pings the database periodically and keeps the last result
+// It is used by readiness probes, which must not hit the database on each call
+type HealthChecker struct {
+	db       *gorm.DB
+	logger   *zap.Logger
+	interval time.Duration
+
+	mu     sync.RWMutex
+	status HealthStatus
+}
+
+// NewHealthChecker creates a health checker pinging every DATABASE_HEALTH_INTERVAL (default 15s)
+func NewHealthChecker(db *gorm.DB, logger *zap.Logger) *HealthChecker {
+	return &HealthChecker{
+		db:       db,
+		logger:   logger,
+		interval: durationSetting("DATABASE_HEALTH_INTERVAL", 15*time.Second),
+	}
+}
+
+// Run checks the database health until the context is canceled
+func (hc *HealthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(hc.interval)
+	defer ticker.Stop()
+	for {
+		hc.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check pings the database now, records and logs the result
+// Health changes are logged, pool statistics are logged at debug level,
+// and a warning is logged when requests waited for a connection since the last check
+func (hc *HealthChecker) Check(ctx context.Context) HealthStatus {
+	status := HealthStatus{CheckedAt: time.Now()}
+	sqlDB, err := hc.db.DB()
+	if err == nil {
+		pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err = sqlDB.PingContext(pingCtx)
+		cancel()
+		status.Stats = sqlDB.Stats()
+	}
+	status.Healthy = err == nil
+	status.Err = err
+
+	// Replicas are checked too, reads fall back to the primary while they are unhealthy
+	if replicaSet := replicaSetOf(hc.db); replicaSet != nil {
+		replicaSet.Check(ctx)
+	}
+
+	hc.mu.Lock()
+	previous := hc.status
+	hc.status = status
+	hc.mu.Unlock()
+
+	switch {
+	case !status.Healthy && (previous.Healthy || previous.CheckedAt.IsZero()):
+		hc.logger.Error("database unhealthy", zap.Error(err))
+	case status.Healthy && !previous.Healthy && !previous.CheckedAt.IsZero():
+		hc.logger.Info("database healthy again")
+	}
+	if waited := status.Stats.WaitCount - previous.Stats.WaitCount; waited > 0 && !previous.CheckedAt.IsZero() {
+		hc.logger.Warn("database pool exhausted, requests waited for a connection",
+			zap.Int64("waited", waited),
+			zap.Duration("wait_duration", status.Stats.WaitDuration-previous.Stats.WaitDuration),
+			zap.Int("max_open", status.Stats.MaxOpenConnections))
+	}
+	hc.logger.Debug("database pool",
+		zap.Int("max_open", status.Stats.MaxOpenConnections),
+		zap.Int("open", status.Stats.OpenConnections),
+		zap.Int("in_use", status.Stats.InUse),
+		zap.Int("idle", status.Stats.Idle),
+		zap.Int64("wait_count", status.Stats.WaitCount),
+		zap.Duration("wait_duration", status.Stats.WaitDuration),
+		zap.Int64("max_idle_closed", status.Stats.MaxIdleClosed),
+		zap.Int64("max_lifetime_closed", status.Stats.MaxLifetimeClosed))
+
+	return status
+}
+
+// Status returns the result of the last check
+func (hc *HealthChecker) Status() HealthStatus {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.status
+}
+
+// Ready returns an error if the last check failed
+// The database is checked now if it was never checked
+func (hc *HealthChecker) Ready(ctx context.Context) error {
+	status := hc.Status()
+	if status.CheckedAt.IsZero() {
+		status = hc.Check(ctx)
+	}
+	if !status.Healthy {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, status.Err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+func (suite *DatabaseSuiteTest) TestHealthChecker() {
+	tests := map[string]struct {
+		closeDB  bool
+		expected bool
+	}{
+		"Healthy": {
+			closeDB:  false,
+			expected: true,
+		},
+		"Unhealthy": {
+			closeDB:  true,
+			expected: false,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			if test.closeDB {
+				sqlDB, err := suite.db.DB()
+				suite.Require().NoError(err)
+				sqlDB.Close()
+			}
+			checker := NewHealthChecker(suite.db, zap.NewNop())
+
+			err := checker.Ready(context.Background())
+
+			suite.Assert().Equal(test.expected, err == nil, "Readiness should match")
+			suite.Assert().Equal(test.expected, checker.Status().Healthy, "Status should match")
+			suite.Assert().False(checker.Status().CheckedAt.IsZero(), "Database should have been checked")
+		})
+	}
+}
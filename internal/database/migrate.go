@@ -1,30 +1,385 @@
 package database
 
 import (
-	"github.com/sarrooo/go-clean/internal/models"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/errcode"
 	"gorm.io/gorm"
 )
 
-func migrate(DB *gorm.DB) error {
-	if err := DB.AutoMigrate(all()...); err != nil {
+//go:embed migrations/*.sql
+var sqlMigrationFiles embed.FS
+
+// Key of the advisory lock taken while migrating
+// It prevents several replicas booting at the same time to run the same migrations
+const migrationLockKey = 72616111
+
+// Migration is a versioned change of the database schema
+// Migrations are written in SQL (migrations folder) or in Go (goMigrations)
+type Migration struct {
+	Version uint
+	Name    string
+	// Revision of a Go migration, starting at 1, it must be bumped when its code changes
+	// The code can't be hashed, the revision is in its checksum instead
+	Revision uint
+	Checksum string
+	Up       func(tx *gorm.DB) error
+	Down     func(tx *gorm.DB) error
+}
+
+// MigrationStatus tells if a migration was applied
+type MigrationStatus struct {
+	*Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// schemaMigration is a row of the schema_migrations table
+type schemaMigration struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+}
+
+// NewMigrator loads SQL and Go migrations, ordered by version
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	sqlMigrations, err := loadSQLMigrations(sqlMigrationFiles, "migrations", db.Dialector.Name())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabaseMigrate, err)
+	}
+
+	codeMigrations := goMigrations()
+	if err := checksumGoMigrations(codeMigrations); err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabaseMigrate, err)
+	}
+
+	migrations := append(sqlMigrations, codeMigrations...)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("%w: duplicated migration version %d", errcode.ErrDatabaseMigrate, migrations[i].Version)
+		}
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies all pending migrations and returns them
+func (m *Migrator) Up(ctx context.Context) (applied []*Migration, err error) {
+	err = m.withLock(ctx, func(conn *gorm.DB) error {
+		pending, err := m.pending(conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range pending {
+			err := m.transaction(conn, func(tx *gorm.DB) error {
+				if err := migration.Up(tx); err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					Checksum:  migration.Checksum,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %v", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return applied, fmt.Errorf("%w: %v", errcode.ErrDatabaseMigrate, err)
+	}
+	return applied, nil
+}
+
+// Down reverts the last `steps` applied migrations and returns them
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted []*Migration, err error) {
+	err = m.withLock(ctx, func(conn *gorm.DB) error {
+		statuses, err := m.status(conn)
+		if err != nil {
+			return err
+		}
+		for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := statuses[i]
+			if !migration.Applied {
+				continue
+			}
+			err := m.transaction(conn, func(tx *gorm.DB) error {
+				if err := migration.Down(tx); err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %v", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration.Migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return reverted, fmt.Errorf("%w: %v", errcode.ErrDatabaseMigrate, err)
+	}
+	return reverted, nil
+}
+
+// Status returns all known migrations and if they were applied
+func (m *Migrator) Status(ctx context.Context) (statuses []MigrationStatus, err error) {
+	statuses, err = m.status(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabaseMigrate, err)
+	}
+	return statuses, nil
+}
+
+// Pending returns migrations not applied yet
+func (m *Migrator) Pending(ctx context.Context) (pending []*Migration, err error) {
+	pending, err = m.pending(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabaseMigrate, err)
+	}
+	return pending, nil
+}
+
+func (m *Migrator) pending(db *gorm.DB) (pending []*Migration, err error) {
+	statuses, err := m.status(db)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// status compares known migrations with the schema_migrations table
+// It fails if an applied migration was modified after being applied
+func (m *Migrator) status(db *gorm.DB) (statuses []MigrationStatus, err error) {
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+	var rows []schemaMigration
+	if err := db.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[uint]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if row, ok := applied[migration.Version]; ok {
+			if row.Checksum != migration.Checksum {
+				return nil, fmt.Errorf("checksum mismatch for applied migration %d_%s", migration.Version, migration.Name)
+			}
+			status.Applied = true
+			status.AppliedAt = row.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// transaction runs a migration in a transaction
+// SQLite can't alter a table, it is rebuilt and dropping the old table deletes or breaks the rows
+// referencing it, so the foreign keys are disabled meanwhile and checked before the commit
+func (m *Migrator) transaction(conn *gorm.DB, fn func(tx *gorm.DB) error) (err error) {
+	if conn.Dialector.Name() != "sqlite" {
+		return conn.Transaction(fn)
+	}
+
+	// The pragma is a no-op inside a transaction
+	var foreignKeys int
+	if err := conn.Raw("PRAGMA foreign_keys").Scan(&foreignKeys).Error; err != nil {
 		return err
 	}
-	return nil
+	if foreignKeys == 1 {
+		if err := conn.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+			return err
+		}
+		defer func() {
+			if errEnable := conn.Exec("PRAGMA foreign_keys = ON").Error; err == nil {
+				err = errEnable
+			}
+		}()
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		var violations []map[string]interface{}
+		if err := tx.Raw("PRAGMA foreign_key_check").Scan(&violations).Error; err != nil {
+			return err
+		}
+		if len(violations) != 0 {
+			return fmt.Errorf("%d rows violate a foreign key, e.g. in table %v", len(violations), violations[0]["table"])
+		}
+		return nil
+	})
 }
 
-func all() []interface{} {
-	out := []interface{}{}
-	for _, v := range allMap() {
-		out = append(out, v)
+// withLock runs fn on a single connection holding the migration lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) (err error) {
+		switch conn.Dialector.Name() {
+		case "postgres":
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+				return err
+			}
+			defer func() {
+				if errUnlock := conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey).Error; err == nil {
+					err = errUnlock
+				}
+			}()
+		case "mysql":
+			var locked int
+			lockName := strconv.Itoa(migrationLockKey)
+			if err := conn.Raw("SELECT GET_LOCK(?, 300)", lockName).Scan(&locked).Error; err != nil {
+				return err
+			}
+			if locked != 1 {
+				return errors.New("timeout acquiring migration lock")
+			}
+			defer func() {
+				if errUnlock := conn.Exec("SELECT RELEASE_LOCK(?)", lockName).Error; err == nil {
+					err = errUnlock
+				}
+			}()
+		}
+		return fn(conn.Session(&gorm.Session{NewDB: true}))
+	})
+}
+
+// loadSQLMigrations reads migrations named `<version>_<name>[.<dialect>].<up|down>.sql`
+// A file suffixed by a dialect overrides the generic one for this dialect,
+// a version which has only files for other dialects is a no-op for this dialect
+func loadSQLMigrations(fsys fs.FS, dir string, dialect string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type sqlFiles struct {
+		name                         string
+		up, down                     string
+		hasDialectUp, hasDialectDown bool
+	}
+	byVersion := map[uint]*sqlFiles{}
+	for _, entry := range entries {
+		fileName := entry.Name()
+		parts := strings.Split(strings.TrimSuffix(fileName, ".sql"), ".")
+		if entry.IsDir() || !strings.HasSuffix(fileName, ".sql") || len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid migration file name %s", fileName)
+		}
+		versionStr, name, found := strings.Cut(parts[0], "_")
+		version, err := strconv.ParseUint(versionStr, 10, 32)
+		if !found || err != nil {
+			return nil, fmt.Errorf("invalid migration file name %s", fileName)
+		}
+		direction := parts[len(parts)-1]
+		if direction != "up" && direction != "down" {
+			return nil, fmt.Errorf("invalid migration direction in %s", fileName)
+		}
+
+		files, ok := byVersion[uint(version)]
+		if !ok {
+			files = &sqlFiles{name: name}
+			byVersion[uint(version)] = files
+		}
+		if files.name != name {
+			return nil, fmt.Errorf("migration version %d used by %s and %s", version, files.name, name)
+		}
+
+		isDialectFile := len(parts) == 3
+		if isDialectFile && parts[1] != dialect {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, fileName))
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case direction == "up" && (isDialectFile || !files.hasDialectUp):
+			files.up = string(content)
+			files.hasDialectUp = isDialectFile
+		case direction == "down" && (isDialectFile || !files.hasDialectDown):
+			files.down = string(content)
+			files.hasDialectDown = isDialectFile
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for version, files := range byVersion {
+		checksum := sha256.Sum256([]byte(files.up + "\x00" + files.down))
+		migrations = append(migrations, &Migration{
+			Version:  version,
+			Name:     files.name,
+			Checksum: hex.EncodeToString(checksum[:]),
+			Up:       execSQL(files.up),
+			Down:     execSQL(files.down),
+		})
+	}
+	return migrations, nil
+}
+
+func execSQL(query string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		if strings.TrimSpace(query) == "" {
+			return nil
+		}
+		return tx.Exec(query).Error
 	}
-	return out
 }
 
-func allMap() map[string]interface{} {
-	return map[string]interface{}{
-		"User":      models.User{},
-		"Artist":    models.Artist{},
-		"Album":     models.Album{},
-		"UserAlbum": models.UserAlbum{},
+// checksumGoMigrations sets the checksum of the Go migrations from their revision, a revision is required
+func checksumGoMigrations(migrations []*Migration) error {
+	for _, migration := range migrations {
+		if migration.Revision == 0 {
+			return fmt.Errorf("migration %d_%s written in Go has no revision", migration.Version, migration.Name)
+		}
+		migration.Checksum = goChecksum(migration)
+	}
+	return nil
+}
+
+// goChecksum is the checksum of a Go migration, it changes with its name and its revision
+// The first revision keeps the checksum of the migrations applied before the revisions
+func goChecksum(migration *Migration) string {
+	content := fmt.Sprintf("go:%d_%s", migration.Version, migration.Name)
+	if migration.Revision > 1 {
+		content += fmt.Sprintf("@%d", migration.Revision)
 	}
+	checksum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(checksum[:])
 }
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing/fstest"
+
+	"github.com/sarrooo/go-clean/internal/errcode"
+)
+
+func (suite *DatabaseSuiteTest) TestMigrator() {
+	tests := map[string]struct {
+		run      func(migrator *Migrator) error
+		expected error
+	}{
+		"Up applies all migrations once": {
+			run: func(migrator *Migrator) error {
+				applied, err := migrator.Up(context.Background())
+				suite.Require().NoError(err)
+				suite.Assert().Len(applied, len(migrator.migrations))
+				suite.Assert().True(suite.db.Migrator().HasTable("artists"))
+
+				applied, err = migrator.Up(context.Background())
+				suite.Assert().Empty(applied, "No migration should be applied twice")
+				return err
+			},
+		},
+		"Down reverts the last migrations": {
+			run: func(migrator *Migrator) error {
+				_, err := migrator.Up(context.Background())
+				suite.Require().NoError(err)
+
+				reverted, err := migrator.Down(context.Background(), len(migrator.migrations))
+				suite.Require().NoError(err)
+				suite.Assert().Len(reverted, len(migrator.migrations))
+				suite.Assert().False(suite.db.Migrator().HasTable("artists"))
+
+				pending, err := migrator.Pending(context.Background())
+				suite.Assert().Len(pending, len(migrator.migrations))
+				return err
+			},
+		},
+		"Modified applied migration": {
+			run: func(migrator *Migrator) error {
+				_, err := migrator.Up(context.Background())
+				suite.Require().NoError(err)
+
+				migrator.migrations[0].Checksum = "modified"
+				_, err = migrator.Status(context.Background())
+				return err
+			},
+			expected: errcode.ErrDatabaseMigrate,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			migrator, err := NewMigrator(suite.db)
+			suite.Require().NoError(err)
+
+			err = test.run(migrator)
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+			} else {
+				suite.Assert().NoError(err, "No error should have occurred")
+			}
+		})
+	}
+}
+
+func (suite *DatabaseSuiteTest) TestLoadSQLMigrations() {
+	files := fstest.MapFS{
+		"migrations/0003_index.up.sql":           {Data: []byte("CREATE INDEX generic")},
+		"migrations/0003_index.down.sql":         {Data: []byte("DROP INDEX generic")},
+		"migrations/0003_index.mysql.down.sql":   {Data: []byte("DROP INDEX mysql")},
+		"migrations/0004_search.postgres.up.sql": {Data: []byte("CREATE EXTENSION")},
+	}
+
+	tests := map[string]struct {
+		dialect  string
+		expected map[uint]string
+	}{
+		"Generic files": {
+			dialect:  "sqlite",
+			expected: map[uint]string{3: "CREATE INDEX generic\x00DROP INDEX generic", 4: "\x00"},
+		},
+		"Dialect file overrides generic file": {
+			dialect:  "mysql",
+			expected: map[uint]string{3: "CREATE INDEX generic\x00DROP INDEX mysql", 4: "\x00"},
+		},
+		"Dialect only migration": {
+			dialect:  "postgres",
+			expected: map[uint]string{3: "CREATE INDEX generic\x00DROP INDEX generic", 4: "CREATE EXTENSION\x00"},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			migrations, err := loadSQLMigrations(files, "migrations", test.dialect)
+			suite.Require().NoError(err)
+			suite.Assert().Len(migrations, len(test.expected))
+			for _, migration := range migrations {
+				checksum := sha256.Sum256([]byte(test.expected[migration.Version]))
+				suite.Assert().Equal(hex.EncodeToString(checksum[:]), migration.Checksum, "Migration %d should use the right files", migration.Version)
+			}
+		})
+	}
+}
+
+func (suite *DatabaseSuiteTest) TestChecksumGoMigrations() {
+	legacy := sha256.Sum256([]byte("go:1_create_tables"))
+	revised := sha256.Sum256([]byte("go:1_create_tables@2"))
+
+	tests := map[string]struct {
+		migrations       []*Migration
+		expectedChecksum string
+		expectedError    bool
+	}{
+		"First revision keeps the checksum of the applied migrations": {
+			migrations:       []*Migration{{Version: 1, Name: "create_tables", Revision: 1}},
+			expectedChecksum: hex.EncodeToString(legacy[:]),
+		},
+		"Bumped revision changes the checksum": {
+			migrations:       []*Migration{{Version: 1, Name: "create_tables", Revision: 2}},
+			expectedChecksum: hex.EncodeToString(revised[:]),
+		},
+		"Missing revision": {
+			migrations:    []*Migration{{Version: 1, Name: "create_tables"}},
+			expectedError: true,
+		},
+		"Go migrations of the repository": {
+			migrations: goMigrations(),
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			err := checksumGoMigrations(test.migrations)
+
+			if test.expectedError {
+				suite.Assert().Error(err)
+				return
+			}
+			suite.Require().NoError(err, "Each Go migration should have a revision")
+			if test.expectedChecksum != "" {
+				suite.Assert().Equal(test.expectedChecksum, test.migrations[0].Checksum)
+			}
+		})
+	}
+}
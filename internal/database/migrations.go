@@ -0,0 +1,294 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// goMigrations returns the migrations written in Go
+// Use a Go migration when the change can't be written in portable SQL
+// Never use the models package here: a migration must keep creating the same schema
+// when models change, declare a snapshot of the tables instead
+// Bump the Revision of a migration when its code changes, the applied migration is reported as modified
+func goMigrations() []*Migration {
+	return []*Migration{
+		{
+			Version:  1,
+			Name:     "create_initial_tables",
+			Revision: 1,
+			Up:       createInitialTablesUp,
+			Down:     createInitialTablesDown,
+		},
+		{
+			Version:  6,
+			Name:     "create_audit_logs",
+			Revision: 1,
+			Up:       createAuditLogsUp,
+			Down:     createAuditLogsDown,
+		},
+		{
+			Version:  8,
+			Name:     "create_idempotency_keys",
+			Revision: 1,
+			Up:       createIdempotencyKeysUp,
+			Down:     createIdempotencyKeysDown,
+		},
+		{
+			Version:  9,
+			Name:     "create_search_indexes",
+			Revision: 1,
+			Up:       createSearchIndexesUp,
+			Down:     createSearchIndexesDown,
+		},
+		{
+			Version:  10,
+			Name:     "create_import_jobs",
+			Revision: 1,
+			Up:       createImportJobsUp,
+			Down:     createImportJobsDown,
+		},
+		// Add new Go migration here
+	}
+}
+
+/* 0001 create_initial_tables */
+
+type user0001 struct {
+	ID         uint `gorm:"primaryKey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt
+	FirstName  string
+	LastName   string
+	BirthDate  time.Time
+	Phone      string
+	Email      string `gorm:"unique"`
+	Password   string
+	UserAlbums []*userAlbum0001 `gorm:"foreignKey:UserID"`
+}
+
+func (user0001) TableName() string { return "users" }
+
+type artist0001 struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+	Name      string
+	Albums    []*album0001 `gorm:"foreignKey:ArtistID"`
+}
+
+func (artist0001) TableName() string { return "artists" }
+
+type album0001 struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+	Name      string      `gorm:"uniqueIndex:album_idx"`
+	ArtistID  uint        `gorm:"uniqueIndex:album_idx"`
+	Artist    *artist0001 `gorm:"foreignKey:ArtistID"`
+}
+
+func (album0001) TableName() string { return "albums" }
+
+type userAlbum0001 struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+	UserID    uint
+	User      *user0001 `gorm:"foreignKey:UserID"`
+	AlbumID   uint
+	Album     *album0001 `gorm:"foreignKey:AlbumID"`
+}
+
+func (userAlbum0001) TableName() string { return "user_albums" }
+
+// Tables are created only if missing, databases created before versioned migrations
+// already have them and are baselined by this migration
+func createInitialTablesUp(tx *gorm.DB) error {
+	for _, table := range []interface{}{&user0001{}, &artist0001{}, &album0001{}, &userAlbum0001{}} {
+		if tx.Migrator().HasTable(table) {
+			continue
+		}
+		if err := tx.Migrator().CreateTable(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createInitialTablesDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&userAlbum0001{}, &album0001{}, &artist0001{}, &user0001{})
+}
+
+/* 0006 create_audit_logs */
+
+type auditLog0006 struct {
+	ID         uint      `gorm:"primaryKey"`
+	CreatedAt  time.Time `gorm:"index"`
+	ActorType  string    `gorm:"index:idx_audit_logs_actor"`
+	ActorID    string    `gorm:"index:idx_audit_logs_actor"`
+	Action     string
+	EntityType string `gorm:"index:idx_audit_logs_entity"`
+	EntityID   uint   `gorm:"index:idx_audit_logs_entity"`
+	Changes    string `gorm:"type:text"`
+	RequestID  string
+	IP         string
+}
+
+func (auditLog0006) TableName() string { return "audit_logs" }
+
+func createAuditLogsUp(tx *gorm.DB) error {
+	return tx.Migrator().CreateTable(&auditLog0006{})
+}
+
+func createAuditLogsDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&auditLog0006{})
+}
+
+/* 0008 create_idempotency_keys */
+
+type idempotencyKey0008 struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	Scope       string `gorm:"size:255;uniqueIndex:idx_idempotency_keys_key"`
+	Key         string `gorm:"column:idempotency_key;size:255;uniqueIndex:idx_idempotency_keys_key"`
+	Fingerprint string
+	StatusCode  int
+	Response    string    `gorm:"type:text"`
+	ExpiresAt   time.Time `gorm:"index"`
+}
+
+func (idempotencyKey0008) TableName() string { return "idempotency_keys" }
+
+func createIdempotencyKeysUp(tx *gorm.DB) error {
+	return tx.Migrator().CreateTable(&idempotencyKey0008{})
+}
+
+func createIdempotencyKeysDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&idempotencyKey0008{})
+}
+
+/* 0009 create_search_indexes */
+
+// Tables of the searched names
+var searchTables = []string{"artists", "albums"}
+
+// search_fold is immutable so it can be indexed, unaccent is not because its dictionary could change
+var postgresSearchUp = []string{
+	"CREATE EXTENSION IF NOT EXISTS unaccent",
+	"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+	`CREATE OR REPLACE FUNCTION search_fold(value text) RETURNS text
+		LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+		AS $$ SELECT lower(public.unaccent('public.unaccent'::regdictionary, value)) $$`,
+}
+
+// createSearchIndexesUp indexes the names for the search
+// - postgres: full-text and trigram indexes of the names without accents
+// - sqlite: FTS5 tables kept up to date by triggers, only if SQLite was built with FTS5 (sqlite_fts5 build tag)
+// - mysql: nothing, the search uses LIKE and the accent insensitive collation
+func createSearchIndexesUp(tx *gorm.DB) error {
+	switch tx.Dialector.Name() {
+	case "postgres":
+		statements := postgresSearchUp
+		for _, table := range searchTables {
+			statements = append(statements,
+				fmt.Sprintf("CREATE INDEX idx_%s_search ON %s USING gin (to_tsvector('simple', search_fold(name)))", table, table),
+				fmt.Sprintf("CREATE INDEX idx_%s_search_trgm ON %s USING gin (search_fold(name) gin_trgm_ops)", table, table),
+			)
+		}
+		return execAll(tx, statements)
+	case "sqlite":
+		var fts5 bool
+		if err := tx.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts5).Error; err != nil {
+			return err
+		}
+		if !fts5 {
+			return nil
+		}
+		var statements []string
+		for _, table := range searchTables {
+			statements = append(statements, sqliteSearchTable(table)...)
+		}
+		return execAll(tx, statements)
+	default:
+		return nil
+	}
+}
+
+// sqliteSearchTable returns the statements creating the FTS5 table of the table
+// Only the rows which are not soft deleted are indexed, an external content table
+// must be given the indexed values to remove a row
+func sqliteSearchTable(table string) []string {
+	remove := fmt.Sprintf("INSERT INTO %[1]s_search (%[1]s_search, rowid, name) SELECT 'delete', old.id, old.name WHERE old.deleted_at IS NULL;", table)
+	add := fmt.Sprintf("INSERT INTO %[1]s_search (rowid, name) SELECT new.id, new.name WHERE new.deleted_at IS NULL;", table)
+	return []string{
+		fmt.Sprintf("CREATE VIRTUAL TABLE %[1]s_search USING fts5(name, content='%[1]s', content_rowid='id', tokenize='unicode61 remove_diacritics 2', prefix='2 3')", table),
+		fmt.Sprintf("INSERT INTO %[1]s_search (rowid, name) SELECT id, name FROM %[1]s WHERE deleted_at IS NULL", table),
+		fmt.Sprintf("CREATE TRIGGER %[1]s_search_insert AFTER INSERT ON %[1]s BEGIN %[2]s END", table, add),
+		fmt.Sprintf("CREATE TRIGGER %[1]s_search_update AFTER UPDATE ON %[1]s BEGIN %[2]s %[3]s END", table, remove, add),
+		fmt.Sprintf("CREATE TRIGGER %[1]s_search_delete AFTER DELETE ON %[1]s BEGIN %[2]s END", table, remove),
+	}
+}
+
+// The extensions are kept, other objects could use them
+func createSearchIndexesDown(tx *gorm.DB) error {
+	var statements []string
+	switch tx.Dialector.Name() {
+	case "postgres":
+		for _, table := range searchTables {
+			statements = append(statements,
+				fmt.Sprintf("DROP INDEX IF EXISTS idx_%s_search", table),
+				fmt.Sprintf("DROP INDEX IF EXISTS idx_%s_search_trgm", table),
+			)
+		}
+		statements = append(statements, "DROP FUNCTION IF EXISTS search_fold(text)")
+	case "sqlite":
+		for _, table := range searchTables {
+			statements = append(statements,
+				fmt.Sprintf("DROP TRIGGER IF EXISTS %s_search_insert", table),
+				fmt.Sprintf("DROP TRIGGER IF EXISTS %s_search_update", table),
+				fmt.Sprintf("DROP TRIGGER IF EXISTS %s_search_delete", table),
+				fmt.Sprintf("DROP TABLE IF EXISTS %s_search", table),
+			)
+		}
+	}
+	return execAll(tx, statements)
+}
+
+func execAll(tx *gorm.DB, statements []string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/* 0010 create_import_jobs */
+
+type importJob0010 struct {
+	ID         uint `gorm:"primaryKey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	EntityType string
+	DryRun     bool
+	Status     string
+	Report     string `gorm:"type:text"`
+	Error      string
+	FinishedAt *time.Time
+}
+
+func (importJob0010) TableName() string { return "import_jobs" }
+
+func createImportJobsUp(tx *gorm.DB) error {
+	return tx.Migrator().CreateTable(&importJob0010{})
+}
+
+func createImportJobsDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&importJob0010{})
+}
@@ -0,0 +1,4 @@
+DROP INDEX idx_users_deleted_at;
+DROP INDEX idx_artists_deleted_at;
+DROP INDEX idx_albums_deleted_at;
+DROP INDEX idx_user_albums_deleted_at;
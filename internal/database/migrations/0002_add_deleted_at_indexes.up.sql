@@ -0,0 +1,5 @@
+-- Soft deleted rows are filtered out of every query by GORM
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
+CREATE INDEX idx_artists_deleted_at ON artists (deleted_at);
+CREATE INDEX idx_albums_deleted_at ON albums (deleted_at);
+CREATE INDEX idx_user_albums_deleted_at ON user_albums (deleted_at);
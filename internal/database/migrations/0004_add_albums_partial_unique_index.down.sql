@@ -0,0 +1,2 @@
+DROP INDEX album_idx;
+CREATE UNIQUE INDEX album_idx ON albums (name, artist_id);
@@ -0,0 +1,4 @@
+ALTER TABLE albums
+    DROP INDEX album_idx,
+    DROP COLUMN active,
+    ADD UNIQUE INDEX album_idx (name, artist_id);
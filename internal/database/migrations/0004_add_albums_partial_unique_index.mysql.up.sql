@@ -0,0 +1,6 @@
+-- MySQL has no partial index: the generated column is NULL for soft deleted rows,
+-- and NULL values never collide in a unique index
+ALTER TABLE albums
+    DROP INDEX album_idx,
+    ADD COLUMN active TINYINT AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL,
+    ADD UNIQUE INDEX album_idx (name, artist_id, active);
@@ -0,0 +1,3 @@
+-- Soft deleted albums must not collide with active ones
+DROP INDEX album_idx;
+CREATE UNIQUE INDEX album_idx ON albums (name, artist_id) WHERE deleted_at IS NULL;
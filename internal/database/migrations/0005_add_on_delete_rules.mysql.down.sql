@@ -0,0 +1,7 @@
+ALTER TABLE albums DROP FOREIGN KEY fk_albums_artist;
+ALTER TABLE albums
+    ADD CONSTRAINT fk_artists_albums FOREIGN KEY (artist_id) REFERENCES artists (id);
+ALTER TABLE user_albums DROP FOREIGN KEY fk_user_albums_album, DROP FOREIGN KEY fk_user_albums_user;
+ALTER TABLE user_albums
+    ADD CONSTRAINT fk_user_albums_album FOREIGN KEY (album_id) REFERENCES albums (id),
+    ADD CONSTRAINT fk_users_user_albums FOREIGN KEY (user_id) REFERENCES users (id);
@@ -0,0 +1,9 @@
+-- An artist can't be deleted while albums reference it,
+-- library entries are deleted with their album or their user
+ALTER TABLE albums DROP FOREIGN KEY fk_artists_albums;
+ALTER TABLE albums
+    ADD CONSTRAINT fk_albums_artist FOREIGN KEY (artist_id) REFERENCES artists (id) ON UPDATE CASCADE ON DELETE RESTRICT;
+ALTER TABLE user_albums DROP FOREIGN KEY fk_user_albums_album, DROP FOREIGN KEY fk_users_user_albums;
+ALTER TABLE user_albums
+    ADD CONSTRAINT fk_user_albums_album FOREIGN KEY (album_id) REFERENCES albums (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    ADD CONSTRAINT fk_user_albums_user FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE;
@@ -0,0 +1,8 @@
+ALTER TABLE albums
+    DROP CONSTRAINT fk_albums_artist,
+    ADD CONSTRAINT fk_artists_albums FOREIGN KEY (artist_id) REFERENCES artists (id);
+ALTER TABLE user_albums
+    DROP CONSTRAINT fk_user_albums_album,
+    DROP CONSTRAINT fk_user_albums_user,
+    ADD CONSTRAINT fk_user_albums_album FOREIGN KEY (album_id) REFERENCES albums (id),
+    ADD CONSTRAINT fk_users_user_albums FOREIGN KEY (user_id) REFERENCES users (id);
@@ -0,0 +1,31 @@
+CREATE TABLE user_albums__old (
+    id integer,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    user_id integer,
+    album_id integer,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_user_albums_album FOREIGN KEY (album_id) REFERENCES albums (id),
+    CONSTRAINT fk_users_user_albums FOREIGN KEY (user_id) REFERENCES users (id)
+);
+INSERT INTO user_albums__old SELECT id, created_at, updated_at, deleted_at, user_id, album_id FROM user_albums;
+DROP TABLE user_albums;
+ALTER TABLE user_albums__old RENAME TO user_albums;
+CREATE INDEX idx_user_albums_deleted_at ON user_albums (deleted_at);
+
+CREATE TABLE albums__old (
+    id integer,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    name text,
+    artist_id integer,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_artists_albums FOREIGN KEY (artist_id) REFERENCES artists (id)
+);
+INSERT INTO albums__old SELECT id, created_at, updated_at, deleted_at, name, artist_id FROM albums;
+DROP TABLE albums;
+ALTER TABLE albums__old RENAME TO albums;
+CREATE INDEX idx_albums_deleted_at ON albums (deleted_at);
+CREATE UNIQUE INDEX album_idx ON albums (name, artist_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,34 @@
+-- An artist can't be deleted while albums reference it,
+-- library entries are deleted with their album or their user
+-- SQLite can't alter constraints, tables are rebuilt while the migrator disables the foreign keys
+CREATE TABLE user_albums__new (
+    id integer,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    user_id integer,
+    album_id integer,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_user_albums_album FOREIGN KEY (album_id) REFERENCES albums (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_user_albums_user FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+INSERT INTO user_albums__new SELECT id, created_at, updated_at, deleted_at, user_id, album_id FROM user_albums;
+DROP TABLE user_albums;
+ALTER TABLE user_albums__new RENAME TO user_albums;
+CREATE INDEX idx_user_albums_deleted_at ON user_albums (deleted_at);
+
+CREATE TABLE albums__new (
+    id integer,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    name text,
+    artist_id integer,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_albums_artist FOREIGN KEY (artist_id) REFERENCES artists (id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+INSERT INTO albums__new SELECT id, created_at, updated_at, deleted_at, name, artist_id FROM albums;
+DROP TABLE albums;
+ALTER TABLE albums__new RENAME TO albums;
+CREATE INDEX idx_albums_deleted_at ON albums (deleted_at);
+CREATE UNIQUE INDEX album_idx ON albums (name, artist_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE user_albums DROP COLUMN version;
+ALTER TABLE albums DROP COLUMN version;
+ALTER TABLE artists DROP COLUMN version;
+ALTER TABLE users DROP COLUMN version;
@@ -0,0 +1,5 @@
+-- Version of the rows, incremented by each update for optimistic concurrency control
+ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE artists ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE albums ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE user_albums ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+-- The running jobs refresh it, a job without heartbeat for long is interrupted
+ALTER TABLE import_jobs ADD COLUMN heartbeat_at TIMESTAMPTZ;
@@ -0,0 +1,4 @@
+ALTER TABLE artists
+    DROP INDEX artist_name_idx,
+    DROP COLUMN active,
+    MODIFY name LONGTEXT;
@@ -0,0 +1,6 @@
+-- MySQL has no partial index: the generated column is NULL for soft deleted rows,
+-- and NULL values never collide in a unique index. A LONGTEXT column can't be indexed
+ALTER TABLE artists
+    MODIFY name VARCHAR(191),
+    ADD COLUMN active TINYINT AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL,
+    ADD UNIQUE INDEX artist_name_idx (name, active);
@@ -0,0 +1,2 @@
+-- Two active artists can't have the same name, the imports upsert the artists by name
+CREATE UNIQUE INDEX artist_name_idx ON artists (name) WHERE deleted_at IS NULL;
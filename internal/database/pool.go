@@ -0,0 +1,98 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Maximum delay between two connection attempts on startup
+const maxConnectBackoff = 10 * time.Second
+
+// intSetting returns the integer setting, or defaultValue if not defined
+func intSetting(key string, defaultValue int) int {
+	if !viper.IsSet(key) {
+		return defaultValue
+	}
+	return viper.GetInt(key)
+}
+
+// durationSetting returns the duration setting (e.g. `30s`, `5m`), or defaultValue if not defined
+func durationSetting(key string, defaultValue time.Duration) time.Duration {
+	if !viper.IsSet(key) {
+		return defaultValue
+	}
+	return viper.GetDuration(key)
+}
+
+// connect opens the database, retrying with an exponential backoff while the database is not reachable
+// It lets the server start before the database, e.g. with docker-compose
+// The automatic ping of GORM is disabled, so plugins opening other connections (replicas) don't ping them
+func connect(logger *zap.Logger, dial gorm.Dialector, config *gorm.Config) (db *gorm.DB, err error) {
+	attempts := intSetting("DATABASE_CONNECT_ATTEMPTS", 10)
+	backoff := durationSetting("DATABASE_CONNECT_BACKOFF", 500*time.Millisecond)
+	config.DisableAutomaticPing = true
+
+	for attempt := 1; ; attempt++ {
+		db, err = gorm.Open(dial, config)
+		if err == nil {
+			var sqlDB *sql.DB
+			sqlDB, err = db.DB()
+			if err == nil {
+				err = sqlDB.Ping()
+				if err == nil {
+					return db, nil
+				}
+				// The pool of a failed attempt is not used, the next attempt opens another one
+				sqlDB.Close()
+			}
+		}
+		if attempt >= attempts {
+			return nil, fmt.Errorf("%w: %d connection attempts: %v", errcode.ErrConfigurationFailed, attempt, err)
+		}
+
+		logger.Warn("database not reachable, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		time.Sleep(backoff)
+		backoff = min(2*backoff, maxConnectBackoff)
+	}
+}
+
+// pool is the connection pool configuration, applied to the primary and to each replica
+type pool struct {
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime time.Duration
+	connMaxIdleTime time.Duration
+}
+
+// poolSettings returns the DATABASE_* connection pool settings
+func poolSettings() pool {
+	return pool{
+		maxOpenConns:    intSetting("DATABASE_MAX_OPEN_CONNS", 25),
+		maxIdleConns:    intSetting("DATABASE_MAX_IDLE_CONNS", 10),
+		connMaxLifetime: durationSetting("DATABASE_CONN_MAX_LIFETIME", 30*time.Minute),
+		connMaxIdleTime: durationSetting("DATABASE_CONN_MAX_IDLE_TIME", 5*time.Minute),
+	}
+}
+
+// configurePool applies the connection pool settings to the primary
+func configurePool(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrConfigurationFailed, err)
+	}
+	pool := poolSettings()
+	sqlDB.SetMaxOpenConns(pool.maxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.maxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.connMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.connMaxIdleTime)
+	return nil
+}
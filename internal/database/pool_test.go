@@ -0,0 +1,45 @@
+package database
+
+import (
+	"database/sql"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// lazyDialector opens a SQLite pool without connecting, like a server dialector, and records the opened pools
+type lazyDialector struct {
+	*sqlite.Dialector
+	pools *[]*sql.DB
+}
+
+func (d lazyDialector) Initialize(db *gorm.DB) error {
+	pool, err := sql.Open(sqlite.DriverName, d.DSN)
+	if err != nil {
+		return err
+	}
+	db.ConnPool = pool
+	*d.pools = append(*d.pools, pool)
+	return nil
+}
+
+func (suite *DatabaseSuiteTest) TestConnect() {
+	suite.Run("Pools of the failed attempts are closed", func() {
+		viper.Set("DATABASE_CONNECT_ATTEMPTS", 2)
+		viper.Set("DATABASE_CONNECT_BACKOFF", "1ms")
+		defer viper.Reset()
+		var pools []*sql.DB
+		unreachable := &sqlite.Dialector{DSN: "file:" + filepath.Join(suite.T().TempDir(), "missing", "go-clean.db")}
+
+		_, err := connect(zap.NewNop(), lazyDialector{Dialector: unreachable, pools: &pools}, &gorm.Config{})
+
+		suite.Require().Error(err)
+		suite.Require().Len(pools, 2)
+		for _, pool := range pools {
+			suite.Assert().EqualError(pool.Ping(), "sql: database is closed")
+		}
+	})
+}
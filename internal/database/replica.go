@@ -0,0 +1,233 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Name of the ReplicaSet plugin in gorm.Config.Plugins
+const replicaSetPluginName = "go-clean:replicas"
+
+// ReplicaSet is a GORM plugin routing reads to replicas with the dbresolver plugin
+// - writes and transactions always use the primary
+// - with WithReadYourWrites, reads following a write in the same context use the primary
+// - reads use the primary while no replica is healthy
+type ReplicaSet struct {
+	logger   *zap.Logger
+	replicas []*sql.DB
+	healthy  []atomic.Bool
+}
+
+// writeTracker records if a write happened in a context
+type writeTracker struct {
+	wrote atomic.Bool
+}
+
+type writeTrackerKey struct{}
+
+// WithReadYourWrites returns a context in which reads following a write are sent to the primary,
+// so a request always reads its own writes even if replicas lag behind
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, writeTrackerKey{}, &writeTracker{})
+}
+
+// replicaDSNs returns the DSNs of DATABASE_REPLICAS, separated by commas
+func replicaDSNs() []string {
+	var dsns []string
+	for _, dsn := range strings.Split(setting("DATABASE_REPLICAS", "", ""), ",") {
+		if dsn = strings.TrimSpace(dsn); dsn != "" {
+			dsns = append(dsns, dsn)
+		}
+	}
+	return dsns
+}
+
+// openReplicas opens the DATABASE_REPLICAS connections of the driver
+// Replicas are not pinged, a replica down on startup is only marked unhealthy
+func openReplicas(driver string, dsns []string) ([]*sql.DB, error) {
+	replicas := make([]*sql.DB, 0, len(dsns))
+	for i, dsn := range dsns {
+		var dial gorm.Dialector
+		switch driver {
+		case DriverMySQL:
+			dial = mysql.New(mysql.Config{DSN: dsn, SkipInitializeWithVersion: true})
+		case DriverSQLite:
+			dial = sqlite.Open(dsn)
+		default:
+			dial = postgres.Open(dsn)
+		}
+		replicaDB, err := gorm.Open(dial, &gorm.Config{DisableAutomaticPing: true})
+		if err != nil {
+			return nil, fmt.Errorf("%w: replica %d: %v", errcode.ErrConfigurationFailed, i, err)
+		}
+		replica, err := replicaDB.DB()
+		if err != nil {
+			return nil, fmt.Errorf("%w: replica %d: %v", errcode.ErrConfigurationFailed, i, err)
+		}
+		replicas = append(replicas, replica)
+	}
+	return replicas, nil
+}
+
+// NewReplicaSet creates the plugin for the given replica connections, register it with db.Use
+func NewReplicaSet(logger *zap.Logger, replicas []*sql.DB) *ReplicaSet {
+	rs := &ReplicaSet{
+		logger:   logger,
+		replicas: replicas,
+		healthy:  make([]atomic.Bool, len(replicas)),
+	}
+	// Replicas are healthy until a check fails, so a replica down on startup is logged
+	for i := range rs.healthy {
+		rs.healthy[i].Store(true)
+	}
+	return rs
+}
+
+// Name implements gorm.Plugin
+func (rs *ReplicaSet) Name() string {
+	return replicaSetPluginName
+}
+
+// Initialize implements gorm.Plugin
+// It registers the dbresolver plugin with the replicas, and the routing callbacks running before it
+func (rs *ReplicaSet) Initialize(db *gorm.DB) error {
+	dialectors := make([]gorm.Dialector, 0, len(rs.replicas))
+	for _, replica := range rs.replicas {
+		switch db.Dialector.Name() {
+		case DriverMySQL:
+			dialectors = append(dialectors, mysql.New(mysql.Config{Conn: replica, SkipInitializeWithVersion: true}))
+		case DriverSQLite:
+			dialectors = append(dialectors, &sqlite.Dialector{Conn: replica})
+		default:
+			dialectors = append(dialectors, postgres.New(postgres.Config{Conn: replica}))
+		}
+	}
+
+	pool := poolSettings()
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   rs,
+	}).
+		SetMaxOpenConns(pool.maxOpenConns).
+		SetMaxIdleConns(pool.maxIdleConns).
+		SetConnMaxLifetime(pool.connMaxLifetime).
+		SetConnMaxIdleTime(pool.connMaxIdleTime)
+	if err := db.Use(resolver); err != nil {
+		return err
+	}
+
+	// Registered after dbresolver, GORM runs the last callback registered before "*" first
+	callback := db.Callback()
+	for _, err := range []error{
+		callback.Query().Before("*").Register("go-clean:route_read", rs.routeRead),
+		callback.Row().Before("*").Register("go-clean:route_read", rs.routeRead),
+		callback.Raw().Before("*").Register("go-clean:route_raw", rs.routeRaw),
+		callback.Create().Before("*").Register("go-clean:track_write", trackWrite),
+		callback.Update().Before("*").Register("go-clean:track_write", trackWrite),
+		callback.Delete().Before("*").Register("go-clean:track_write", trackWrite),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	rs.Check(context.Background())
+	return nil
+}
+
+// Resolve implements dbresolver.Policy, it picks a random healthy replica
+// It is only called when there are several replicas
+func (rs *ReplicaSet) Resolve(connPools []gorm.ConnPool) gorm.ConnPool {
+	healthy := make([]gorm.ConnPool, 0, len(connPools))
+	for _, connPool := range connPools {
+		for i, replica := range rs.replicas {
+			if connPool == gorm.ConnPool(replica) && rs.healthy[i].Load() {
+				healthy = append(healthy, connPool)
+			}
+		}
+	}
+	if len(healthy) == 0 {
+		healthy = connPools
+	}
+	return healthy[rand.Intn(len(healthy))]
+}
+
+// routeRead sends the read to the primary after a write in the context, or if no replica is healthy
+func (rs *ReplicaSet) routeRead(db *gorm.DB) {
+	if tracker, ok := db.Statement.Context.Value(writeTrackerKey{}).(*writeTracker); ok && tracker.wrote.Load() {
+		dbresolver.Write.ModifyStatement(db.Statement)
+		return
+	}
+	if !rs.anyHealthy() {
+		dbresolver.Write.ModifyStatement(db.Statement)
+	}
+}
+
+// routeRaw handles raw SQL, only SELECT statements are reads
+func (rs *ReplicaSet) routeRaw(db *gorm.DB) {
+	query := strings.TrimSpace(db.Statement.SQL.String())
+	if len(query) >= 6 && strings.EqualFold(query[:6], "select") {
+		rs.routeRead(db)
+		return
+	}
+	trackWrite(db)
+}
+
+// trackWrite records the write in the context, if it is tracked
+func trackWrite(db *gorm.DB) {
+	if tracker, ok := db.Statement.Context.Value(writeTrackerKey{}).(*writeTracker); ok {
+		tracker.wrote.Store(true)
+	}
+}
+
+func (rs *ReplicaSet) anyHealthy() bool {
+	for i := range rs.healthy {
+		if rs.healthy[i].Load() {
+			return true
+		}
+	}
+	return false
+}
+
+// Check pings the replicas and logs health changes
+func (rs *ReplicaSet) Check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i, replica := range rs.replicas {
+		wg.Add(1)
+		go func(i int, replica *sql.DB) {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			err := replica.PingContext(pingCtx)
+			cancel()
+
+			healthy := err == nil
+			if rs.healthy[i].Swap(healthy) != healthy {
+				if healthy {
+					rs.logger.Info("database replica healthy", zap.Int("replica", i))
+				} else {
+					rs.logger.Warn("database replica unhealthy, reads fall back to the primary", zap.Int("replica", i), zap.Error(err))
+				}
+			}
+		}(i, replica)
+	}
+	wg.Wait()
+}
+
+// replicaSetOf returns the ReplicaSet plugin of the database, or nil if there are no replicas
+func replicaSetOf(db *gorm.DB) *ReplicaSet {
+	replicaSet, _ := db.Config.Plugins[replicaSetPluginName].(*ReplicaSet)
+	return replicaSet
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type replicaRow struct {
+	ID   uint
+	Name string
+}
+
+func (suite *DatabaseSuiteTest) TestReplicaSet() {
+	tests := map[string]struct {
+		read     func(db *gorm.DB) (string, error)
+		expected string
+	}{
+		"Read from replica": {
+			read: func(db *gorm.DB) (string, error) {
+				row := &replicaRow{}
+				err := db.First(row).Error
+				return row.Name, err
+			},
+			expected: "replica",
+		},
+		"Raw select from replica": {
+			read: func(db *gorm.DB) (name string, err error) {
+				err = db.Raw("SELECT name FROM replica_rows LIMIT 1").Scan(&name).Error
+				return name, err
+			},
+			expected: "replica",
+		},
+		"Untracked write does not stick": {
+			read: func(db *gorm.DB) (string, error) {
+				if err := db.Create(&replicaRow{Name: "written"}).Error; err != nil {
+					return "", err
+				}
+				row := &replicaRow{}
+				err := db.First(row).Error
+				return row.Name, err
+			},
+			expected: "replica",
+		},
+		"Read your writes": {
+			read: func(db *gorm.DB) (string, error) {
+				db = db.WithContext(WithReadYourWrites(context.Background()))
+				if err := db.Create(&replicaRow{Name: "written"}).Error; err != nil {
+					return "", err
+				}
+				row := &replicaRow{}
+				err := db.Last(row).Error
+				return row.Name, err
+			},
+			expected: "written",
+		},
+		"Transaction reads the primary": {
+			read: func(db *gorm.DB) (name string, err error) {
+				err = db.Transaction(func(tx *gorm.DB) error {
+					row := &replicaRow{}
+					err := tx.First(row).Error
+					name = row.Name
+					return err
+				})
+				return name, err
+			},
+			expected: "primary",
+		},
+		"Unhealthy replica falls back to the primary": {
+			read: func(db *gorm.DB) (string, error) {
+				replicaSet := replicaSetOf(db)
+				replicaSet.replicas[0].Close()
+				replicaSet.Check(context.Background())
+				row := &replicaRow{}
+				err := db.First(row).Error
+				return row.Name, err
+			},
+			expected: "primary",
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			replicaDB, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s_replica?mode=memory&cache=shared", suite.T().Name())), &gorm.Config{})
+			suite.Require().NoError(err)
+			for db, name := range map[*gorm.DB]string{suite.db: "primary", replicaDB: "replica"} {
+				suite.Require().NoError(db.AutoMigrate(&replicaRow{}))
+				suite.Require().NoError(db.Create(&replicaRow{Name: name}).Error)
+			}
+			replica, err := replicaDB.DB()
+			suite.Require().NoError(err)
+			defer replica.Close()
+
+			err = suite.db.Use(NewReplicaSet(zap.NewNop(), []*sql.DB{replica}))
+			suite.Require().NoError(err, "No error should have occurred")
+
+			name, err := test.read(suite.db)
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expected, name, "The read should be routed to the expected database")
+		})
+	}
+}
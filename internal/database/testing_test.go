@@ -0,0 +1,35 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type DatabaseSuiteTest struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+// Before each sub test, open a new empty database
+func (suite *DatabaseSuiteTest) SetupSubTest() {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", suite.T().Name())), &gorm.Config{})
+	if err != nil {
+		panic(fmt.Errorf("error open database: %w", err))
+	}
+	suite.db = db
+}
+
+func (suite *DatabaseSuiteTest) TearDownSubTest() {
+	sqlDB, err := suite.db.DB()
+	if err == nil {
+		sqlDB.Close()
+	}
+}
+
+func TestDatabaseSuite(t *testing.T) {
+	suite.Run(t, new(DatabaseSuiteTest))
+}
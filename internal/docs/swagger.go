@@ -9,9 +9,15 @@
 //
 //	Consumes:
 //	- application/json
+//	- application/xml
+//	- application/msgpack
+//	- application/yaml
 //
 //	Produces:
 //	- application/json
+//	- application/xml
+//	- application/msgpack
+//	- application/yaml
 //
 // swagger:meta
 package docs
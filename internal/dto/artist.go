@@ -0,0 +1,27 @@
+package dto
+
+// Strategies of the artist deletion when albums reference the artist
+const (
+	// The deletion is refused with a conflict
+	DeleteArtistRestrict = "restrict"
+	// The albums and their library entries are soft deleted with the artist
+	DeleteArtistCascade = "cascade"
+	// The albums are moved to another artist
+	DeleteArtistReassign = "reassign"
+)
+
+type DeleteArtist struct {
+	ID uint
+	// The version the client knows, checked if not 0
+	Version uint
+	// One of the DeleteArtist* strategies, restrict if empty
+	Strategy string
+	// The artist receiving the albums with the reassign strategy
+	ReassignTo uint
+}
+
+// ArtistDependents counts the active rows referencing an artist
+type ArtistDependents struct {
+	Albums         int64
+	LibraryEntries int64
+}
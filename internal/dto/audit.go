@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// AuditFilter selects audit logs, zero fields don't filter
+type AuditFilter struct {
+	ActorType  string
+	ActorID    string
+	Action     string
+	EntityType string
+	EntityID   uint
+	// Logs created at or after From, and before To
+	From time.Time
+	To   time.Time
+
+	Limit  int
+	Offset int
+}
@@ -22,4 +22,8 @@ type RegisterUser struct {
 
 	// The birth date of the user.
 	BirthDate string `json:"birth_date" binding:"omitempty,datetime=2006-01-02"`
+
+	// If the user is an administrator.
+	// It can't be bound from a request, only trusted callers (e.g. the CLI) set it.
+	IsAdmin bool `json:"-"`
 }
@@ -0,0 +1,9 @@
+package dto
+
+// Modes of the bulk operations
+const (
+	// All the items are executed in one transaction, the first failure rolls back all the items
+	BulkAtomic = "atomic"
+	// The items are executed in batches, a failed item is rolled back alone
+	BulkBestEffort = "best_effort"
+)
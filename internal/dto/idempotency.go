@@ -0,0 +1,20 @@
+package dto
+
+// IdempotentRequest is a request sent with an Idempotency-Key header
+type IdempotentRequest struct {
+	// Endpoint and actor of the request
+	Scope string
+	Key   string
+	// Hash of the request
+	Fingerprint string
+
+	// ID of the key held by the request, set by BeginIdempotentRequest
+	ID uint
+}
+
+// IdempotentResponse is the response stored for an idempotency key
+type IdempotentResponse struct {
+	StatusCode int
+	// JSON body of the response
+	Body []byte
+}
@@ -0,0 +1,27 @@
+package dto
+
+// Entities which can be searched
+const (
+	SearchTypeArtists = "artists"
+	SearchTypeAlbums  = "albums"
+)
+
+type SearchQuery struct {
+	// Folded words which must all match, see search.Terms
+	Terms []string
+	// Searched entities, all of them if empty
+	Types []string
+}
+
+type SearchResult struct {
+	Type string
+	ID   uint
+	Name string
+	// Artist of an album, nil for an artist
+	ArtistID   *uint
+	ArtistName *string
+	// Relevance of the result, only comparable within a search
+	Score float64
+	// Name with the matched terms marked, see search.Highlight
+	Highlight string
+}
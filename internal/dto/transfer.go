@@ -0,0 +1,68 @@
+package dto
+
+// Statuses of an import job
+const (
+	ImportJobPending   = "pending"
+	ImportJobRunning   = "running"
+	ImportJobSucceeded = "succeeded"
+	ImportJobFailed    = "failed"
+)
+
+// Import is an imported file
+type Import struct {
+	// One of the transfer formats, csv or jsonl
+	Format string
+	// Maps the columns of the file to the fields, see transfer.NewReader
+	Mapping map[string]string
+	// The lines are validated and executed in a transaction rolled back at the end
+	DryRun  bool
+	Content []byte
+}
+
+// ArtistRecord is a line of an artists import, artists are upserted by name
+type ArtistRecord struct {
+	Name string `json:"name" validate:"required,max=255"`
+	// Albums added to the artist, the albums already existing are kept
+	Albums []string `json:"albums" validate:"dive,max=255"`
+}
+
+// Outcomes of an imported line
+const (
+	ImportCreated   = "created"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+)
+
+// ImportLineError is the error of an imported line
+// Tag and Param identify the failed validation rule, like a validation error, Message is used without translation
+type ImportLineError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Tag     string `json:"tag,omitempty"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportReport counts the outcomes of the lines of an import
+type ImportReport struct {
+	Total     int `json:"total"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+	// The first errors, see MaxImportErrors
+	Errors []*ImportLineError `json:"errors"`
+}
+
+// MaxImportErrors is the maximum number of line errors of a report, the failed lines are all counted
+const MaxImportErrors = 1000
+
+// Fail counts a failed line, and keeps its errors while the report has less than MaxImportErrors
+func (r *ImportReport) Fail(lineErrors ...*ImportLineError) {
+	r.Failed++
+	for _, lineError := range lineErrors {
+		if len(r.Errors) < MaxImportErrors {
+			r.Errors = append(r.Errors, lineError)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// Entities which can be listed, restored and purged from the trash
+const (
+	TrashEntityArtists = "artists"
+	TrashEntityAlbums  = "albums"
+)
+
+type TrashItem struct {
+	// The entity id.
+	// Required: true
+	ID uint `json:"id"`
+
+	// The entity name.
+	// Required: true
+	Name string `json:"name"`
+
+	// The deletion date.
+	// Required: true
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -20,15 +20,25 @@ var (
 	ErrNotImplemented = newErrcode("not implemented", 101)
 
 	//// database errors (200-299)
-	ErrDatabase        = newErrcode("database error", 200)
-	ErrDatabaseMigrate = newErrcode("database migrate error", 201)
-	ErrDropProduction  = newErrcode("production database cannot be dropped", 202)
+	ErrDatabase         = newErrcode("database error", 200)
+	ErrDatabaseMigrate  = newErrcode("database migrate error", 201)
+	ErrDropProduction   = newErrcode("production database cannot be dropped", 202)
+	ErrPendingMigration = newErrcode("pending database migrations", 203)
 
 	//// controllers errors (300-399)
-	ErrInvalidParameters   = newErrcode("invalid parameters", 300)
-	ErrNotFound            = newErrcode("not found", 301)
-	ErrUnknown             = newErrcode("unknown", 302)
-	ErrConfigurationFailed = newErrcode("configuration failed", 303)
+	ErrInvalidParameters    = newErrcode("invalid parameters", 300)
+	ErrNotFound             = newErrcode("not found", 301)
+	ErrUnknown              = newErrcode("unknown", 302)
+	ErrConfigurationFailed  = newErrcode("configuration failed", 303)
+	ErrConflict             = newErrcode("conflict with an existing entity", 304)
+	ErrPreconditionFailed   = newErrcode("the entity was modified", 305)
+	ErrPreconditionRequired = newErrcode("the If-Match header is required", 306)
+	ErrIdempotencyKeyReused = newErrcode("the idempotency key was used by another request", 307)
+	ErrIdempotencyInFlight  = newErrcode("a request with the same idempotency key is in progress", 308)
+	ErrBulkAborted          = newErrcode("the bulk operation was aborted by another item", 309)
+	ErrNotAcceptable        = newErrcode("no acceptable media type is supported", 310)
+	ErrUnsupportedMediaType = newErrcode("the media type of the body is not supported", 311)
+	ErrRequestTooLarge      = newErrcode("the request body is too large", 312)
 
 	//// auth errors (400-499)
 	ErrUnauthorized = newErrcode("unauthorized", 400)
@@ -44,12 +54,29 @@ var (
 	ErrInvalidToken       = newErrcode("invalid token", 504)
 	ErrTokenExpirated     = newErrcode("invalid token", 505)
 	ErrInvalidCredentials = newErrcode("invalid credentials", 506)
+	ErrAudit              = newErrcode("audit error", 507)
 )
 
 func newErrcode(message string, code int) GoCleanError {
 	return GoCleanError{errors.New(message), code}
 }
 
+// ContextError attaches a context to an error
+// The error handler middleware sends the context to the client with the error message
+type ContextError struct {
+	error
+	Context map[string]string
+}
+
+// WithContext attaches a context to an error
+func WithContext(err error, context map[string]string) error {
+	return &ContextError{err, context}
+}
+
+func (e *ContextError) Unwrap() error {
+	return e.error
+}
+
 func Wrap(err *error, format string, args ...any) {
 	if *err != nil {
 		*err = fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), *err)
@@ -0,0 +1,165 @@
+package fieldset
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxDepth is the maximum number of relations of an expansion, e.g. 2 for albums.tracks
+const MaxDepth = 2
+
+// Request is embedded in the request view models of GET endpoints
+// The fields and the expansions are checked by Params
+type Request struct {
+	// The response fields separated by commas, e.g. id,name,albums.name. All the fields by default.
+	// in:query
+	Fields string `json:"fields" form:"fields"`
+
+	// The relations included in the response separated by commas, e.g. albums.
+	// in:query
+	Expand string `json:"expand" form:"expand"`
+}
+
+// Fieldset is Request, embedded in the request view models which also embed another Request, e.g. pagination.Request
+type Fieldset = Request
+
+// Expandable maps the expansions of an endpoint to their GORM preload, e.g. "albums": "Albums"
+// Only the listed expansions are allowed, list the ones which are cheap to load
+type Expandable map[string]string
+
+// Params are the validated fields and expansions of a request
+type Params struct {
+	// Selected fields of the response body, all the fields if empty
+	Fields []string
+	// GORM preloads of the expansions
+	Preloads []string
+}
+
+// FieldError is an invalid fields or expand parameter
+// Tag identifies the rule, like a validation tag, and Param is its parameter
+type FieldError struct {
+	Field string
+	Tag   string
+	Param string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid %s: %s %s", e.Field, e.Tag, e.Param)
+}
+
+// Tags of the field errors
+const (
+	TagFields       = "fields"
+	TagExpand       = "expand"
+	TagExpandDepth  = "expand_depth"
+	TagFieldsExpand = "fields_expand"
+)
+
+// Params validates the request
+// body is the response body, whose JSON fields can be selected, and expandable the expansions of the endpoint
+// A nested field of a relation, e.g. albums.name, can only be selected with the expansion of the relation
+func (r *Request) Params(body interface{}, expandable Expandable) (*Params, error) {
+	params := &Params{}
+
+	// An expansion also loads its parent relations, e.g. albums for albums.tracks
+	expanded := make(map[string]bool)
+	for _, name := range split(r.Expand) {
+		if strings.Count(name, ".") >= MaxDepth {
+			return nil, &FieldError{Field: "expand", Tag: TagExpandDepth, Param: strconv.Itoa(MaxDepth)}
+		}
+		preload, ok := expandable[name]
+		if !ok {
+			return nil, &FieldError{Field: "expand", Tag: TagExpand, Param: expandableNames(expandable)}
+		}
+		params.Preloads = append(params.Preloads, preload)
+		for i := range name {
+			if name[i] == '.' {
+				expanded[name[:i]] = true
+			}
+		}
+		expanded[name] = true
+	}
+
+	if fields := split(r.Fields); len(fields) != 0 {
+		selectable := make(map[string]bool)
+		for _, field := range Fields(body) {
+			selectable[field] = true
+		}
+		for _, field := range fields {
+			if !selectable[field] {
+				return nil, &FieldError{Field: "fields", Tag: TagFields, Param: field}
+			}
+			for i := range field {
+				if relation := field[:i]; field[i] == '.' && expandable[relation] != "" && !expanded[relation] {
+					return nil, &FieldError{Field: "fields", Tag: TagFieldsExpand, Param: relation}
+				}
+			}
+		}
+		params.Fields = fields
+	}
+	return params, nil
+}
+
+// Fields returns the JSON fields of the value, and of its nested objects with dotted paths, e.g. albums.name
+func Fields(value interface{}) []string {
+	return appendFields(nil, "", reflect.TypeOf(value), 0)
+}
+
+// Nested objects deeper than the expansions have no selectable field
+const maxFieldDepth = MaxDepth + 1
+
+func appendFields(fields []string, prefix string, t reflect.Type, depth int) []string {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || depth > maxFieldDepth {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			fields = appendFields(fields, prefix, field.Type, depth)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, prefix+name)
+		fields = appendFields(fields, prefix+name+".", field.Type, depth+1)
+	}
+	return fields
+}
+
+// split returns the distinct values of a list separated by commas
+func split(list string) []string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+	return values
+}
+
+func expandableNames(expandable Expandable) string {
+	names := make([]string, 0, len(expandable))
+	for name := range expandable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,78 @@
+package fieldset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAlbum struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type testBody struct {
+	ID       uint         `json:"id"`
+	Name     string       `json:"name,omitempty"`
+	Password string       `json:"-"`
+	Albums   []*testAlbum `json:"albums,omitempty"`
+}
+
+var testExpandable = Expandable{"albums": "Albums", "albums.tracks": "Albums.Tracks"}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []string{"id", "name", "albums", "albums.id", "albums.name"}, Fields(testBody{}))
+	assert.Equal(t, []string{"id", "name"}, Fields(&testAlbum{}))
+	assert.Empty(t, Fields("name"))
+}
+
+func TestParams(t *testing.T) {
+	tests := map[string]struct {
+		request       Request
+		expected      *Params
+		expectedError *FieldError
+	}{
+		"Nothing": {
+			request:  Request{},
+			expected: &Params{},
+		},
+		"Fields and expansions": {
+			request:  Request{Fields: "id, albums.name,,id", Expand: "albums.tracks,albums"},
+			expected: &Params{Fields: []string{"id", "albums.name"}, Preloads: []string{"Albums.Tracks", "Albums"}},
+		},
+		"Unknown field": {
+			request:       Request{Fields: "id,password"},
+			expectedError: &FieldError{Field: "fields", Tag: TagFields, Param: "password"},
+		},
+		"Nested field of an expanded parent": {
+			request:  Request{Fields: "albums.name", Expand: "albums.tracks"},
+			expected: &Params{Fields: []string{"albums.name"}, Preloads: []string{"Albums.Tracks"}},
+		},
+		"Nested field without expansion": {
+			request:       Request{Fields: "id,albums.name"},
+			expectedError: &FieldError{Field: "fields", Tag: TagFieldsExpand, Param: "albums"},
+		},
+		"Unknown expansion": {
+			request:       Request{Expand: "users"},
+			expectedError: &FieldError{Field: "expand", Tag: TagExpand, Param: "albums, albums.tracks"},
+		},
+		"Too deep": {
+			request:       Request{Expand: "albums.tracks.artists"},
+			expectedError: &FieldError{Field: "expand", Tag: TagExpandDepth, Param: "2"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			params, err := test.request.Params(testBody{}, testExpandable)
+
+			if test.expectedError != nil {
+				assert.Equal(t, test.expectedError, err)
+				return
+			}
+			require.NoError(t, err, "No error should have occurred")
+			assert.Equal(t, test.expected, params)
+		})
+	}
+}
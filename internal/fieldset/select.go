@@ -0,0 +1,77 @@
+package fieldset
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// selection is a tree of selected fields, a field without children is selected with all its fields
+type selection map[string]selection
+
+func newSelection(fields []string) selection {
+	root := selection{}
+	for _, field := range fields {
+		root.add(strings.Split(field, "."))
+	}
+	return root
+}
+
+func (s selection) add(path []string) {
+	child, ok := s[path[0]]
+	if ok && len(child) == 0 {
+		// The field is already selected with all its fields
+		return
+	}
+	if !ok || len(path) == 1 {
+		// A field selected alone wins over its selected children
+		child = selection{}
+		s[path[0]] = child
+	}
+	if len(path) > 1 {
+		child.add(path[1:])
+	}
+}
+
+// Select keeps the fields of the JSON body, the fields of the objects of an array are selected in each object
+// The body is returned as is without fields
+func Select(body []byte, fields []string) ([]byte, error) {
+	if len(fields) == 0 {
+		return body, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// Numbers are kept as written, e.g. big ids are not rounded
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(newSelection(fields).apply(value))
+}
+
+func (s selection) apply(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		selected := make(map[string]interface{}, len(s))
+		for name, child := range s {
+			fieldValue, ok := value[name]
+			if !ok {
+				continue
+			}
+			if len(child) == 0 {
+				selected[name] = fieldValue
+			} else {
+				selected[name] = child.apply(fieldValue)
+			}
+		}
+		return selected
+	case []interface{}:
+		selected := make([]interface{}, len(value))
+		for i, item := range value {
+			selected[i] = s.apply(item)
+		}
+		return selected
+	default:
+		return value
+	}
+}
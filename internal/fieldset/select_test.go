@@ -0,0 +1,56 @@
+package fieldset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelect(t *testing.T) {
+	body := `{"id":12345678901234567890,"name":"Stromae","version":2,"albums":[{"id":1,"name":"Racine carrée"},{"id":2,"name":"Multitude"}]}`
+
+	tests := map[string]struct {
+		fields   []string
+		expected string
+	}{
+		"All fields": {
+			fields:   nil,
+			expected: body,
+		},
+		"Fields": {
+			fields:   []string{"id", "name"},
+			expected: `{"id":12345678901234567890,"name":"Stromae"}`,
+		},
+		"Nested fields": {
+			fields:   []string{"name", "albums.name"},
+			expected: `{"albums":[{"name":"Racine carrée"},{"name":"Multitude"}],"name":"Stromae"}`,
+		},
+		"Parent wins over children": {
+			fields:   []string{"albums.name", "albums"},
+			expected: `{"albums":[{"id":1,"name":"Racine carrée"},{"id":2,"name":"Multitude"}]}`,
+		},
+		"Children after parent": {
+			fields:   []string{"albums", "albums.name"},
+			expected: `{"albums":[{"id":1,"name":"Racine carrée"},{"id":2,"name":"Multitude"}]}`,
+		},
+		"Missing fields": {
+			fields:   []string{"version", "artists.name", "name.first"},
+			expected: `{"name":"Stromae","version":2}`,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			selected, err := Select([]byte(body), test.fields)
+
+			require.NoError(t, err, "No error should have occurred")
+			assert.Equal(t, test.expected, string(selected))
+		})
+	}
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := Select([]byte("{"), []string{"id"})
+		assert.Error(t, err)
+	})
+}
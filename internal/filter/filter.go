@@ -0,0 +1,290 @@
+package filter
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operators of the conditions, a query parameter `field[operator]=value` is a condition on the field
+// A parameter without operator, `field=value`, is an eq condition
+const (
+	Eq         = "eq"
+	Ne         = "ne"
+	Gt         = "gt"
+	Gte        = "gte"
+	Lt         = "lt"
+	Lte        = "lte"
+	Contains   = "contains"
+	StartsWith = "starts_with"
+	// The value of an in condition is a list separated by commas
+	In = "in"
+)
+
+// Maximum number of values of an in condition
+const MaxValues = 100
+
+// Condition is a condition on a column, its value is a slice for the in operator
+type Condition struct {
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+// Value is a type of filterable field
+type Value interface {
+	string | int | uint | float64 | bool | time.Time
+}
+
+// Field is a filterable field of a request view model, bound from the query string by Bind
+//
+//	Name filter.Field[string] `json:"name" form:"-" filter:"name" operators:"eq,contains"`
+//
+// The json tag is the query parameter, the filter tag the column, and the operators tag the allowed operators, eq by default
+// Only the fields of a view model are filterable: the columns are never taken from the request
+type Field[T Value] struct {
+	conditions []Condition
+}
+
+// Binder is the binding of a filterable field
+type Binder interface {
+	// bind adds the condition of the operator on the column with the raw value of the query string
+	bind(column, operator, value string) error
+	// Conditions returns the conditions of the field
+	Conditions() []Condition
+}
+
+func (f *Field[T]) Conditions() []Condition {
+	return f.conditions
+}
+
+// ValueType returns the type of the values of the field, e.g. uint for Field[uint]
+func (f *Field[T]) ValueType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// IsZero tells if the field has no condition
+func (f *Field[T]) IsZero() bool {
+	return len(f.conditions) == 0
+}
+
+func (f *Field[T]) bind(column, operator, value string) error {
+	if operator == In {
+		rawValues := strings.Split(value, ",")
+		if len(rawValues) > MaxValues {
+			return &FieldError{Tag: TagValues, Param: strconv.Itoa(MaxValues)}
+		}
+		values := make([]T, 0, len(rawValues))
+		for _, rawValue := range rawValues {
+			parsed, err := parse[T](rawValue)
+			if err != nil {
+				return err
+			}
+			values = append(values, parsed)
+		}
+		f.conditions = append(f.conditions, Condition{Column: column, Operator: operator, Value: values})
+		return nil
+	}
+
+	parsed, err := parse[T](value)
+	if err != nil {
+		return err
+	}
+	f.conditions = append(f.conditions, Condition{Column: column, Operator: operator, Value: parsed})
+	return nil
+}
+
+// Layouts of the dates of the query string
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02"}
+
+func parse[T Value](raw string) (value T, err error) {
+	var parsed interface{}
+	switch any(value).(type) {
+	case string:
+		parsed = raw
+	case int:
+		parsed, err = strconv.Atoi(raw)
+	case uint:
+		var n uint64
+		n, err = strconv.ParseUint(raw, 10, 0)
+		parsed = uint(n)
+	case float64:
+		parsed, err = strconv.ParseFloat(raw, 64)
+	case bool:
+		parsed, err = strconv.ParseBool(raw)
+	case time.Time:
+		for _, layout := range timeLayouts {
+			parsed, err = time.Parse(layout, raw)
+			if err == nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return value, &FieldError{Tag: TagValue, Param: typeName(value)}
+	}
+	return parsed.(T), nil
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case int, uint:
+		return "integer"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case time.Time:
+		return "date"
+	default:
+		return "string"
+	}
+}
+
+// FieldError is an invalid filter, like a validation error
+// Field is the query parameter, Tag identifies the rule and Param is its parameter
+type FieldError struct {
+	Field string
+	Tag   string
+	Param string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid filter %s: %s %s", e.Field, e.Tag, e.Param)
+}
+
+// Tags of the field errors
+const (
+	// The field can't be filtered
+	TagFilter = "filter"
+	// The operator is not allowed, Param lists the allowed operators
+	TagOperator = "filter_operator"
+	// The value can't be parsed, Param is its type
+	TagValue = "filter_value"
+	// The in condition has too many values, Param is the maximum
+	TagValues = "filter_values"
+)
+
+// Operators allowed on the types of values, other operators are refused even if a field allows them
+var typeOperators = map[string][]string{
+	"string":  {Eq, Ne, Contains, StartsWith, In},
+	"integer": {Eq, Ne, Gt, Gte, Lt, Lte, In},
+	"number":  {Eq, Ne, Gt, Gte, Lt, Lte},
+	"boolean": {Eq, Ne},
+	"date":    {Eq, Ne, Gt, Gte, Lt, Lte},
+}
+
+// Bind sets the filterable fields of the view model from the query string
+// The query parameters with an operator, `field[operator]`, must be filterable fields
+func Bind(query url.Values, viewmodel interface{}) error {
+	value := reflect.ValueOf(viewmodel)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	value = value.Elem()
+
+	// Filterable fields by query parameter
+	type filterable struct {
+		binder    Binder
+		column    string
+		operators []string
+	}
+	fields := map[string]filterable{}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		column := field.Tag.Get("filter")
+		if column == "" || !field.IsExported() {
+			continue
+		}
+		binder, ok := value.Field(i).Addr().Interface().(Binder)
+		if !ok {
+			continue
+		}
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			name = column
+		}
+		operators := []string{Eq}
+		if tag := field.Tag.Get("operators"); tag != "" {
+			operators = strings.Split(tag, ",")
+		}
+		fields[name] = filterable{binder, column, operators}
+	}
+
+	// Sort the parameters, so the first invalid one is always the same
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		name, operator, hasOperator := strings.Cut(key, "[")
+		if hasOperator {
+			if !strings.HasSuffix(operator, "]") {
+				return &FieldError{Field: key, Tag: TagFilter}
+			}
+			operator = strings.TrimSuffix(operator, "]")
+		} else {
+			operator = Eq
+		}
+
+		field, ok := fields[name]
+		if !ok {
+			if hasOperator {
+				return &FieldError{Field: key, Tag: TagFilter}
+			}
+			// Other query parameters are bound by gin
+			continue
+		}
+		if !slices.Contains(field.operators, operator) || !slices.Contains(typeOperators[typeOf(field.binder)], operator) {
+			return &FieldError{Field: key, Tag: TagOperator, Param: strings.Join(field.operators, ", ")}
+		}
+		for _, raw := range query[key] {
+			if err := field.binder.bind(field.column, operator, raw); err != nil {
+				fieldError := err.(*FieldError)
+				fieldError.Field = key
+				return fieldError
+			}
+		}
+	}
+	return nil
+}
+
+// typeOf returns the type name of the values of a field
+func typeOf(binder Binder) string {
+	switch binder.(type) {
+	case *Field[int], *Field[uint]:
+		return "integer"
+	case *Field[float64]:
+		return "number"
+	case *Field[bool]:
+		return "boolean"
+	case *Field[time.Time]:
+		return "date"
+	default:
+		return "string"
+	}
+}
+
+// Conditions returns the conditions of the filterable fields of the view model
+func Conditions(viewmodel interface{}) []Condition {
+	value := reflect.Indirect(reflect.ValueOf(viewmodel))
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	conditions := []Condition{}
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Type().Field(i).IsExported() {
+			continue
+		}
+		if binder, ok := value.Field(i).Addr().Interface().(Binder); ok {
+			conditions = append(conditions, binder.Conditions()...)
+		}
+	}
+	return conditions
+}
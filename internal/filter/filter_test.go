@@ -0,0 +1,98 @@
+package filter
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type filterRequest struct {
+	ID        Field[uint]      `json:"id" filter:"id" operators:"eq,in"`
+	Name      Field[string]    `json:"name" filter:"name" operators:"eq,contains,gt"`
+	CreatedAt Field[time.Time] `json:"created_at" filter:"created_at" operators:"gte,lt"`
+	Limit     int              `json:"limit"`
+}
+
+func TestBind(t *testing.T) {
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		query         string
+		expected      []Condition
+		expectedError *FieldError
+	}{
+		"Conditions": {
+			query: "id[in]=1,2&name[contains]=em&created_at[gte]=2024-01-02&created_at[lt]=2024-01-02T00:00:00Z",
+			expected: []Condition{
+				{Column: "id", Operator: In, Value: []uint{1, 2}},
+				{Column: "name", Operator: Contains, Value: "em"},
+				{Column: "created_at", Operator: Gte, Value: date},
+				{Column: "created_at", Operator: Lt, Value: date},
+			},
+		},
+		"Equality": {
+			query:    "name=Eminem&limit=2",
+			expected: []Condition{{Column: "name", Operator: Eq, Value: "Eminem"}},
+		},
+		"Unknown field": {
+			query:         "password[contains]=a",
+			expectedError: &FieldError{Field: "password[contains]", Tag: TagFilter},
+		},
+		"Malformed operator": {
+			query:         "name[contains=a",
+			expectedError: &FieldError{Field: "name[contains", Tag: TagFilter},
+		},
+		"Operator not allowed": {
+			query:         "created_at=2024-01-02",
+			expectedError: &FieldError{Field: "created_at", Tag: TagOperator, Param: "gte, lt"},
+		},
+		"Operator not allowed for the type": {
+			query:         "name[gt]=a",
+			expectedError: &FieldError{Field: "name[gt]", Tag: TagOperator, Param: "eq, contains, gt"},
+		},
+		"Invalid value": {
+			query:         "id[in]=1,a",
+			expectedError: &FieldError{Field: "id[in]", Tag: TagValue, Param: "integer"},
+		},
+		"Invalid date": {
+			query:         "created_at[gte]=yesterday",
+			expectedError: &FieldError{Field: "created_at[gte]", Tag: TagValue, Param: "date"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			require.NoError(t, err)
+			request := &filterRequest{}
+
+			err = Bind(query, request)
+
+			if test.expectedError != nil {
+				var fieldError *FieldError
+				require.True(t, errors.As(err, &fieldError), "Error should be a field error")
+				assert.Equal(t, test.expectedError, fieldError)
+				return
+			}
+			require.NoError(t, err, "No error should have occurred")
+			assert.Equal(t, test.expected, Conditions(request))
+		})
+	}
+}
+
+func TestBindTooManyValues(t *testing.T) {
+	values := "1"
+	for i := 0; i < MaxValues; i++ {
+		values += ",1"
+	}
+
+	err := Bind(url.Values{"id[in]": {values}}, &filterRequest{})
+
+	var fieldError *FieldError
+	require.True(t, errors.As(err, &fieldError), "Error should be a field error")
+	assert.Equal(t, TagValues, fieldError.Tag)
+}
@@ -0,0 +1,72 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Escape character of the LIKE patterns
+const likeEscape = "!"
+
+var likeEscaper = strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+
+// Apply adds the conditions to the query
+// Columns are quoted and values are bound, so a condition can't inject SQL
+// contains and starts_with are case insensitive
+func Apply(query *gorm.DB, conditions []Condition) (*gorm.DB, error) {
+	for _, condition := range conditions {
+		expression, err := expression(condition)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(expression)
+	}
+	return query, nil
+}
+
+func expression(condition Condition) (clause.Expression, error) {
+	column := clause.Column{Table: clause.CurrentTable, Name: condition.Column}
+	switch condition.Operator {
+	case Eq:
+		return clause.Eq{Column: column, Value: condition.Value}, nil
+	case Ne:
+		return clause.Neq{Column: column, Value: condition.Value}, nil
+	case Gt:
+		return clause.Gt{Column: column, Value: condition.Value}, nil
+	case Gte:
+		return clause.Gte{Column: column, Value: condition.Value}, nil
+	case Lt:
+		return clause.Lt{Column: column, Value: condition.Value}, nil
+	case Lte:
+		return clause.Lte{Column: column, Value: condition.Value}, nil
+	case In:
+		values := reflect.ValueOf(condition.Value)
+		if values.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("filter: %s of %s needs a slice", condition.Operator, condition.Column)
+		}
+		in := clause.IN{Column: column, Values: make([]interface{}, values.Len())}
+		for i := range in.Values {
+			in.Values[i] = values.Index(i).Interface()
+		}
+		return in, nil
+	case Contains, StartsWith:
+		value, ok := condition.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("filter: %s of %s needs a string", condition.Operator, condition.Column)
+		}
+		pattern := likeEscaper.Replace(strings.ToLower(value)) + "%"
+		if condition.Operator == Contains {
+			pattern = "%" + pattern
+		}
+		return clause.Expr{
+			SQL:  "LOWER(?) LIKE ? ESCAPE '" + likeEscape + "'",
+			Vars: []interface{}{column, pattern},
+		}, nil
+	default:
+		return nil, fmt.Errorf("filter: unknown operator %s", condition.Operator)
+	}
+}
@@ -0,0 +1,75 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type filterModel struct {
+	ID   uint
+	Name string
+}
+
+func TestApply(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:filter?mode=memory"), &gorm.Config{})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		conditions   []Condition
+		expectedSQL  string
+		expectedVars []interface{}
+	}{
+		"Comparisons": {
+			conditions: []Condition{
+				{Column: "id", Operator: Gte, Value: uint(2)},
+				{Column: "name", Operator: Ne, Value: "Eminem"},
+			},
+			expectedSQL:  "SELECT * FROM `filter_models` WHERE `filter_models`.`id` >= ? AND `filter_models`.`name` <> ?",
+			expectedVars: []interface{}{uint(2), "Eminem"},
+		},
+		"In": {
+			conditions:   []Condition{{Column: "id", Operator: In, Value: []uint{1, 2}}},
+			expectedSQL:  "SELECT * FROM `filter_models` WHERE `filter_models`.`id` IN (?,?)",
+			expectedVars: []interface{}{uint(1), uint(2)},
+		},
+		"Contains is escaped": {
+			conditions:   []Condition{{Column: "name", Operator: Contains, Value: "100%_Hits!"}},
+			expectedSQL:  "SELECT * FROM `filter_models` WHERE LOWER(`filter_models`.`name`) LIKE ? ESCAPE '!'",
+			expectedVars: []interface{}{"%100!%!_hits!!%"},
+		},
+		"Starts with": {
+			conditions:   []Condition{{Column: "name", Operator: StartsWith, Value: "Em"}},
+			expectedSQL:  "SELECT * FROM `filter_models` WHERE LOWER(`filter_models`.`name`) LIKE ? ESCAPE '!'",
+			expectedVars: []interface{}{"em%"},
+		},
+		"Column is quoted": {
+			conditions:   []Condition{{Column: "name`; DROP TABLE users; --", Operator: Eq, Value: "a"}},
+			expectedSQL:  "SELECT * FROM `filter_models` WHERE `filter_models`.`name``; DROP TABLE users; --` = ?",
+			expectedVars: []interface{}{"a"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			query, err := Apply(db.Session(&gorm.Session{DryRun: true}).Model(&filterModel{}), test.conditions)
+			require.NoError(t, err, "No error should have occurred")
+
+			stmt := query.Find(&[]filterModel{}).Statement
+			assert.Equal(t, test.expectedSQL, stmt.SQL.String())
+			assert.Equal(t, test.expectedVars, stmt.Vars)
+		})
+	}
+}
+
+func TestApplyUnknownOperator(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:filter?mode=memory"), &gorm.Config{})
+	require.NoError(t, err)
+
+	_, err = Apply(db.Model(&filterModel{}), []Condition{{Column: "name", Operator: "regexp", Value: "a"}})
+
+	assert.Error(t, err, "Unknown operator should be refused")
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -10,7 +11,17 @@ type Model struct {
 	ID        uint `gorm:"primaryKey"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	// Incremented by each update, an update of a stale version is refused
+	Version uint `gorm:"not null;default:1"`
+}
+
+// BeforeCreate starts the version of the created row at 1
+func (m *Model) BeforeCreate(tx *gorm.DB) error {
+	if m.Version == 0 {
+		m.Version = 1
+	}
+	return nil
 }
 
 type User struct {
@@ -20,15 +31,25 @@ type User struct {
 	BirthDate time.Time
 	Phone     string
 	Email     string `gorm:"unique"`
-	Password  string
+	Password  string `audit:"-"`
+	IsAdmin   bool
 
 	// Relations
 	UserAlbums []*UserAlbum
 }
 
+// HashPassword returns the bcrypt hash of a password, stored in User.Password
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), 10)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
 type Artist struct {
 	Model
-	Name string
+	Name string `gorm:"uniqueIndex:artist_name_idx,where:deleted_at IS NULL"`
 
 	// Relations
 	Albums []*Album
@@ -36,17 +57,17 @@ type Artist struct {
 
 type Album struct {
 	Model
-	Name     string `gorm:"uniqueIndex:album_idx"`
-	ArtistID uint   `gorm:"uniqueIndex:album_idx"`
-	Artist   *Artist
+	Name     string  `gorm:"uniqueIndex:album_idx,where:deleted_at IS NULL"`
+	ArtistID uint    `gorm:"uniqueIndex:album_idx,where:deleted_at IS NULL"`
+	Artist   *Artist `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 }
 
 type UserAlbum struct {
 	Model
 	UserID  uint
-	User    *User
+	User    *User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	AlbumID uint
-	Album   *Album
+	Album   *Album `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 type Translation struct {
@@ -57,3 +78,53 @@ type Translation struct {
 	Language   string `gorm:"uniqueIndex:translation_idx"`
 	Value      string
 }
+
+// AuditLog records a mutation, it is never updated nor soft deleted
+type AuditLog struct {
+	ID         uint      `gorm:"primaryKey"`
+	CreatedAt  time.Time `gorm:"index"`
+	ActorType  string    `gorm:"index:idx_audit_logs_actor"`
+	ActorID    string    `gorm:"index:idx_audit_logs_actor"`
+	Action     string
+	EntityType string `gorm:"index:idx_audit_logs_entity"`
+	EntityID   uint   `gorm:"index:idx_audit_logs_entity"`
+	// JSON object of the changed fields, see audit.Diff
+	Changes   string `gorm:"type:text"`
+	RequestID string
+	IP        string
+}
+
+// IdempotencyKey is the outcome of a request sent with an Idempotency-Key header
+// A key without status code is held by a request in progress
+type IdempotencyKey struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	// Endpoint and actor of the request, the same key can be used by other actors
+	Scope string `gorm:"size:255;uniqueIndex:idx_idempotency_keys_key"`
+	Key   string `gorm:"column:idempotency_key;size:255;uniqueIndex:idx_idempotency_keys_key"`
+	// Hash of the request, a key can't be reused with another payload
+	Fingerprint string
+	StatusCode  int
+	// JSON body of the response
+	Response  string    `gorm:"type:text"`
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+// ImportJob is an import run in background, the client polls it until it is finished
+type ImportJob struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Imported entity type, e.g. artists
+	EntityType string
+	DryRun     bool
+	// One of the dto.ImportJob* statuses
+	Status string
+	// JSON of the dto.ImportReport, set when the job is finished
+	Report string `gorm:"type:text"`
+	// Message of the error which stopped the job
+	Error      string
+	FinishedAt *time.Time
+	// Refreshed by the server running the job, see repositories.ImportJobRepository.FailStale
+	HeartbeatAt *time.Time
+}
@@ -0,0 +1,338 @@
+package openapi
+
+import (
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sarrooo/go-clean/internal/params"
+)
+
+// Version of the OpenAPI specification of the documents
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3 document, see https://spec.openapis.org/oas/v3.0.3
+// Only the objects generated from the routes are supported
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem has the operations of a path by lower case method, e.g. get
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is a JSON schema, an empty schema is any value
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// Route is a registered route, Request and Response are its view models, nil if it has none
+type Route struct {
+	Method string
+	// Path of the router, e.g. /albums/:id
+	Path string
+	// OperationID is the name of the controller, e.g. getArtistController
+	OperationID string
+	Request     reflect.Type
+	Response    reflect.Type
+}
+
+// StatusCoder is a response view model answered with other status codes than 200, e.g. 202 or 207
+// Each status code is a response of the document, 200 is only documented if it is one of them
+type StatusCoder interface {
+	StatusCodes() []int
+}
+
+// Options of the generated document
+type Options struct {
+	Info Info
+	// MediaTypes of the request and response bodies, see codec.MediaTypes
+	MediaTypes []string
+	// Error is the view model of the error responses
+	Error reflect.Type
+}
+
+// Parameter locations of the tags, see params.Bind
+var parameterLocations = []struct {
+	tag string
+	in  string
+}{
+	{params.TagURI, "path"},
+	{params.TagQuery, "query"},
+	{params.TagHeader, "header"},
+}
+
+var fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+
+// routeParameter matches the parameters of a router path, e.g. :id
+var routeParameter = regexp.MustCompile(`[:*](\w+)`)
+
+// Generate returns the document of the routes
+// The parameters are the fields tagged with uri, query or header, the filterable fields and, without body,
+// the fields tagged with form. The body is the Body field of the view models, or a multipart form if a field is a file
+func Generate(options *Options, routes []Route) *Document {
+	g := &generator{schemas: map[string]*Schema{}, names: map[reflect.Type]string{}}
+	document := &Document{
+		OpenAPI:    Version,
+		Info:       options.Info,
+		Paths:      map[string]*PathItem{},
+		Components: Components{Schemas: g.schemas},
+	}
+
+	var errorResponse *Response
+	if options.Error != nil {
+		errorResponse = g.response("Error", options.Error, options.MediaTypes)
+	}
+
+	operationIDs := map[string]int{}
+	for _, route := range routes {
+		path := routeParameter.ReplaceAllString(route.Path, "{$1}")
+		item, ok := document.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			document.Paths[path] = item
+		}
+
+		// The operation ids are unique, e.g. the routes of the same controller are exportArtistsController and exportArtistsController2
+		operationID := route.OperationID
+		if operationIDs[route.OperationID]++; operationIDs[route.OperationID] > 1 {
+			operationID += strconv.Itoa(operationIDs[route.OperationID])
+		}
+		operation := &Operation{
+			OperationID: operationID,
+			Responses:   map[string]*Response{},
+		}
+		if segments := strings.Split(strings.Trim(route.Path, "/"), "/"); segments[0] != "" {
+			operation.Tags = []string{segments[0]}
+		}
+		if route.Request != nil {
+			operation.Parameters, operation.RequestBody = g.request(route.Request, options.MediaTypes)
+		}
+		operation.Parameters = withPathParameters(operation.Parameters, route.Path)
+
+		for _, statusCode := range statusCodes(route.Response) {
+			operation.Responses[strconv.Itoa(statusCode)] = &Response{Description: http.StatusText(statusCode)}
+			if route.Response != nil {
+				operation.Responses[strconv.Itoa(statusCode)] = g.response(http.StatusText(statusCode), route.Response, options.MediaTypes)
+			}
+		}
+		if errorResponse != nil {
+			operation.Responses["default"] = errorResponse
+		}
+		(*item)[strings.ToLower(route.Method)] = operation
+	}
+	return document
+}
+
+// statusCodes returns the status codes of a response view model, 200 unless it is a StatusCoder
+func statusCodes(typ reflect.Type) []int {
+	if typ == nil {
+		return []int{http.StatusOK}
+	}
+	if typ.Kind() != reflect.Ptr {
+		typ = reflect.PtrTo(typ)
+	}
+	if coder, ok := reflect.New(typ.Elem()).Interface().(StatusCoder); ok {
+		return coder.StatusCodes()
+	}
+	return []int{http.StatusOK}
+}
+
+// withPathParameters adds the path parameters which are not bound, they are strings
+func withPathParameters(parameters []*Parameter, path string) []*Parameter {
+	for _, match := range routeParameter.FindAllStringSubmatch(path, -1) {
+		found := false
+		for _, parameter := range parameters {
+			found = found || (parameter.In == "path" && parameter.Name == match[1])
+		}
+		if !found {
+			parameters = append(parameters, &Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	return parameters
+}
+
+// generator holds the named schemas of the document
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+// request returns the parameters and the body of a request view model
+func (g *generator) request(typ reflect.Type, mediaTypes []string) ([]*Parameter, *RequestBody) {
+	typ = indirect(typ)
+	if typ.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	bodyField, hasBody := typ.FieldByName("Body")
+	multipartForm := hasFile(typ)
+
+	var parameters []*Parameter
+	form := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	eachField(typ, func(field reflect.StructField) {
+		required := hasRule(field, "required")
+		for _, location := range parameterLocations {
+			if name := tagName(field, location.tag); name != "" {
+				parameters = append(parameters, &Parameter{Name: name, In: location.in, Required: required || location.in == "path", Schema: g.schema(field.Type, field)})
+				return
+			}
+		}
+		if valueType, operators, ok := filterField(field); ok {
+			name := tagName(field, "json")
+			parameters = append(parameters, &Parameter{
+				Name:        name,
+				In:          "query",
+				Description: "Operators: " + strings.Join(operators, ", ") + ", e.g. " + name + "[" + operators[len(operators)-1] + "]=",
+				Schema:      g.schema(valueType, reflect.StructField{}),
+			})
+			return
+		}
+		name := tagName(field, "form")
+		switch {
+		case name == "" || hasBody:
+		case multipartForm:
+			form.Properties[name] = g.schema(field.Type, field)
+			if required {
+				form.Required = append(form.Required, name)
+			}
+		case !hasBody:
+			parameters = append(parameters, &Parameter{Name: name, In: "query", Required: required, Schema: g.schema(field.Type, field)})
+		}
+	})
+
+	switch {
+	case multipartForm:
+		return parameters, &RequestBody{Required: true, Content: map[string]*MediaType{"multipart/form-data": {Schema: form}}}
+	case hasBody:
+		schema := g.schema(bodyField.Type, bodyField)
+		return parameters, &RequestBody{Required: hasRule(bodyField, "required"), Content: content(schema, mediaTypes)}
+	default:
+		return parameters, nil
+	}
+}
+
+// response returns the response of a response view model, its Body field is the content
+func (g *generator) response(description string, typ reflect.Type, mediaTypes []string) *Response {
+	response := &Response{Description: description}
+	if field, ok := indirect(typ).FieldByName("Body"); ok && indirect(typ).Kind() == reflect.Struct {
+		response.Content = content(g.schema(field.Type, field), mediaTypes)
+	}
+	return response
+}
+
+func content(schema *Schema, mediaTypes []string) map[string]*MediaType {
+	content := make(map[string]*MediaType, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = &MediaType{Schema: schema}
+	}
+	return content
+}
+
+func hasFile(typ reflect.Type) bool {
+	found := false
+	eachField(typ, func(field reflect.StructField) {
+		found = found || field.Type == fileHeaderType
+	})
+	return found
+}
+
+// filterField returns the type of the values and the operators of a filterable field, see filter.Field
+func filterField(field reflect.StructField) (reflect.Type, []string, bool) {
+	valued, ok := reflect.New(field.Type).Interface().(interface{ ValueType() reflect.Type })
+	if !ok || field.Tag.Get("filter") == "" {
+		return nil, nil, false
+	}
+	operators := []string{"eq"}
+	if tag := field.Tag.Get("operators"); tag != "" {
+		operators = strings.Split(tag, ",")
+	}
+	return valued.ValueType(), operators, true
+}
+
+// eachField calls fn with the exported fields of a struct, and the fields of its embedded structs
+func eachField(typ reflect.Type, fn func(field reflect.StructField)) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && tagName(field, "json") == "" && indirect(field.Type).Kind() == reflect.Struct {
+			eachField(indirect(field.Type), fn)
+			continue
+		}
+		if field.IsExported() {
+			fn(field)
+		}
+	}
+}
+
+// tagName returns the name of a tag of a field, empty if it has none or is -
+func tagName(field reflect.StructField, tag string) string {
+	name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+func indirect(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
@@ -0,0 +1,209 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Item struct {
+	ID        uint      `json:"id"`
+	Tags      []string  `json:"tags" binding:"max=3,dive,min=1"`
+	CreatedAt time.Time `json:"created_at"`
+	Parent    *Item     `json:"parent,omitempty"`
+	Secret    string    `json:"-"`
+}
+
+type pagination struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type listRequest struct {
+	pagination
+	Name   filter.Field[string] `json:"name" form:"-" filter:"name" operators:"eq,contains"`
+	Status string               `json:"status" form:"status" binding:"omitempty,oneof=draft published"`
+	Tenant string               `header:"X-Tenant" binding:"required"`
+}
+
+type listResponse struct {
+	Body struct {
+		Items []*Item `json:"items"`
+	}
+}
+
+type updateRequest struct {
+	ID   uint `uri:"id"`
+	Body struct {
+		Name string `json:"name" binding:"required,max=50"`
+		// Not bound with a body
+		Ignored string `form:"ignored"`
+	} `json:"body" binding:"required"`
+}
+
+type uploadRequest struct {
+	File   *multipart.FileHeader `json:"file" form:"file" binding:"required"`
+	DryRun bool                  `json:"dry_run" form:"dry_run"`
+}
+
+// Answered 202 when the upload is processed in background
+type uploadResponse struct {
+	Body struct {
+		Status string `json:"status"`
+	} `json:"body"`
+}
+
+func (uploadResponse) StatusCodes() []int {
+	return []int{http.StatusOK, http.StatusAccepted}
+}
+
+type errorResponse struct {
+	Body struct {
+		Message string `json:"message"`
+	} `json:"body"`
+}
+
+func TestGenerate(t *testing.T) {
+	document := Generate(&Options{
+		Info:       Info{Title: "Test", Version: "1.0.0"},
+		MediaTypes: []string{"application/json"},
+		Error:      reflect.TypeOf(errorResponse{}),
+	}, []Route{
+		{Method: http.MethodGet, Path: "/items", OperationID: "listController", Request: reflect.TypeOf(&listRequest{}), Response: reflect.TypeOf(&listResponse{})},
+		{Method: http.MethodPut, Path: "/items/:id", OperationID: "updateController", Request: reflect.TypeOf(&updateRequest{})},
+		{Method: http.MethodPost, Path: "/items/upload", OperationID: "uploadController", Request: reflect.TypeOf(&uploadRequest{}), Response: reflect.TypeOf(&uploadResponse{})},
+		{Method: http.MethodGet, Path: "/items/:id/export", OperationID: "exportController"},
+		{Method: http.MethodGet, Path: "/items/:id/export.csv", OperationID: "exportController"},
+	})
+
+	encoded, err := json.Marshal(document)
+	require.NoError(t, err)
+	var actual map[string]interface{}
+	require.NoError(t, json.Unmarshal(encoded, &actual))
+
+	expected := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0.0"},
+		"paths": {
+			"/items": {
+				"get": {
+					"operationId": "listController",
+					"tags": ["items"],
+					"parameters": [
+						{"name": "limit", "in": "query", "schema": {"type": "integer", "format": "int64", "minimum": 1, "maximum": 100}},
+						{"name": "name", "in": "query", "description": "Operators: eq, contains, e.g. name[contains]=", "schema": {"type": "string"}},
+						{"name": "status", "in": "query", "schema": {"type": "string", "enum": ["draft", "published"]}},
+						{"name": "X-Tenant", "in": "header", "required": true, "schema": {"type": "string"}}
+					],
+					"responses": {
+						"200": {"description": "OK", "content": {"application/json": {"schema": {
+							"type": "object",
+							"properties": {"items": {"type": "array", "items": {"$ref": "#/components/schemas/Item"}}}
+						}}}},
+						"default": {"$ref_error": true}
+					}
+				}
+			},
+			"/items/{id}": {
+				"put": {
+					"operationId": "updateController",
+					"tags": ["items"],
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer", "minimum": 0}}],
+					"requestBody": {"required": true, "content": {"application/json": {"schema": {
+						"type": "object",
+						"properties": {"name": {"type": "string", "maxLength": 50}, "Ignored": {"type": "string"}},
+						"required": ["name"]
+					}}}},
+					"responses": {"200": {"description": "OK"}, "default": {"$ref_error": true}}
+				}
+			},
+			"/items/upload": {
+				"post": {
+					"operationId": "uploadController",
+					"tags": ["items"],
+					"requestBody": {"required": true, "content": {"multipart/form-data": {"schema": {
+						"type": "object",
+						"properties": {"file": {"type": "string", "format": "binary"}, "dry_run": {"type": "boolean"}},
+						"required": ["file"]
+					}}}},
+					"responses": {
+						"200": {"description": "OK", "content": {"application/json": {"schema": {
+							"type": "object",
+							"properties": {"status": {"type": "string"}}
+						}}}},
+						"202": {"description": "Accepted", "content": {"application/json": {"schema": {
+							"type": "object",
+							"properties": {"status": {"type": "string"}}
+						}}}},
+						"default": {"$ref_error": true}
+					}
+				}
+			},
+			"/items/{id}/export": {
+				"get": {
+					"operationId": "exportController",
+					"tags": ["items"],
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+					"responses": {"200": {"description": "OK"}, "default": {"$ref_error": true}}
+				}
+			},
+			"/items/{id}/export.csv": {
+				"get": {
+					"operationId": "exportController2",
+					"tags": ["items"],
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+					"responses": {"200": {"description": "OK"}, "default": {"$ref_error": true}}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Item": {
+					"type": "object",
+					"properties": {
+						"id": {"type": "integer", "minimum": 0},
+						"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 3},
+						"created_at": {"type": "string", "format": "date-time"},
+						"parent": {"$ref": "#/components/schemas/Item"}
+					}
+				}
+			}
+		}
+	}`
+	errorResponse := map[string]interface{}{
+		"description": "Error",
+		"content": map[string]interface{}{"application/json": map[string]interface{}{"schema": map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"message": map[string]interface{}{"type": "string"}},
+		}}},
+	}
+	var expectedDocument map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(expected), &expectedDocument))
+	for _, item := range expectedDocument["paths"].(map[string]interface{}) {
+		for _, operation := range item.(map[string]interface{}) {
+			operation.(map[string]interface{})["responses"].(map[string]interface{})["default"] = errorResponse
+		}
+	}
+	assert.Equal(t, expectedDocument, actual)
+}
+
+func TestUI(t *testing.T) {
+	page, err := UI("Test <API>", "/openapi.json", "/docs/assets")
+
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "<title>Test &lt;API&gt;</title>", "Title should be escaped")
+	assert.Contains(t, string(page), `data-spec-url="/openapi.json"`, "Document should be loaded")
+	assert.NotContains(t, string(page), "https://", "Assets should be served by the server")
+	for _, asset := range []string{"swagger-ui.css", "docs.css", "swagger-ui-bundle.js", "docs.js"} {
+		assert.Contains(t, string(page), "/docs/assets/"+asset)
+		_, err := fs.Stat(UIAssets(), asset)
+		assert.NoError(t, err, "Asset %s should be embedded", asset)
+	}
+}
@@ -0,0 +1,184 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaName matches the characters which can't be in the name of a schema, e.g. the brackets of Page[Artist]
+var schemaName = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// schema returns the schema of a type, field has the binding rules of the value
+// The named structs are components, referenced by their name
+func (g *generator) schema(typ reflect.Type, field reflect.StructField) *Schema {
+	typ = indirect(typ)
+	var schema *Schema
+	switch {
+	case typ == fileHeaderType.Elem():
+		schema = &Schema{Type: "string", Format: "binary"}
+	case typ == timeType:
+		schema = &Schema{Type: "string", Format: "date-time"}
+	case typ == rawMessageType || typ.Kind() == reflect.Interface:
+		schema = &Schema{}
+	case reflect.PtrTo(typ).Implements(jsonMarshalerType):
+		schema = &Schema{}
+	case reflect.PtrTo(typ).Implements(textMarshalerType):
+		schema = &Schema{Type: "string"}
+	case typ.Kind() == reflect.Struct && typ.Name() != "":
+		schema = &Schema{Ref: "#/components/schemas/" + g.component(typ)}
+	case typ.Kind() == reflect.Struct:
+		schema = g.object(typ)
+	case (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) && typ.Elem().Kind() == reflect.Uint8:
+		// Bytes are a base64 string in JSON
+		schema = &Schema{Type: "string", Format: "byte"}
+	case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array:
+		schema = &Schema{Type: "array", Items: g.schema(typ.Elem(), reflect.StructField{})}
+	case typ.Kind() == reflect.Map:
+		schema = &Schema{Type: "object", AdditionalProperties: g.schema(typ.Elem(), reflect.StructField{})}
+	default:
+		schema = scalar(typ)
+	}
+	if schema.Ref == "" {
+		applyRules(schema, field)
+	}
+	return schema
+}
+
+// component adds the schema of a named struct to the components, and returns its name
+// A name already taken by another type is prefixed by the package, e.g. models.Artist
+func (g *generator) component(typ reflect.Type) string {
+	if name, ok := g.names[typ]; ok {
+		return name
+	}
+	name := schemaName.ReplaceAllString(typ.Name(), "_")
+	if _, taken := g.schemas[name]; taken {
+		name = schemaName.ReplaceAllString(typ.String(), "_")
+	}
+	g.names[typ] = name
+	// Registered before its fields, a recursive type references itself
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.object(typ)
+	return name
+}
+
+// object returns the schema of a struct, its properties are its JSON fields
+func (g *generator) object(typ reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	eachField(typ, func(field reflect.StructField) {
+		name := tagName(field, "json")
+		if name == "" {
+			if field.Tag.Get("json") == "-" {
+				return
+			}
+			name = field.Name
+		}
+		schema.Properties[name] = g.schema(field.Type, field)
+		if hasRule(field, "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	})
+	return schema
+}
+
+func scalar(typ reflect.Type) *Schema {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	default:
+		return &Schema{}
+	}
+}
+
+// rules returns the binding rules of a field which apply to its value, before dive, e.g. [required max=100]
+func rules(field reflect.StructField) []string {
+	tag := field.Tag.Get("binding")
+	if tag == "" {
+		return nil
+	}
+	var valueRules []string
+	for _, rule := range strings.Split(tag, ",") {
+		if rule == "dive" {
+			break
+		}
+		valueRules = append(valueRules, rule)
+	}
+	return valueRules
+}
+
+func hasRule(field reflect.StructField, name string) bool {
+	for _, rule := range rules(field) {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRules sets the constraints of the oneof, min and max rules of the field
+// A limit is a length for the strings, a number of items for the lists and a value for the numbers
+func applyRules(schema *Schema, field reflect.StructField) {
+	for _, rule := range rules(field) {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, enumValue(schema.Type, value))
+			}
+		case "min", "max":
+			limit, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+			length := int(limit)
+			switch {
+			case schema.Type == "string" && name == "min":
+				schema.MinLength = &length
+			case schema.Type == "string":
+				schema.MaxLength = &length
+			case schema.Type == "array" && name == "min":
+				schema.MinItems = &length
+			case schema.Type == "array":
+				schema.MaxItems = &length
+			case name == "min":
+				schema.Minimum = &limit
+			default:
+				schema.Maximum = &limit
+			}
+		}
+	}
+}
+
+// enumValue returns a value of oneof with the type of the schema, e.g. 1 for an integer
+func enumValue(schemaType, value string) interface{} {
+	if schemaType == "integer" || schemaType == "number" {
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	}
+	return value
+}
@@ -0,0 +1,36 @@
+package openapi
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"io/fs"
+)
+
+// The documentation page and its assets: Swagger UI (see ui/NOTICE), embedded so the page only loads the server
+//
+//go:embed ui
+var uiFiles embed.FS
+
+var uiTemplate = template.Must(template.ParseFS(uiFiles, "ui/index.html"))
+
+// UI returns the documentation page of the document at specURL, e.g. /openapi.json
+// Its assets (UIAssets) must be served at assetsURL, e.g. /docs/assets
+func UI(title, specURL, assetsURL string) ([]byte, error) {
+	var page bytes.Buffer
+	err := uiTemplate.Execute(&page, struct {
+		Title     string
+		SpecURL   string
+		AssetsURL string
+	}{title, specURL, assetsURL})
+	return page.Bytes(), err
+}
+
+// UIAssets returns the stylesheets and the scripts of the documentation page, Swagger UI included
+func UIAssets() fs.FS {
+	assets, err := fs.Sub(uiFiles, "ui/assets")
+	if err != nil {
+		panic(err)
+	}
+	return assets
+}
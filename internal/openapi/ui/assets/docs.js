@@ -0,0 +1,8 @@
+// Renders the document of the page with Swagger UI, swagger-ui-bundle.js is loaded before
+window.ui = SwaggerUIBundle({
+  url: document.body.dataset.specUrl,
+  dom_id: "#swagger-ui",
+  deepLinking: true,
+  // The document is not sent to the online validator, the page only loads the server
+  validatorUrl: null,
+});
@@ -0,0 +1,350 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const mediaTypeJSON = "application/json"
+
+// Mismatch is a value of a request or a response which doesn't match the document
+// Location is the parameter or the JSON pointer of the value, e.g. query limit or body/items/0/name
+type Mismatch struct {
+	Location string
+	Message  string
+}
+
+func (m *Mismatch) Error() string {
+	return m.Location + " " + m.Message
+}
+
+// Mismatches are the mismatches of a request or a response
+type Mismatches []*Mismatch
+
+func (m Mismatches) Error() string {
+	messages := make([]string, len(m))
+	for i, mismatch := range m {
+		messages[i] = mismatch.Error()
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Request is a request validated against the operation of its route
+type Request struct {
+	Method string
+	// Path of the router, e.g. /albums/:id
+	Path string
+	// URI parameters, e.g. id of /albums/:id
+	URI    map[string]string
+	Query  url.Values
+	Header http.Header
+	// Body of the request, only a JSON body is validated, a request without Content-Type has no body
+	Body []byte
+}
+
+// Validator validates the requests and the responses of the routes against the operations of a document
+// The routes which are not in the document, e.g. the documentation itself, are not validated
+type Validator struct {
+	document *Document
+}
+
+func NewValidator(document *Document) *Validator {
+	return &Validator{document: document}
+}
+
+// ValidateRequest returns the Mismatches of the parameters and the body of a request, nil if it matches
+// The query parameters of a filterable field, e.g. name[contains], are the parameter of the field
+func (v *Validator) ValidateRequest(request *Request) error {
+	operation := v.operation(request.Method, request.Path)
+	if operation == nil {
+		return nil
+	}
+
+	var mismatches Mismatches
+	for _, parameter := range operation.Parameters {
+		location := parameter.In + " " + parameter.Name
+		values := parameterValues(parameter, request)
+		if len(values) == 0 {
+			if parameter.Required {
+				mismatches = append(mismatches, &Mismatch{Location: location, Message: "is required"})
+			}
+			continue
+		}
+		schema := v.resolve(parameter.Schema)
+		if schema.Type == "array" {
+			// The values of a list are repeated or comma separated, e.g. ids=1,2
+			var items []interface{}
+			for _, value := range values {
+				for _, item := range strings.Split(value, ",") {
+					items = append(items, parameterValue(v.resolve(schema.Items), item))
+				}
+			}
+			mismatches = append(mismatches, v.validate(schema, items, location)...)
+			continue
+		}
+		for _, value := range values {
+			mismatches = append(mismatches, v.validate(schema, parameterValue(schema, value), location)...)
+		}
+	}
+
+	if operation.RequestBody != nil {
+		mismatches = append(mismatches, v.validateBody(operation.RequestBody, request)...)
+	}
+
+	if len(mismatches) != 0 {
+		return mismatches
+	}
+	return nil
+}
+
+func (v *Validator) validateBody(requestBody *RequestBody, request *Request) Mismatches {
+	contentType := request.Header.Get("Content-Type")
+	if contentType == "" {
+		if requestBody.Required {
+			return Mismatches{{Location: "body", Message: "is required"}}
+		}
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return Mismatches{{Location: "body", Message: "has an invalid media type " + contentType}}
+	}
+	content, ok := requestBody.Content[mediaType]
+	if !ok {
+		return Mismatches{{Location: "body", Message: "has an undocumented media type " + mediaType}}
+	}
+	if mediaType != mediaTypeJSON {
+		return nil
+	}
+	return v.validateJSON(content.Schema, request.Body)
+}
+
+// ValidateResponse returns the Mismatches of the response of a route, nil if it matches
+// body is the JSON body, nil if the response has none. A successful response is the 200 response
+// of the operation if its status code isn't documented, e.g. 201, and an error response is the default response
+func (v *Validator) ValidateResponse(method, path string, statusCode int, body []byte) error {
+	operation := v.operation(method, path)
+	if operation == nil {
+		return nil
+	}
+	response := operation.response(statusCode)
+	if response == nil {
+		return Mismatches{{Location: "status", Message: "has an undocumented code " + strconv.Itoa(statusCode)}}
+	}
+
+	var mismatches Mismatches
+	content, ok := response.Content[mediaTypeJSON]
+	switch {
+	case !ok && body != nil:
+		mismatches = Mismatches{{Location: "body", Message: "is not documented"}}
+	case ok && body == nil:
+		mismatches = Mismatches{{Location: "body", Message: "is required"}}
+	case ok:
+		mismatches = v.validateJSON(content.Schema, body)
+	}
+
+	if len(mismatches) != 0 {
+		return mismatches
+	}
+	return nil
+}
+
+// operation returns the operation of a route, nil if it isn't documented
+func (v *Validator) operation(method, path string) *Operation {
+	item, ok := v.document.Paths[routeParameter.ReplaceAllString(path, "{$1}")]
+	if !ok {
+		return nil
+	}
+	return (*item)[strings.ToLower(method)]
+}
+
+func (o *Operation) response(statusCode int) *Response {
+	code := strconv.Itoa(statusCode)
+	keys := []string{code, code[:1] + "XX"}
+	if statusCode >= 200 && statusCode < 300 {
+		keys = append(keys, strconv.Itoa(http.StatusOK))
+	}
+	for _, key := range append(keys, "default") {
+		if response, ok := o.Responses[key]; ok {
+			return response
+		}
+	}
+	return nil
+}
+
+// parameterValues returns the values of a parameter of a request, nil if it is missing
+func parameterValues(parameter *Parameter, request *Request) []string {
+	switch parameter.In {
+	case "path":
+		if value, ok := request.URI[parameter.Name]; ok {
+			return []string{value}
+		}
+		return nil
+	case "header":
+		return request.Header.Values(parameter.Name)
+	default:
+		values := request.Query[parameter.Name]
+		// The operators of a filterable field, their values are comma separated for the in operator
+		for key, operatorValues := range request.Query {
+			if strings.HasPrefix(key, parameter.Name+"[") {
+				for _, value := range operatorValues {
+					values = append(values, strings.Split(value, ",")...)
+				}
+			}
+		}
+		return values
+	}
+}
+
+// parameterValue returns the value of a parameter with the type of its schema, the value itself if it isn't valid
+func parameterValue(schema *Schema, value string) interface{} {
+	switch schema.Type {
+	case "integer", "number":
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+	return value
+}
+
+func (v *Validator) validateJSON(schema *Schema, body []byte) Mismatches {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return Mismatches{{Location: "body", Message: "is not valid JSON"}}
+	}
+	return v.validate(schema, value, "body")
+}
+
+// resolve returns the schema of a component referenced by a schema, the schema itself if it isn't a reference
+func (v *Validator) resolve(schema *Schema) *Schema {
+	if schema == nil {
+		return &Schema{}
+	}
+	if schema.Ref == "" {
+		return schema
+	}
+	component, ok := v.document.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	if !ok {
+		return &Schema{}
+	}
+	return component
+}
+
+// validate returns the mismatches of a JSON value with a schema
+// null is any value, the nil pointers, slices and maps of the view models are null
+func (v *Validator) validate(schema *Schema, value interface{}, location string) Mismatches {
+	schema = v.resolve(schema)
+	if value == nil || schema.Type == "" {
+		return nil
+	}
+
+	var mismatches Mismatches
+	mismatch := func(format string, args ...interface{}) {
+		mismatches = append(mismatches, &Mismatch{Location: location, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			mismatch("must be an object")
+			return mismatches
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				mismatches = append(mismatches, &Mismatch{Location: location + "/" + name, Message: "is required"})
+			}
+		}
+		// Sorted, the mismatches are in the same order
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := schema.Properties[name]
+			if !ok {
+				property = schema.AdditionalProperties
+			}
+			if property != nil {
+				mismatches = append(mismatches, v.validate(property, object[name], location+"/"+name)...)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			mismatch("must be an array")
+			return mismatches
+		}
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			mismatch("must have at least %d items", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			mismatch("must have at most %d items", *schema.MaxItems)
+		}
+		for i, item := range items {
+			mismatches = append(mismatches, v.validate(schema.Items, item, location+"/"+strconv.Itoa(i))...)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			mismatch("must be a string")
+			return mismatches
+		}
+		length := utf8.RuneCountInString(text)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			mismatch("must have at least %d characters", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			mismatch("must have at most %d characters", *schema.MaxLength)
+		}
+		if _, err := time.Parse(time.RFC3339, text); schema.Format == "date-time" && err != nil {
+			mismatch("must be a date-time")
+		}
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			mismatch("must be a number")
+			return mismatches
+		}
+		if schema.Type == "integer" && number != math.Trunc(number) {
+			mismatch("must be an integer")
+			return mismatches
+		}
+		if schema.Minimum != nil && number < *schema.Minimum {
+			mismatch("must be at least %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			mismatch("must be at most %v", *schema.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			mismatch("must be a boolean")
+			return mismatches
+		}
+	}
+
+	if len(schema.Enum) != 0 {
+		found := false
+		for _, enum := range schema.Enum {
+			found = found || enum == value
+		}
+		if !found {
+			mismatch("must be one of %v", schema.Enum)
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,187 @@
+package openapi
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type getResponse struct {
+	Body *Item
+}
+
+type statusResponse struct{}
+
+func testValidator() *Validator {
+	return NewValidator(Generate(&Options{
+		MediaTypes: []string{"application/json", "application/yaml"},
+		Error:      reflect.TypeOf(errorResponse{}),
+	}, []Route{
+		{Method: http.MethodGet, Path: "/items", Request: reflect.TypeOf(&listRequest{}), Response: reflect.TypeOf(&listResponse{})},
+		{Method: http.MethodGet, Path: "/items/:id", Response: reflect.TypeOf(&getResponse{})},
+		{Method: http.MethodPut, Path: "/items/:id", Request: reflect.TypeOf(&updateRequest{}), Response: reflect.TypeOf(&statusResponse{})},
+		{Method: http.MethodPost, Path: "/items/upload", Request: reflect.TypeOf(&uploadRequest{})},
+	}))
+}
+
+func TestValidateRequest(t *testing.T) {
+	tests := map[string]struct {
+		request  *Request
+		expected string
+	}{
+		"Valid parameters": {
+			request: &Request{
+				Method: http.MethodGet, Path: "/items",
+				Query:  url.Values{"limit": {"10"}, "name[contains]": {"Em"}, "status": {"draft"}},
+				Header: http.Header{"X-Tenant": {"tenant"}},
+			},
+		},
+		"Invalid parameters": {
+			request: &Request{
+				Method: http.MethodGet, Path: "/items",
+				Query: url.Values{"limit": {"1000"}, "status": {"deleted"}},
+			},
+			expected: "query limit must be at most 100, query status must be one of [draft published], header X-Tenant is required",
+		},
+		"Parameter of the wrong type": {
+			request: &Request{
+				Method: http.MethodGet, Path: "/items",
+				Query:  url.Values{"limit": {"ten"}},
+				Header: http.Header{"X-Tenant": {"tenant"}},
+			},
+			expected: "query limit must be a number",
+		},
+		"Valid body": {
+			request: &Request{
+				Method: http.MethodPut, Path: "/items/:id", URI: map[string]string{"id": "1"},
+				Header: http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+				Body:   []byte(`{"name": "Eminem", "unknown": true}`),
+			},
+		},
+		"Invalid body": {
+			request: &Request{
+				Method: http.MethodPut, Path: "/items/:id", URI: map[string]string{"id": "-1"},
+				Header: http.Header{"Content-Type": {"application/json"}},
+				Body:   []byte(`{"Ignored": 1}`),
+			},
+			expected: "path id must be at least 0, body/name is required, body/Ignored must be a string",
+		},
+		"Body which isn't JSON": {
+			request: &Request{
+				Method: http.MethodPut, Path: "/items/:id", URI: map[string]string{"id": "1"},
+				Header: http.Header{"Content-Type": {"application/json"}},
+				Body:   []byte(`{"name":`),
+			},
+			expected: "body is not valid JSON",
+		},
+		"Missing body": {
+			request:  &Request{Method: http.MethodPut, Path: "/items/:id", URI: map[string]string{"id": "1"}},
+			expected: "body is required",
+		},
+		"Body of another documented media type": {
+			request: &Request{
+				Method: http.MethodPut, Path: "/items/:id", URI: map[string]string{"id": "1"},
+				Header: http.Header{"Content-Type": {"application/yaml"}},
+				Body:   []byte(`name: 1`),
+			},
+		},
+		"Body of an undocumented media type": {
+			request: &Request{
+				Method: http.MethodPut, Path: "/items/:id", URI: map[string]string{"id": "1"},
+				Header: http.Header{"Content-Type": {"text/plain"}},
+				Body:   []byte(`Eminem`),
+			},
+			expected: "body has an undocumented media type text/plain",
+		},
+		"Multipart form": {
+			request: &Request{
+				Method: http.MethodPost, Path: "/items/upload",
+				Header: http.Header{"Content-Type": {"multipart/form-data; boundary=boundary"}},
+			},
+		},
+		"Undocumented route": {
+			request: &Request{Method: http.MethodDelete, Path: "/items/:id", URI: map[string]string{"id": "item"}},
+		},
+	}
+
+	validator := testValidator()
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if test.request.Header == nil {
+				test.request.Header = http.Header{}
+			}
+
+			err := validator.ValidateRequest(test.request)
+
+			if test.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	tests := map[string]struct {
+		method     string
+		path       string
+		statusCode int
+		body       string
+		expected   string
+	}{
+		"Valid response": {
+			method: http.MethodGet, path: "/items/:id", statusCode: http.StatusOK,
+			body: `{"id": 1, "tags": ["rap"], "created_at": "2023-01-01T00:00:00Z", "parent": {"id": 2, "tags": null, "created_at": "2023-01-01T00:00:00Z"}}`,
+		},
+		"Invalid response": {
+			method: http.MethodGet, path: "/items", statusCode: http.StatusOK,
+			body:     `{"items": [{"id": 1.5, "tags": ["a", "b", "c", 4], "created_at": "yesterday"}]}`,
+			expected: "body/items/0/created_at must be a date-time, body/items/0/id must be an integer, body/items/0/tags must have at most 3 items, body/items/0/tags/3 must be a string",
+		},
+		"Undocumented success status code": {
+			method: http.MethodGet, path: "/items/:id", statusCode: http.StatusCreated,
+			body: `{"id": "1"}`, expected: "body/id must be a number",
+		},
+		"Error response": {
+			method: http.MethodGet, path: "/items/:id", statusCode: http.StatusConflict,
+			body: `{"message": "conflict"}`,
+		},
+		"Response without body": {
+			method: http.MethodPut, path: "/items/:id", statusCode: http.StatusNoContent,
+		},
+		"Undocumented body": {
+			method: http.MethodPut, path: "/items/:id", statusCode: http.StatusOK,
+			body: `{}`, expected: "body is not documented",
+		},
+		"Missing body": {
+			method: http.MethodGet, path: "/items/:id", statusCode: http.StatusOK,
+			expected: "body is required",
+		},
+		"Undocumented route": {
+			method: http.MethodGet, path: "/unknown", statusCode: http.StatusOK,
+			body: `{}`,
+		},
+	}
+
+	validator := testValidator()
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var body []byte
+			if test.body != "" {
+				body = []byte(test.body)
+			}
+
+			err := validator.ValidateResponse(test.method, test.path, test.statusCode, body)
+
+			if test.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrInvalidCursor is returned for a cursor which was not signed by the server
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position of a page in a keyset pagination
+// It is sent to the clients signed, so they can't forge a position
+type Cursor struct {
+	// Values of the sort columns, then of the primary key, of the row before the page
+	Values []json.RawMessage `json:"v"`
+	// Backward is true for the page before the row
+	Backward bool `json:"b,omitempty"`
+	// Sort of the pagination, see Params.sortKey
+	Sort string `json:"s"`
+}
+
+// cursorSecret is PAGINATION_SECRET, or JWT_SECRET if not defined
+func cursorSecret() []byte {
+	if secret := viper.GetString("PAGINATION_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte(viper.GetString("JWT_SECRET"))
+}
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, cursorSecret())
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// EncodeCursor returns the opaque and signed representation of the cursor
+func EncodeCursor(cursor *Cursor) (string, error) {
+	content, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(content)
+	return payload + "." + sign(payload), nil
+}
+
+// DecodeCursor returns the cursor of its representation, ErrInvalidCursor if it is not signed by the server
+func DecodeCursor(value string) (*Cursor, error) {
+	payload, signature, found := strings.Cut(value, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(sign(payload))) {
+		return nil, ErrInvalidCursor
+	}
+	content, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	cursor := &Cursor{}
+	err = json.Unmarshal(content, cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
@@ -0,0 +1,57 @@
+package pagination
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	cursor := &Cursor{Values: []json.RawMessage{[]byte(`"2024-01-02T03:04:05Z"`), []byte(`7`)}, Backward: true, Sort: "-created_at,id"}
+	encoded, err := EncodeCursor(cursor)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		value    string
+		setup    func()
+		expected *Cursor
+	}{
+		"Signed cursor": {
+			value:    encoded,
+			expected: cursor,
+		},
+		"Forged payload": {
+			value: "eyJ2IjpbIjEiXSwicyI6ImlkIn0." + encoded[len(encoded)-43:],
+		},
+		"Missing signature": {
+			value: encoded[:len(encoded)-44],
+		},
+		"Other secret": {
+			value: encoded,
+			setup: func() {
+				viper.Set("PAGINATION_SECRET", "other")
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if test.setup != nil {
+				test.setup()
+				defer viper.Set("PAGINATION_SECRET", "")
+			}
+
+			result, err := DecodeCursor(test.value)
+
+			if test.expected == nil {
+				assert.ErrorIs(t, err, ErrInvalidCursor, "Cursor should be refused")
+				return
+			}
+			require.NoError(t, err, "No error should have occurred")
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
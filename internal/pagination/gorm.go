@@ -0,0 +1,185 @@
+package pagination
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Find returns the page of the query, T is the model of the query (e.g. *models.Artist)
+// The primary key is added to the sort, so the order of the rows is total
+// - with a page number, the rows are found by offset and counted
+// - otherwise, the rows after (or before) the cursor are found by keyset, they are not counted
+func Find[T any](query *gorm.DB, params *Params) (*Page[T], error) {
+	stmt := &gorm.Statement{DB: query}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, err
+	}
+	orders, err := sortFields(stmt.Schema, params.Sort)
+	if err != nil {
+		return nil, err
+	}
+	query = query.Session(&gorm.Session{})
+
+	if params.Page > 0 {
+		return findOffset[T](query, params, orders)
+	}
+	return findKeyset[T](query, params, orders)
+}
+
+// sortField is an order of the pagination, with the field of its column
+type sortField struct {
+	Order
+	field *schema.Field
+}
+
+// sortFields returns the fields of the sort, followed by the primary key
+func sortFields(s *schema.Schema, sort []Order) ([]sortField, error) {
+	fields := make([]sortField, 0, len(sort)+1)
+	for _, order := range sort {
+		field := s.LookUpField(order.Column)
+		if field == nil {
+			return nil, fmt.Errorf("pagination: unknown column %s of %s", order.Column, s.Table)
+		}
+		fields = append(fields, sortField{order, field})
+		if field == s.PrioritizedPrimaryField {
+			return fields, nil
+		}
+	}
+	if s.PrioritizedPrimaryField == nil {
+		return nil, fmt.Errorf("pagination: %s has no primary key", s.Table)
+	}
+	return append(fields, sortField{Order{Column: s.PrioritizedPrimaryField.DBName}, s.PrioritizedPrimaryField}), nil
+}
+
+func column(field sortField) clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: field.field.DBName}
+}
+
+func findOffset[T any](query *gorm.DB, params *Params, orders []sortField) (*Page[T], error) {
+	var total int64
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, order := range orders {
+		query = query.Order(clause.OrderByColumn{Column: column(order), Desc: order.Desc})
+	}
+	items := []T{}
+	err = query.Offset((params.Page - 1) * params.Limit).Limit(params.Limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[T]{Items: items, Info: Info{Limit: params.Limit, Number: params.Page, Total: &total}}
+	if int64(params.Page*params.Limit) < total {
+		page.NextNumber = params.Page + 1
+	}
+	if params.Page > 1 {
+		page.PrevNumber = params.Page - 1
+	}
+	return page, nil
+}
+
+func findKeyset[T any](query *gorm.DB, params *Params, orders []sortField) (*Page[T], error) {
+	backward := params.Cursor != nil && params.Cursor.Backward
+	if params.Cursor != nil {
+		condition, err := keysetCondition(orders, params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition)
+	}
+
+	// A backward page is found in the reverse order, then reversed
+	for _, order := range orders {
+		query = query.Order(clause.OrderByColumn{Column: column(order), Desc: order.Desc != backward})
+	}
+	// One more row tells if there is a page after
+	items := []T{}
+	err := query.Limit(params.Limit + 1).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	more := len(items) > params.Limit
+	if more {
+		items = items[:params.Limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	page := &Page[T]{Items: items, Info: Info{Limit: params.Limit}}
+	if len(items) == 0 {
+		return page, nil
+	}
+	sortKey := params.sortKey()
+	// There is a next page if there are more rows forward, or if the page was reached backward
+	if more || backward {
+		page.NextCursor, err = rowCursor(query, orders, items[len(items)-1], false, sortKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// There is a previous page if there are more rows backward, or if the page was reached forward by a cursor
+	if (more && backward) || (params.Cursor != nil && !backward) {
+		page.PrevCursor, err = rowCursor(query, orders, items[0], true, sortKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// keysetCondition selects the rows after (or before) the cursor in the sort order:
+// (a > ?) OR (a = ? AND b > ?) OR (a = ? AND b = ? AND id > ?)
+func keysetCondition(orders []sortField, cursor *Cursor) (clause.Expression, error) {
+	if len(cursor.Values) != len(orders) {
+		return nil, ErrInvalidCursor
+	}
+	values := make([]interface{}, len(orders))
+	for i, order := range orders {
+		value := reflect.New(order.field.FieldType)
+		if err := json.Unmarshal(cursor.Values[i], value.Interface()); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		values[i] = value.Elem().Interface()
+	}
+
+	alternatives := make([]clause.Expression, 0, len(orders))
+	for i, order := range orders {
+		conditions := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, clause.Eq{Column: column(orders[j]), Value: values[j]})
+		}
+		if order.Desc != cursor.Backward {
+			conditions = append(conditions, clause.Lt{Column: column(order), Value: values[i]})
+		} else {
+			conditions = append(conditions, clause.Gt{Column: column(order), Value: values[i]})
+		}
+		alternatives = append(alternatives, clause.And(conditions...))
+	}
+	return clause.Or(alternatives...), nil
+}
+
+// rowCursor returns the cursor of the page after (or before) the row
+func rowCursor(query *gorm.DB, orders []sortField, row interface{}, backward bool, sortKey string) (string, error) {
+	value := reflect.Indirect(reflect.ValueOf(row))
+	cursor := &Cursor{Values: make([]json.RawMessage, 0, len(orders)), Backward: backward, Sort: sortKey}
+	for _, order := range orders {
+		fieldValue, _ := order.field.ValueOf(query.Statement.Context, value)
+		content, err := json.Marshal(fieldValue)
+		if err != nil {
+			return "", err
+		}
+		cursor.Values = append(cursor.Values, content)
+	}
+	return EncodeCursor(cursor)
+}
@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Page is a page of items
+type Page[T any] struct {
+	Items []T
+	Info
+}
+
+// Info describes a page and the pages around it
+type Info struct {
+	Limit int
+	// Cursors of the next and previous pages of a keyset pagination, empty if there is none
+	NextCursor string
+	PrevCursor string
+	// Numbers of the page, and of the next and previous pages, of an offset pagination, 0 if there is none
+	Number     int
+	NextNumber int
+	PrevNumber int
+	// Total number of items, nil if it was not counted
+	Total *int64
+}
+
+// Envelope is the pagination of a list response
+type Envelope struct {
+	// The maximum number of items of the page.
+	// Required: true
+	Limit int `json:"limit"`
+
+	// The link to the next page, missing on the last page.
+	Next string `json:"next,omitempty"`
+
+	// The link to the previous page, missing on the first page.
+	Prev string `json:"prev,omitempty"`
+
+	// The page number, with offset pagination.
+	Page int `json:"page,omitempty"`
+
+	// The total number of items, with offset pagination.
+	Total *int64 `json:"total,omitempty"`
+}
+
+// NewEnvelope returns the envelope of the page, its links are the request URL with the cursor or page of the next and previous pages
+func NewEnvelope(requestURL *url.URL, info Info) Envelope {
+	envelope := Envelope{Limit: info.Limit, Page: info.Number, Total: info.Total}
+	switch {
+	case info.Number != 0:
+		envelope.Next = link(requestURL, "page", info.NextNumber != 0, strconv.Itoa(info.NextNumber))
+		envelope.Prev = link(requestURL, "page", info.PrevNumber != 0, strconv.Itoa(info.PrevNumber))
+	default:
+		envelope.Next = link(requestURL, "cursor", info.NextCursor != "", info.NextCursor)
+		envelope.Prev = link(requestURL, "cursor", info.PrevCursor != "", info.PrevCursor)
+	}
+	return envelope
+}
+
+// link returns the path and query of the request URL with the parameter set to value, empty if the link doesn't exist
+func link(requestURL *url.URL, parameter string, exists bool, value string) string {
+	if !exists {
+		return ""
+	}
+	query := requestURL.Query()
+	query.Del("page")
+	query.Del("cursor")
+	query.Set(parameter, value)
+	return (&url.URL{Path: requestURL.Path, RawQuery: query.Encode()}).String()
+}
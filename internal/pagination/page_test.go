@@ -0,0 +1,49 @@
+package pagination
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnvelope(t *testing.T) {
+	requestURL, err := url.Parse("/albums/?limit=2&sort=-name&cursor=abc")
+	require.NoError(t, err)
+	total := int64(7)
+
+	tests := map[string]struct {
+		info     Info
+		expected Envelope
+	}{
+		"Cursors": {
+			info: Info{Limit: 2, NextCursor: "next", PrevCursor: "prev"},
+			expected: Envelope{
+				Limit: 2,
+				Next:  "/albums/?cursor=next&limit=2&sort=-name",
+				Prev:  "/albums/?cursor=prev&limit=2&sort=-name",
+			},
+		},
+		"Last page": {
+			info:     Info{Limit: 2, PrevCursor: "prev"},
+			expected: Envelope{Limit: 2, Prev: "/albums/?cursor=prev&limit=2&sort=-name"},
+		},
+		"Page numbers": {
+			info: Info{Limit: 2, Number: 2, NextNumber: 3, PrevNumber: 1, Total: &total},
+			expected: Envelope{
+				Limit: 2,
+				Next:  "/albums/?limit=2&page=3&sort=-name",
+				Prev:  "/albums/?limit=2&page=1&sort=-name",
+				Page:  2,
+				Total: &total,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, NewEnvelope(requestURL, test.info))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package pagination
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Limits of the number of items of a page
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Request is embedded in the request view models of list endpoints
+// The limit, page and cursor rules are checked by the binding, the sort and the cursor by Params
+type Request struct {
+	// The maximum number of items, 20 by default.
+	// in:query
+	Limit int `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+
+	// The cursor of the page, from the next or prev link of the previous page.
+	// in:query
+	Cursor string `json:"cursor" form:"cursor" binding:"omitempty,excluded_with=Page"`
+
+	// The page number, starting at 1, for offset pagination instead of cursors.
+	// in:query
+	Page int `json:"page" form:"page" binding:"omitempty,min=1"`
+
+	// The sort fields separated by commas, prefixed by - for a descending order, e.g. -created_at,name.
+	// in:query
+	Sort string `json:"sort" form:"sort"`
+}
+
+// Sortable maps the sort fields of an endpoint to their column
+// Sort columns must not be nullable: NULL values can't be compared by cursors
+type Sortable map[string]string
+
+// Params are the validated pagination of a list request
+type Params struct {
+	Limit int
+	// Page number of an offset pagination, 0 for a cursor pagination
+	Page int
+	// Cursor of the page, nil for the first page
+	Cursor *Cursor
+	Sort   []Order
+}
+
+// Order is a sort column
+type Order struct {
+	Column string
+	Desc   bool
+}
+
+// FieldError is an invalid pagination field
+// Tag identifies the rule, like a validation tag, and Param is its parameter
+type FieldError struct {
+	Field string
+	Tag   string
+	Param string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid %s: %s %s", e.Field, e.Tag, e.Param)
+}
+
+// Tags of the field errors
+const (
+	TagSort     = "sort"
+	TagSortable = "sortable"
+	TagCursor   = "cursor"
+)
+
+var sortPattern = regexp.MustCompile(`^-?[a-z_]+(,-?[a-z_]+)*$`)
+
+// Params validates the request, sortable are the sort fields of the endpoint
+// and defaultSort is the sort of a request without one
+func (r *Request) Params(sortable Sortable, defaultSort string) (*Params, error) {
+	params := &Params{Limit: r.Limit, Page: r.Page}
+	if params.Limit == 0 {
+		params.Limit = DefaultLimit
+	}
+
+	sortValue := r.Sort
+	if sortValue == "" {
+		sortValue = defaultSort
+	}
+	if !sortPattern.MatchString(sortValue) {
+		return nil, &FieldError{Field: "sort", Tag: TagSort}
+	}
+	for _, field := range strings.Split(sortValue, ",") {
+		name := strings.TrimPrefix(field, "-")
+		column, ok := sortable[name]
+		if !ok {
+			return nil, &FieldError{Field: "sort", Tag: TagSortable, Param: sortableFields(sortable)}
+		}
+		params.Sort = append(params.Sort, Order{Column: column, Desc: name != field})
+	}
+
+	if r.Cursor != "" {
+		cursor, err := DecodeCursor(r.Cursor)
+		// A cursor is only valid with the sort of its pages
+		if err != nil || cursor.Sort != params.sortKey() {
+			return nil, &FieldError{Field: "cursor", Tag: TagCursor}
+		}
+		params.Cursor = cursor
+	}
+	return params, nil
+}
+
+// sortKey identifies the sort of the params in cursors
+func (p *Params) sortKey() string {
+	fields := make([]string, 0, len(p.Sort))
+	for _, order := range p.Sort {
+		if order.Desc {
+			fields = append(fields, "-"+order.Column)
+		} else {
+			fields = append(fields, order.Column)
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+func sortableFields(sortable Sortable) string {
+	fields := make([]string, 0, len(sortable))
+	for field := range sortable {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
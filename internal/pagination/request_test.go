@@ -0,0 +1,75 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParams(t *testing.T) {
+	sortable := Sortable{"name": "name", "created_at": "created_at"}
+	cursor, err := EncodeCursor(&Cursor{Values: []json.RawMessage{[]byte(`"Eminem"`), []byte(`1`)}, Sort: "name"})
+	require.NoError(t, err)
+	otherSortCursor, err := EncodeCursor(&Cursor{Values: []json.RawMessage{[]byte(`1`)}, Sort: "-created_at"})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		request       *Request
+		expected      *Params
+		expectedError *FieldError
+	}{
+		"Defaults": {
+			request:  &Request{},
+			expected: &Params{Limit: DefaultLimit, Sort: []Order{{Column: "name"}}},
+		},
+		"Sort": {
+			request:  &Request{Limit: 5, Sort: "-created_at,name"},
+			expected: &Params{Limit: 5, Sort: []Order{{Column: "created_at", Desc: true}, {Column: "name"}}},
+		},
+		"Page": {
+			request:  &Request{Page: 3},
+			expected: &Params{Limit: DefaultLimit, Page: 3, Sort: []Order{{Column: "name"}}},
+		},
+		"Cursor": {
+			request: &Request{Cursor: cursor},
+			expected: &Params{Limit: DefaultLimit, Sort: []Order{{Column: "name"}}, Cursor: &Cursor{
+				Values: []json.RawMessage{[]byte(`"Eminem"`), []byte(`1`)},
+				Sort:   "name",
+			}},
+		},
+		"Invalid sort": {
+			request:       &Request{Sort: "name,,id"},
+			expectedError: &FieldError{Field: "sort", Tag: TagSort},
+		},
+		"Unknown sort field": {
+			request:       &Request{Sort: "-password"},
+			expectedError: &FieldError{Field: "sort", Tag: TagSortable, Param: "created_at, name"},
+		},
+		"Forged cursor": {
+			request:       &Request{Cursor: cursor[:len(cursor)-1]},
+			expectedError: &FieldError{Field: "cursor", Tag: TagCursor},
+		},
+		"Cursor of another sort": {
+			request:       &Request{Cursor: otherSortCursor},
+			expectedError: &FieldError{Field: "cursor", Tag: TagCursor},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			params, err := test.request.Params(sortable, "name")
+
+			if test.expectedError != nil {
+				var fieldError *FieldError
+				require.True(t, errors.As(err, &fieldError), "Error should be a field error")
+				assert.Equal(t, test.expectedError, fieldError)
+				return
+			}
+			require.NoError(t, err, "No error should have occurred")
+			assert.Equal(t, test.expected, params)
+		})
+	}
+}
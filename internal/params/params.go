@@ -0,0 +1,235 @@
+package params
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tags of the fields bound from the parameters, their value is the parameter name
+const (
+	TagURI    = "uri"
+	TagQuery  = "query"
+	TagHeader = "header"
+)
+
+// TagValue is the tag of the field errors, the value can't be parsed to the type of the field
+const TagValue = "param_value"
+
+// Request holds the parameters of a request
+type Request struct {
+	// URI parameters, e.g. id of /albums/:id
+	URI    map[string]string
+	Query  url.Values
+	Header http.Header
+}
+
+// values returns the values of a parameter, nil if it is missing
+func (r *Request) values(tag, name string) []string {
+	switch tag {
+	case TagURI:
+		if value, ok := r.URI[name]; ok {
+			return []string{value}
+		}
+	case TagQuery:
+		return r.Query[name]
+	case TagHeader:
+		return r.Header.Values(name)
+	}
+	return nil
+}
+
+// FieldError is a parameter which can't be parsed, like a validation error
+// Field is the parameter name, Tag identifies the rule and Param is the expected type
+type FieldError struct {
+	Field string
+	Tag   string
+	Param string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid parameter %s: %s %s", e.Field, e.Tag, e.Param)
+}
+
+// Errors are the field errors of the parameters of a request
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Error()
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Layouts of the dates of the parameters
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02"}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// Bind sets the fields of the view model tagged with uri, query or header from the parameters of the request
+// The fields of the embedded structs are bound too. A missing parameter leaves its field unchanged
+// The values of a slice are the repeated parameters, each one split by commas, e.g. ?id=1,2&id=3
+// A pointer is allocated if its parameter is present, and a TextUnmarshaler parses its own value
+// All the fields are bound, Errors has the fields which can't be parsed
+func Bind(request *Request, viewmodel interface{}) error {
+	value := reflect.ValueOf(viewmodel)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	var errs Errors
+	bindStruct(request, value.Elem(), &errs)
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+func bindStruct(request *Request, value reflect.Value, errs *Errors) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		bound := false
+		for _, tag := range []string{TagURI, TagQuery, TagHeader} {
+			name := field.Tag.Get(tag)
+			if name == "" || name == "-" {
+				continue
+			}
+			bound = true
+			values := request.values(tag, name)
+			if values == nil {
+				continue
+			}
+			if err := setValue(value.Field(i), values); err != nil {
+				*errs = append(*errs, &FieldError{Field: name, Tag: TagValue, Param: typeName(field.Type)})
+			}
+			break
+		}
+		if !bound && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			bindStruct(request, value.Field(i), errs)
+		}
+	}
+}
+
+// setValue parses the values to the field
+func setValue(field reflect.Value, values []string) error {
+	typ := field.Type()
+	switch {
+	case typ.Kind() == reflect.Ptr:
+		elem := reflect.New(typ.Elem())
+		if err := setValue(elem.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	case typ == timeType:
+		// Before the TextUnmarshaler of time.Time, which only parses RFC 3339
+		for _, layout := range timeLayouts {
+			parsed, err := time.Parse(layout, values[0])
+			if err == nil {
+				field.Set(reflect.ValueOf(parsed))
+				return nil
+			}
+		}
+		return errors.New("invalid date")
+	case reflect.PtrTo(typ).Implements(textUnmarshalerType):
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(values[0]))
+	case typ.Kind() == reflect.Slice:
+		var items []string
+		for _, value := range values {
+			items = append(items, strings.Split(value, ",")...)
+		}
+		slice := reflect.MakeSlice(typ, len(items), len(items))
+		for i, item := range items {
+			if err := setValue(slice.Index(i), []string{strings.TrimSpace(item)}); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	default:
+		return setScalar(field, values[0])
+	}
+}
+
+func setScalar(field reflect.Value, value string) error {
+	typ := field.Type()
+	if typ == durationType {
+		parsed, err := time.ParseDuration(value)
+		if err == nil {
+			field.SetInt(int64(parsed))
+		}
+		return err
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported type %s", typ)
+	}
+	return nil
+}
+
+// typeName returns the name of the type of a field in the errors, e.g. integer for []*int
+func typeName(typ reflect.Type) string {
+	for typ.Kind() == reflect.Ptr || (typ.Kind() == reflect.Slice && !reflect.PtrTo(typ).Implements(textUnmarshalerType)) {
+		typ = typ.Elem()
+	}
+	switch {
+	case typ == timeType:
+		return "date"
+	case typ == durationType:
+		return "duration"
+	case reflect.PtrTo(typ).Implements(textUnmarshalerType):
+		return typ.Name()
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "positive integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return typ.String()
+	}
+}
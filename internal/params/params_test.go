@@ -0,0 +1,134 @@
+package params
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upper is a custom TextUnmarshaler
+type upper string
+
+func (u *upper) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		return errors.New("empty")
+	}
+	*u = upper(strings.ToUpper(string(text)))
+	return nil
+}
+
+type Page struct {
+	Limit int `query:"limit"`
+}
+
+type paramsRequest struct {
+	Page
+	ID       uint          `uri:"id"`
+	Int8     int8          `query:"int8"`
+	Uint16   uint16        `query:"uint16"`
+	Float    float32       `query:"float"`
+	Bool     bool          `query:"bool"`
+	Name     *string       `query:"name"`
+	Since    time.Time     `query:"since"`
+	Timeout  time.Duration `query:"timeout"`
+	IDs      []int         `query:"ids"`
+	Pointers []*uint       `query:"pointers"`
+	Version  *UUID         `header:"X-Version"`
+	Mode     upper         `header:"X-Mode"`
+	Body     string        `json:"body"`
+	Ignored  string        `query:"-"`
+}
+
+func TestBind(t *testing.T) {
+	name := "Eminem"
+	two := uint(2)
+	version := UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
+	tests := map[string]struct {
+		uri            map[string]string
+		query          string
+		header         http.Header
+		expected       *paramsRequest
+		expectedErrors Errors
+	}{
+		"All kinds": {
+			uri:   map[string]string{"id": "4"},
+			query: "limit=10&int8=-8&uint16=16&float=1.5&bool=true&name=Eminem&since=2024-01-02&timeout=1m30s&ids=1,2&ids=3&pointers=2&Ignored=a",
+			header: http.Header{
+				"X-Version": {"{123E4567-E89B-12D3-A456-426614174000}"},
+				"X-Mode":    {"fast"},
+			},
+			expected: &paramsRequest{
+				Page:     Page{Limit: 10},
+				ID:       4,
+				Int8:     -8,
+				Uint16:   16,
+				Float:    1.5,
+				Bool:     true,
+				Name:     &name,
+				Since:    time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				Timeout:  90 * time.Second,
+				IDs:      []int{1, 2, 3},
+				Pointers: []*uint{&two},
+				Version:  &version,
+				Mode:     "FAST",
+			},
+		},
+		"Missing parameters": {
+			expected: &paramsRequest{},
+		},
+		"Date and time": {
+			query:    "since=2024-01-02T10:00:00.5Z",
+			expected: &paramsRequest{Since: time.Date(2024, 1, 2, 10, 0, 0, 500000000, time.UTC)},
+		},
+		"Invalid values": {
+			uri:    map[string]string{"id": "-1"},
+			query:  "limit=a&int8=128&bool=yes&since=yesterday&ids=1,a",
+			header: http.Header{"X-Version": {"v2"}, "X-Mode": {""}},
+			expectedErrors: Errors{
+				{Field: "limit", Tag: TagValue, Param: "integer"},
+				{Field: "id", Tag: TagValue, Param: "positive integer"},
+				{Field: "int8", Tag: TagValue, Param: "integer"},
+				{Field: "bool", Tag: TagValue, Param: "boolean"},
+				{Field: "since", Tag: TagValue, Param: "date"},
+				{Field: "ids", Tag: TagValue, Param: "integer"},
+				{Field: "X-Version", Tag: TagValue, Param: "UUID"},
+				{Field: "X-Mode", Tag: TagValue, Param: "upper"},
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			require.NoError(t, err)
+			request := &paramsRequest{}
+
+			err = Bind(&Request{URI: test.uri, Query: query, Header: test.header}, request)
+
+			if test.expectedErrors != nil {
+				var errs Errors
+				require.True(t, errors.As(err, &errs), "Error should be the field errors")
+				assert.Equal(t, test.expectedErrors, errs)
+				return
+			}
+			require.NoError(t, err, "No error should have occurred")
+			assert.Equal(t, test.expected, request)
+		})
+	}
+}
+
+func TestUUID(t *testing.T) {
+	var id UUID
+
+	require.NoError(t, id.UnmarshalText([]byte("123E4567-E89B-12D3-A456-426614174000")))
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", id.String(), "UUID should be canonical")
+	assert.Error(t, id.UnmarshalText([]byte("123e4567e89b12d3a456426614174000")), "UUID without hyphens should be refused")
+	assert.Error(t, id.UnmarshalText([]byte("123e4567-e89b-12d3-a456-42661417400g")), "UUID with a non hex digit should be refused")
+}
@@ -0,0 +1,34 @@
+package params
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// UUID is a parameter in the canonical UUID form, e.g. 123e4567-e89b-12d3-a456-426614174000
+// The upper case digits and the braces of the Microsoft form are accepted
+type UUID [16]byte
+
+var errUUID = errors.New("invalid UUID")
+
+func (u *UUID) UnmarshalText(text []byte) error {
+	value := strings.TrimSuffix(strings.TrimPrefix(string(text), "{"), "}")
+	if len(value) != 36 || value[8] != '-' || value[13] != '-' || value[18] != '-' || value[23] != '-' {
+		return errUUID
+	}
+	digits := value[0:8] + value[9:13] + value[14:18] + value[19:23] + value[24:36]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return errUUID
+	}
+	return nil
+}
+
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u UUID) String() string {
+	digits := hex.EncodeToString(u[:])
+	return digits[0:8] + "-" + digits[8:12] + "-" + digits[12:16] + "-" + digits[16:20] + "-" + digits[20:32]
+}
@@ -1,38 +1,248 @@
 package repositories
 
 import (
+	"context"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/filter"
 	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ArtistRepositoryInterface interface {
-	GetByID(id uint) (*models.Artist, error)
-	Create(artist *models.Artist) error
-	Update(artist *models.Artist) error
-	Delete(id uint) error
+	GetByID(ctx context.Context, id uint) (*models.Artist, error)
+	GetWithRelations(ctx context.Context, id uint, preloads []string) (*models.Artist, error)
+	List(ctx context.Context, filters []filter.Condition, params *pagination.Params, preloads []string) (*pagination.Page[*models.Artist], error)
+	Create(ctx context.Context, artist *models.Artist) error
+	Update(ctx context.Context, artist *models.Artist) error
+	Delete(ctx context.Context, id uint, version uint) error
+	CountDependents(ctx context.Context, id uint) (*dto.ArtistDependents, error)
+	DeleteAlbums(ctx context.Context, id uint) ([]*models.Album, []*models.UserAlbum, error)
+	ReassignAlbums(ctx context.Context, id, toID uint) (before, after []*models.Album, err error)
+	GetByName(ctx context.Context, name string) (*models.Artist, error)
+	AddAlbums(ctx context.Context, id uint, names []string) ([]*models.Album, error)
+	FindInBatches(ctx context.Context, batchSize int, fn func(artists []*models.Artist) error) error
 }
 
 type ArtistRepository struct {
 	DB *gorm.DB
 }
 
-func (rpt *ArtistRepository) GetByID(id uint) (*models.Artist, error) {
+func (rpt *ArtistRepository) GetByID(ctx context.Context, id uint) (*models.Artist, error) {
 	var artist models.Artist
-	err := rpt.DB.Where("id = ?", id).First(&artist).Error
+	err := rpt.DB.WithContext(ctx).Where("id = ?", id).First(&artist).Error
 	if err != nil {
 		return nil, err
 	}
 	return &artist, nil
 }
 
-func (rpt *ArtistRepository) Create(artist *models.Artist) error {
-	return rpt.DB.Create(artist).Error
+// GetWithRelations returns the artist with the preloaded relations, e.g. Albums
+func (rpt *ArtistRepository) GetWithRelations(ctx context.Context, id uint, preloads []string) (*models.Artist, error) {
+	var artist models.Artist
+	err := withPreloads(rpt.DB.WithContext(ctx), preloads).Where("id = ?", id).First(&artist).Error
+	if err != nil {
+		return nil, err
+	}
+	return &artist, nil
+}
+
+// withPreloads preloads the relations of the query, e.g. Albums, ordered by id
+func withPreloads(query *gorm.DB, preloads []string) *gorm.DB {
+	for _, preload := range preloads {
+		query = query.Preload(preload, func(db *gorm.DB) *gorm.DB {
+			return db.Order(clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: "id"}})
+		})
+	}
+	return query
+}
+
+// List returns a page of the artists matching the filters, with the preloaded relations
+func (rpt *ArtistRepository) List(ctx context.Context, filters []filter.Condition, params *pagination.Params, preloads []string) (*pagination.Page[*models.Artist], error) {
+	query, err := filter.Apply(withPreloads(rpt.DB.WithContext(ctx).Model(&models.Artist{}), preloads), filters)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.Find[*models.Artist](query, params)
+}
+
+func (rpt *ArtistRepository) Create(ctx context.Context, artist *models.Artist) error {
+	return rpt.DB.WithContext(ctx).Create(artist).Error
+}
+
+// Update writes the non-zero fields of the artist if its version is the current one, and increments it
+// Returns ErrStaleVersion if the artist was updated meanwhile, or doesn't exist
+func (rpt *ArtistRepository) Update(ctx context.Context, artist *models.Artist) error {
+	version := artist.Version
+	artist.Version++
+	res := rpt.DB.WithContext(ctx).Model(artist).Where("version = ?", version).Updates(artist)
+	if res.Error == nil && res.RowsAffected == 0 {
+		res.Error = ErrStaleVersion
+	}
+	if res.Error != nil {
+		artist.Version = version
+		return res.Error
+	}
+	return nil
+}
+
+// Delete soft deletes the artist, if version is not 0 it must be the current version
+// Returns ErrStaleVersion if the artist was updated meanwhile
+func (rpt *ArtistRepository) Delete(ctx context.Context, id uint, version uint) error {
+	query := rpt.DB.WithContext(ctx)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	res := query.Delete(&models.Artist{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if version != 0 && res.RowsAffected == 0 {
+		return ErrStaleVersion
+	}
+	return nil
+}
+
+// albumIDs is the subquery of the active albums of the artist
+func (rpt *ArtistRepository) albumIDs(id uint) *gorm.DB {
+	return rpt.DB.Model(&models.Album{}).Select("id").Where("artist_id = ?", id)
 }
 
-func (rpt *ArtistRepository) Update(artist *models.Artist) error {
-	return rpt.DB.UpdateColumns(artist).Error
+// CountDependents counts the active albums of the artist and their active library entries
+func (rpt *ArtistRepository) CountDependents(ctx context.Context, id uint) (*dto.ArtistDependents, error) {
+	dependents := &dto.ArtistDependents{}
+	err := rpt.DB.WithContext(ctx).Model(&models.Album{}).
+		Where("artist_id = ?", id).
+		Count(&dependents.Albums).Error
+	if err != nil {
+		return nil, err
+	}
+	err = rpt.DB.WithContext(ctx).Model(&models.UserAlbum{}).
+		Where("album_id IN (?)", rpt.albumIDs(id)).
+		Count(&dependents.LibraryEntries).Error
+	if err != nil {
+		return nil, err
+	}
+	return dependents, nil
+}
+
+// DeleteAlbums soft deletes the active albums of the artist and their library entries, and returns them as they were
+// Run it in a transaction, the library entries and the albums are deleted by two statements
+func (rpt *ArtistRepository) DeleteAlbums(ctx context.Context, id uint) ([]*models.Album, []*models.UserAlbum, error) {
+	var albums []*models.Album
+	err := rpt.DB.WithContext(ctx).Where("artist_id = ?", id).Order("id").Find(&albums).Error
+	if err != nil || len(albums) == 0 {
+		return nil, nil, err
+	}
+	albumIDs := make([]uint, len(albums))
+	for i, album := range albums {
+		albumIDs[i] = album.ID
+	}
+
+	var entries []*models.UserAlbum
+	err = rpt.DB.WithContext(ctx).Where("album_id IN ?", albumIDs).Order("id").Find(&entries).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(entries) != 0 {
+		err = rpt.DB.WithContext(ctx).Delete(&entries).Error
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	err = rpt.DB.WithContext(ctx).Delete(&models.Album{}, albumIDs).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return albums, entries, nil
+}
+
+// ReassignAlbums moves the active albums of the artist to another artist, and returns them before and after the move
+// Returns gorm.ErrDuplicatedKey if the other artist already has an album with the same name,
+// gorm.ErrForeignKeyViolated if the other artist doesn't exist
+func (rpt *ArtistRepository) ReassignAlbums(ctx context.Context, id, toID uint) (before, after []*models.Album, err error) {
+	err = rpt.DB.WithContext(ctx).Where("artist_id = ?", id).Order("id").Find(&before).Error
+	if err != nil || len(before) == 0 {
+		return nil, nil, err
+	}
+	albumIDs := make([]uint, len(before))
+	for i, album := range before {
+		albumIDs[i] = album.ID
+	}
+
+	err = rpt.DB.WithContext(ctx).Model(&models.Album{}).
+		Where("id IN ?", albumIDs).
+		Updates(map[string]interface{}{
+			"artist_id": toID,
+			"version":   gorm.Expr("version + 1"),
+		}).Error
+	if err != nil {
+		return nil, nil, translateError(err)
+	}
+	err = rpt.DB.WithContext(ctx).Where("id IN ?", albumIDs).Order("id").Find(&after).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+// GetByName returns the first artist with the name, gorm.ErrRecordNotFound if there is none
+func (rpt *ArtistRepository) GetByName(ctx context.Context, name string) (*models.Artist, error) {
+	var artist models.Artist
+	err := rpt.DB.WithContext(ctx).Where("name = ?", name).Order("id").First(&artist).Error
+	if err != nil {
+		return nil, err
+	}
+	return &artist, nil
+}
+
+// AddAlbums creates the albums of the artist which don't exist yet, and returns them
+func (rpt *ArtistRepository) AddAlbums(ctx context.Context, id uint, names []string) ([]*models.Album, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	var existing []string
+	err := rpt.DB.WithContext(ctx).Model(&models.Album{}).
+		Where("artist_id = ? AND name IN ?", id, names).
+		Pluck("name", &existing).Error
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make(map[string]bool, len(names))
+	for _, name := range existing {
+		exists[name] = true
+	}
+	var albums []*models.Album
+	for _, name := range names {
+		if exists[name] {
+			continue
+		}
+		exists[name] = true
+		albums = append(albums, &models.Album{Name: name, ArtistID: id})
+	}
+	if len(albums) == 0 {
+		return nil, nil
+	}
+	err = rpt.DB.WithContext(ctx).Create(&albums).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return albums, nil
 }
 
-func (rpt *ArtistRepository) Delete(id uint) error {
-	return rpt.DB.Delete(&models.Artist{}, id).Error
+// FindInBatches calls fn with the artists and their albums by batches, ordered by id
+// It stops at the first error of fn
+func (rpt *ArtistRepository) FindInBatches(ctx context.Context, batchSize int, fn func(artists []*models.Artist) error) error {
+	var artists []*models.Artist
+	return rpt.DB.WithContext(ctx).
+		Preload("Albums", func(db *gorm.DB) *gorm.DB {
+			return db.Order(clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: "id"}})
+		}).
+		Order("id").
+		FindInBatches(&artists, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(artists)
+		}).Error
 }
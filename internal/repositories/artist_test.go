@@ -0,0 +1,437 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/filter"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"gorm.io/gorm"
+)
+
+func (suite *RepositorySuiteTest) TestArtistGetByID() {
+	type expectedType struct {
+		name string
+		err  error
+	}
+
+	tests := map[string]struct {
+		id       uint
+		expected expectedType
+	}{
+		"Existing artist": {
+			id: suite.fixtures["Artist.eminem"],
+			expected: expectedType{
+				name: "Eminem",
+			},
+		},
+		"Unknown artist": {
+			id: 0,
+			expected: expectedType{
+				err: gorm.ErrRecordNotFound,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			artist, err := suite.gr.Artist.GetByID(context.Background(), test.id)
+			if test.expected.err != nil {
+				suite.Assert().ErrorIs(err, test.expected.err, "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expected.name, artist.Name, "Artist name should match")
+		})
+	}
+}
+
+func (suite *RepositorySuiteTest) TestArtistGetWithRelations() {
+	ctx := context.Background()
+	artist, albums := suite.createArtistWithAlbums("Expanded", "Expanded first", "Expanded second")
+	suite.Require().NoError(suite.db.Delete(albums[1]).Error)
+
+	suite.Run("Albums", func() {
+		found, err := suite.gr.Artist.GetWithRelations(ctx, artist.ID, []string{"Albums"})
+
+		suite.Require().NoError(err, "No error should have occurred")
+		suite.Assert().Equal("Expanded", found.Name)
+		suite.Require().Len(found.Albums, 1, "Deleted albums should not be preloaded")
+		suite.Assert().Equal(albums[0].ID, found.Albums[0].ID)
+	})
+
+	suite.Run("Unknown artist", func() {
+		_, err := suite.gr.Artist.GetWithRelations(ctx, 0, []string{"Albums"})
+
+		suite.Assert().ErrorIs(err, gorm.ErrRecordNotFound, "Error type should match")
+	})
+}
+
+func (suite *RepositorySuiteTest) TestArtistCreate() {
+	artist := &models.Artist{Name: "repository created"}
+
+	err := suite.gr.Artist.Create(context.Background(), artist)
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().NotZero(artist.ID, "Created artist should have an ID")
+}
+
+func (suite *RepositorySuiteTest) TestArtistUpdate() {
+	ctx := context.Background()
+	artist := &models.Artist{Name: "repository to update"}
+	suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+
+	artist.Name = "repository updated"
+	err := suite.gr.Artist.Update(ctx, artist)
+	suite.Require().NoError(err, "No error should have occurred")
+
+	updated, err := suite.gr.Artist.GetByID(ctx, artist.ID)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("repository updated", updated.Name, "Artist name should be updated")
+	suite.Assert().Equal(uint(2), updated.Version, "Artist version should be incremented")
+	suite.Assert().Equal(uint(2), artist.Version, "Updated artist should have its new version")
+
+	stale := &models.Artist{Model: models.Model{ID: artist.ID, Version: 1}, Name: "repository stale"}
+	err = suite.gr.Artist.Update(ctx, stale)
+	suite.Assert().ErrorIs(err, ErrStaleVersion, "Stale version should not be updated")
+	suite.Assert().Equal(uint(1), stale.Version, "Stale artist version should be kept")
+}
+
+func (suite *RepositorySuiteTest) TestArtistDelete() {
+	ctx := context.Background()
+	artist := &models.Artist{Name: "repository to delete"}
+	suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+
+	err := suite.gr.Artist.Delete(ctx, artist.ID, artist.Version+1)
+	suite.Assert().ErrorIs(err, ErrStaleVersion, "Stale version should not be deleted")
+
+	err = suite.gr.Artist.Delete(ctx, artist.ID, artist.Version)
+	suite.Require().NoError(err, "No error should have occurred")
+
+	_, err = suite.gr.Artist.GetByID(ctx, artist.ID)
+	suite.Assert().ErrorIs(err, gorm.ErrRecordNotFound, "Deleted artist should not be found")
+}
+
+// createArtistWithAlbums creates an artist with albums, the first one in the library of the fixture user
+func (suite *RepositorySuiteTest) createArtistWithAlbums(name string, albums ...string) (*models.Artist, []*models.Album) {
+	artist := &models.Artist{Name: name}
+	suite.Require().NoError(suite.db.Create(artist).Error)
+	created := make([]*models.Album, 0, len(albums))
+	for _, albumName := range albums {
+		album := &models.Album{Name: albumName, ArtistID: artist.ID}
+		suite.Require().NoError(suite.db.Create(album).Error)
+		created = append(created, album)
+	}
+	if len(created) != 0 {
+		userAlbum := &models.UserAlbum{UserID: suite.fixtures["User.john"], AlbumID: created[0].ID}
+		suite.Require().NoError(suite.db.Create(userAlbum).Error)
+	}
+	return artist, created
+}
+
+func (suite *RepositorySuiteTest) TestArtistCountDependents() {
+	ctx := context.Background()
+	artist, albums := suite.createArtistWithAlbums("dependents counted", "Counted 1", "Counted 2", "Counted 3")
+	// Soft deleted albums are not dependents
+	suite.Require().NoError(suite.db.Delete(albums[2]).Error)
+	empty, _ := suite.createArtistWithAlbums("dependents none")
+
+	dependents, err := suite.gr.Artist.CountDependents(ctx, artist.ID)
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Equal(&dto.ArtistDependents{Albums: 2, LibraryEntries: 1}, dependents)
+
+	dependents, err = suite.gr.Artist.CountDependents(ctx, empty.ID)
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Equal(&dto.ArtistDependents{}, dependents)
+}
+
+func (suite *RepositorySuiteTest) TestArtistDeleteAlbums() {
+	ctx := context.Background()
+	artist, albums := suite.createArtistWithAlbums("cascade deleted", "Cascaded 1", "Cascaded 2")
+
+	deleted, entries, err := suite.gr.Artist.DeleteAlbums(ctx, artist.ID)
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Len(deleted, 2, "Deleted albums should be returned")
+	suite.Assert().Equal(albums[0].ID, deleted[0].ID)
+	suite.Assert().Len(entries, 1, "Deleted library entries should be returned")
+
+	var count int64
+	suite.Require().NoError(suite.db.Model(&models.Album{}).Where("artist_id = ?", artist.ID).Count(&count).Error)
+	suite.Assert().Zero(count, "Albums should be soft deleted")
+	suite.Require().NoError(suite.db.Model(&models.UserAlbum{}).Where("album_id = ?", albums[0].ID).Count(&count).Error)
+	suite.Assert().Zero(count, "Library entries should be soft deleted")
+	suite.Require().NoError(suite.db.Unscoped().Model(&models.Album{}).Where("artist_id = ?", artist.ID).Count(&count).Error)
+	suite.Assert().Equal(int64(2), count, "Albums should stay in the trash")
+}
+
+func (suite *RepositorySuiteTest) TestArtistReassignAlbums() {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		setup    func() (id, toID uint)
+		expected error
+	}{
+		"Reassign to another artist": {
+			setup: func() (uint, uint) {
+				from, _ := suite.createArtistWithAlbums("reassigned from", "Reassigned")
+				to, _ := suite.createArtistWithAlbums("reassigned to")
+				return from.ID, to.ID
+			},
+		},
+		"Other artist has the same album": {
+			setup: func() (uint, uint) {
+				from, _ := suite.createArtistWithAlbums("duplicated from", "Duplicated")
+				to, _ := suite.createArtistWithAlbums("duplicated to", "Duplicated")
+				return from.ID, to.ID
+			},
+			expected: gorm.ErrDuplicatedKey,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			id, toID := test.setup()
+
+			before, after, err := suite.gr.Artist.ReassignAlbums(ctx, id, toID)
+			if test.expected != nil {
+				suite.Assert().ErrorIs(err, test.expected, "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Require().Len(after, 1, "Reassigned albums should be returned")
+			suite.Assert().Equal(id, before[0].ArtistID)
+			suite.Assert().Equal(toID, after[0].ArtistID)
+			suite.Assert().Equal(before[0].Version+1, after[0].Version)
+			dependents, err := suite.gr.Artist.CountDependents(ctx, toID)
+			suite.Require().NoError(err)
+			suite.Assert().Equal(&dto.ArtistDependents{Albums: 1, LibraryEntries: 1}, dependents, "Albums should be moved with their library entries")
+		})
+	}
+}
+
+func (suite *RepositorySuiteTest) TestArtistForeignKeys() {
+	ctx := context.Background()
+	artist, albums := suite.createArtistWithAlbums("foreign keys", "Referenced")
+	_, _, err := suite.gr.Artist.DeleteAlbums(ctx, artist.ID)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.gr.Artist.Delete(ctx, artist.ID, 0))
+
+	// An artist can't be purged while albums reference it, even deleted ones
+	err = suite.gr.Trash.Purge(ctx, dto.TrashEntityArtists, artist.ID)
+	suite.Assert().ErrorIs(err, gorm.ErrForeignKeyViolated, "Referenced artist should not be purged")
+
+	// Library entries are purged with their album
+	err = suite.gr.Trash.Purge(ctx, dto.TrashEntityAlbums, albums[0].ID)
+	suite.Require().NoError(err, "No error should have occurred")
+	var count int64
+	suite.Require().NoError(suite.db.Unscoped().Model(&models.UserAlbum{}).Where("album_id = ?", albums[0].ID).Count(&count).Error)
+	suite.Assert().Zero(count, "Library entries should be deleted with their album")
+
+	err = suite.gr.Trash.Purge(ctx, dto.TrashEntityArtists, artist.ID)
+	suite.Assert().NoError(err, "Unreferenced artist should be purged")
+}
+
+func (suite *RepositorySuiteTest) TestArtistList() {
+	ctx := context.Background()
+	for _, name := range []string{"paginated b", "paginated a", "paginated c", "paginated d"} {
+		suite.Require().NoError(suite.gr.Artist.Create(ctx, &models.Artist{Name: name}))
+	}
+	sort := []pagination.Order{{Column: "name", Desc: true}}
+	expected := []*models.Artist{}
+	suite.Require().NoError(suite.db.Order("name DESC").Order("id").Find(&expected).Error)
+	suite.Require().Greater(len(expected), 4)
+
+	suite.Run("Forward and backward cursors", func() {
+		ids := []uint{}
+		var pages []*pagination.Page[*models.Artist]
+		params := &pagination.Params{Limit: 2, Sort: sort}
+		for {
+			page, err := suite.gr.Artist.List(ctx, nil, params, nil)
+			suite.Require().NoError(err, "No error should have occurred")
+			pages = append(pages, page)
+			for _, artist := range page.Items {
+				ids = append(ids, artist.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor, err := pagination.DecodeCursor(page.NextCursor)
+			suite.Require().NoError(err)
+			params = &pagination.Params{Limit: 2, Sort: sort, Cursor: cursor}
+		}
+		suite.Assert().Equal(artistIDs(expected), ids, "Pages should list all the artists in order")
+		suite.Assert().Empty(pages[0].PrevCursor, "First page should have no previous page")
+
+		// Go back from the last page
+		last := pages[len(pages)-1]
+		cursor, err := pagination.DecodeCursor(last.PrevCursor)
+		suite.Require().NoError(err)
+		previous, err := suite.gr.Artist.List(ctx, nil, &pagination.Params{Limit: 2, Sort: sort, Cursor: cursor}, nil)
+		suite.Require().NoError(err, "No error should have occurred")
+		suite.Assert().Equal(artistIDs(pages[len(pages)-2].Items), artistIDs(previous.Items), "Previous page should match")
+		suite.Assert().NotEmpty(previous.NextCursor, "Previous page should have a next page")
+	})
+
+	suite.Run("Cursor of a date", func() {
+		byDate := []*models.Artist{}
+		suite.Require().NoError(suite.db.Order("created_at DESC").Order("id").Find(&byDate).Error)
+
+		ids := []uint{}
+		params := &pagination.Params{Limit: 3, Sort: []pagination.Order{{Column: "created_at", Desc: true}}}
+		for {
+			page, err := suite.gr.Artist.List(ctx, nil, params, nil)
+			suite.Require().NoError(err, "No error should have occurred")
+			ids = append(ids, artistIDs(page.Items)...)
+			if page.NextCursor == "" {
+				break
+			}
+			params.Cursor, err = pagination.DecodeCursor(page.NextCursor)
+			suite.Require().NoError(err)
+		}
+		suite.Assert().Equal(artistIDs(byDate), ids, "Pages should list all the artists in order")
+	})
+
+	suite.Run("Page numbers", func() {
+		page, err := suite.gr.Artist.List(ctx, nil, &pagination.Params{Limit: 2, Page: 2, Sort: sort}, nil)
+
+		suite.Require().NoError(err, "No error should have occurred")
+		suite.Assert().Equal(artistIDs(expected[2:4]), artistIDs(page.Items), "Page should match")
+		suite.Assert().Equal(int64(len(expected)), *page.Total, "Total should be counted")
+		suite.Assert().Equal(1, page.PrevNumber, "Previous page should match")
+		suite.Assert().Equal(3, page.NextNumber, "Next page should match")
+	})
+}
+
+func (suite *RepositorySuiteTest) TestArtistListFilters() {
+	ctx := context.Background()
+	artists := []*models.Artist{{Name: "Filtered 100% Hits"}, {Name: "Filtered 1000 Hits"}, {Name: "Other filtered"}}
+	for _, artist := range artists {
+		suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+	}
+	params := &pagination.Params{Limit: pagination.MaxLimit, Sort: []pagination.Order{{Column: "id"}}}
+
+	tests := map[string]struct {
+		filters  []filter.Condition
+		expected []uint
+	}{
+		"Contains is case insensitive": {
+			filters:  []filter.Condition{{Column: "name", Operator: filter.Contains, Value: "filtered 100"}},
+			expected: []uint{artists[0].ID, artists[1].ID},
+		},
+		"Contains a wildcard": {
+			filters:  []filter.Condition{{Column: "name", Operator: filter.Contains, Value: "100%"}},
+			expected: []uint{artists[0].ID},
+		},
+		"Starts with": {
+			filters:  []filter.Condition{{Column: "name", Operator: filter.StartsWith, Value: "other FILTERED"}},
+			expected: []uint{artists[2].ID},
+		},
+		"In and comparison": {
+			filters: []filter.Condition{
+				{Column: "id", Operator: filter.In, Value: []uint{artists[0].ID, artists[2].ID}},
+				{Column: "id", Operator: filter.Gt, Value: artists[0].ID},
+			},
+			expected: []uint{artists[2].ID},
+		},
+		"Date": {
+			filters: []filter.Condition{
+				{Column: "created_at", Operator: filter.Gte, Value: artists[1].CreatedAt},
+				{Column: "name", Operator: filter.Contains, Value: "filtered"},
+			},
+			expected: []uint{artists[1].ID, artists[2].ID},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			page, err := suite.gr.Artist.List(ctx, test.filters, params, nil)
+
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expected, artistIDs(page.Items))
+		})
+	}
+}
+
+func (suite *RepositorySuiteTest) TestArtistListWithRelations() {
+	ctx := context.Background()
+	artist, albums := suite.createArtistWithAlbums("listed with albums", "Listed 2", "Listed 1")
+	filters := []filter.Condition{{Column: "id", Operator: filter.Eq, Value: artist.ID}}
+
+	page, err := suite.gr.Artist.List(ctx, filters, &pagination.Params{Limit: 10}, []string{"Albums"})
+
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Require().Len(page.Items, 1)
+	suite.Require().Len(page.Items[0].Albums, 2, "Albums should be preloaded")
+	suite.Assert().Equal(albums[0].ID, page.Items[0].Albums[0].ID, "Albums should be ordered by id")
+	suite.Assert().Equal(albums[1].ID, page.Items[0].Albums[1].ID)
+}
+
+func artistIDs(artists []*models.Artist) []uint {
+	ids := make([]uint, 0, len(artists))
+	for _, artist := range artists {
+		ids = append(ids, artist.ID)
+	}
+	return ids
+}
+
+func (suite *RepositorySuiteTest) TestArtistGetByName() {
+	ctx := context.Background()
+	first, _ := suite.createArtistWithAlbums("named twice")
+	suite.Require().NoError(suite.db.Delete(first).Error)
+	second, _ := suite.createArtistWithAlbums("named twice")
+	deleted, _ := suite.createArtistWithAlbums("named deleted")
+	suite.Require().NoError(suite.db.Delete(deleted).Error)
+
+	artist, err := suite.gr.Artist.GetByName(ctx, "named twice")
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Equal(second.ID, artist.ID, "The active artist should be returned")
+
+	err = suite.gr.Artist.Create(ctx, &models.Artist{Name: "named twice"})
+	suite.Assert().ErrorIs(err, gorm.ErrDuplicatedKey, "Two active artists should not have the same name")
+
+	_, err = suite.gr.Artist.GetByName(ctx, "named deleted")
+	suite.Assert().ErrorIs(err, gorm.ErrRecordNotFound, "Deleted artists should be ignored")
+}
+
+func (suite *RepositorySuiteTest) TestArtistAddAlbums() {
+	ctx := context.Background()
+	artist, _ := suite.createArtistWithAlbums("albums added", "Added 1")
+
+	added, err := suite.gr.Artist.AddAlbums(ctx, artist.ID, []string{"Added 1", "Added 2", "Added 2"})
+
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Require().Len(added, 1, "Only the new album should be added, once")
+	suite.Assert().Equal("Added 2", added[0].Name)
+	suite.Assert().NotZero(added[0].ID)
+
+	added, err = suite.gr.Artist.AddAlbums(ctx, artist.ID, []string{"Added 2"})
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Empty(added, "Existing albums should be kept")
+}
+
+func (suite *RepositorySuiteTest) TestArtistFindInBatches() {
+	ctx := context.Background()
+	artist, albums := suite.createArtistWithAlbums("found in batches", "Batch 2", "Batch 1")
+	var count int64
+	suite.Require().NoError(suite.db.Model(&models.Artist{}).Count(&count).Error)
+
+	var batches, found int
+	var exported *models.Artist
+	err := suite.gr.Artist.FindInBatches(ctx, 2, func(artists []*models.Artist) error {
+		batches++
+		found += len(artists)
+		for _, batchArtist := range artists {
+			if batchArtist.ID == artist.ID {
+				exported = batchArtist
+			}
+		}
+		return nil
+	})
+
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Equal(int(count), found, "All the artists should be found")
+	suite.Assert().Equal(int(count+1)/2, batches)
+	suite.Require().NotNil(exported)
+	suite.Require().Len(exported.Albums, 2)
+	suite.Assert().Equal(albums[0].ID, exported.Albums[0].ID, "Albums should be ordered by id")
+}
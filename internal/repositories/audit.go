@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/models"
+	"gorm.io/gorm"
+)
+
+type AuditRepositoryInterface interface {
+	Create(ctx context.Context, log *models.AuditLog) error
+	List(ctx context.Context, filter *dto.AuditFilter) ([]*models.AuditLog, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type AuditRepository struct {
+	DB *gorm.DB
+}
+
+func (rpt *AuditRepository) Create(ctx context.Context, log *models.AuditLog) error {
+	return rpt.DB.WithContext(ctx).Create(log).Error
+}
+
+// List returns the audit logs matching the filter, most recent first
+// A zero limit returns all of them
+func (rpt *AuditRepository) List(ctx context.Context, filter *dto.AuditFilter) ([]*models.AuditLog, error) {
+	query := rpt.DB.WithContext(ctx).Model(&models.AuditLog{})
+	if filter.ActorType != "" {
+		query = query.Where("actor_type = ?", filter.ActorType)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	logs := []*models.AuditLog{}
+	err := query.Order("created_at DESC").Order("id DESC").
+		Offset(filter.Offset).
+		Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// DeleteBefore deletes the audit logs created before the given date, and returns their count
+func (rpt *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	res := rpt.DB.WithContext(ctx).Where("created_at < ?", before).Delete(&models.AuditLog{})
+	return res.RowsAffected, res.Error
+}
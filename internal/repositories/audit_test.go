@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/models"
+)
+
+func (suite *RepositorySuiteTest) TestAuditList() {
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	logs := []*models.AuditLog{
+		{CreatedAt: start, ActorType: "user", ActorID: "1", Action: "create", EntityType: "audit listed", EntityID: 1, Changes: "{}"},
+		{CreatedAt: start.Add(time.Minute), ActorType: "user", ActorID: "2", Action: "delete", EntityType: "audit listed", EntityID: 1, Changes: "{}"},
+		{CreatedAt: start.Add(2 * time.Minute), ActorType: "system", Action: "purge", EntityType: "audit listed", EntityID: 2, Changes: "{}"},
+	}
+	for _, log := range logs {
+		suite.Require().NoError(suite.gr.Audit.Create(ctx, log))
+	}
+
+	tests := map[string]struct {
+		filter   *dto.AuditFilter
+		expected []uint
+	}{
+		"Most recent first": {
+			filter:   &dto.AuditFilter{EntityType: "audit listed"},
+			expected: []uint{logs[2].ID, logs[1].ID, logs[0].ID},
+		},
+		"By actor": {
+			filter:   &dto.AuditFilter{EntityType: "audit listed", ActorType: "user", ActorID: "2"},
+			expected: []uint{logs[1].ID},
+		},
+		"By action": {
+			filter:   &dto.AuditFilter{EntityType: "audit listed", Action: "create"},
+			expected: []uint{logs[0].ID},
+		},
+		"By entity": {
+			filter:   &dto.AuditFilter{EntityType: "audit listed", EntityID: 1},
+			expected: []uint{logs[1].ID, logs[0].ID},
+		},
+		"By date": {
+			filter:   &dto.AuditFilter{EntityType: "audit listed", From: start.Add(time.Minute), To: start.Add(2 * time.Minute)},
+			expected: []uint{logs[1].ID},
+		},
+		"Limit and offset": {
+			filter:   &dto.AuditFilter{EntityType: "audit listed", Limit: 1, Offset: 1},
+			expected: []uint{logs[1].ID},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			result, err := suite.gr.Audit.List(ctx, test.filter)
+
+			suite.Require().NoError(err, "No error should have occurred")
+			ids := make([]uint, 0, len(result))
+			for _, log := range result {
+				ids = append(ids, log.ID)
+			}
+			suite.Assert().Equal(test.expected, ids, "Listed logs should match")
+		})
+	}
+}
+
+func (suite *RepositorySuiteTest) TestAuditDeleteBefore() {
+	ctx := context.Background()
+	old := &models.AuditLog{CreatedAt: time.Now().Add(-48 * time.Hour), ActorType: "system", Action: "purge", EntityType: "audit expired", Changes: "{}"}
+	recent := &models.AuditLog{ActorType: "system", Action: "purge", EntityType: "audit expired", Changes: "{}"}
+	suite.Require().NoError(suite.gr.Audit.Create(ctx, old))
+	suite.Require().NoError(suite.gr.Audit.Create(ctx, recent))
+
+	deleted, err := suite.gr.Audit.DeleteBefore(ctx, time.Now().Add(-24*time.Hour))
+
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Equal(int64(1), deleted, "Only the old log should be deleted")
+	result, err := suite.gr.Audit.List(ctx, &dto.AuditFilter{EntityType: "audit expired"})
+	suite.Require().NoError(err)
+	suite.Require().Len(result, 1)
+	suite.Assert().Equal(recent.ID, result[0].ID, "Recent log should be kept")
+}
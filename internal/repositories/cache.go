@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/cache"
+	"github.com/sarrooo/go-clean/internal/models"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// NewCachedGlobalRepository returns a GlobalRepository whose catalog repositories are cached for ttl
+// Writes made in transactions invalidate the cache when the transaction ends
+func NewCachedGlobalRepository(DB *gorm.DB, c cache.Cache, ttl time.Duration) *GlobalRepository {
+	gr := NewGlobalRepository(DB)
+	artist := NewCachedArtistRepository(gr.Artist, c, ttl)
+	gr.Artist = artist
+
+	// Add new cached repository here
+
+	gr.Transaction = &TransactionManager{DB: DB, Cache: c, generations: artist.generations}
+	return gr
+}
+
+// cacheGenerations counts the invalidations of the keys being loaded
+// A load which started before an invalidation of its key doesn't cache its value, it can be stale
+// Only the keys being loaded are counted, so the counters don't grow with the cache
+type cacheGenerations struct {
+	mu    sync.Mutex
+	loads map[string]*cacheLoads
+}
+
+type cacheLoads struct {
+	generation uint64
+	count      int
+}
+
+func newCacheGenerations() *cacheGenerations {
+	return &cacheGenerations{loads: make(map[string]*cacheLoads)}
+}
+
+// start registers a load of the key and returns the generation of the key
+func (cg *cacheGenerations) start(key string) uint64 {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	loads, ok := cg.loads[key]
+	if !ok {
+		loads = &cacheLoads{}
+		cg.loads[key] = loads
+	}
+	loads.count++
+	return loads.generation
+}
+
+// done unregisters a load of the key, and calls set if the key was not invalidated since the load started
+// set is called with the lock held, so an invalidation can't happen between the check and the write
+func (cg *cacheGenerations) done(key string, generation uint64, set func()) {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	loads := cg.loads[key]
+	if loads.generation == generation {
+		set()
+	}
+	loads.count--
+	if loads.count == 0 {
+		delete(cg.loads, key)
+	}
+}
+
+// bump invalidates the loads of the keys, it must be called before the keys are deleted from the cache
+func (cg *cacheGenerations) bump(keys ...string) {
+	if cg == nil {
+		return
+	}
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	for _, key := range keys {
+		if loads, ok := cg.loads[key]; ok {
+			loads.generation++
+		}
+	}
+}
+
+// cacheInvalidations collects the keys written in a transaction, to invalidate them when it ends
+// A concurrent read could cache a value between the write and the commit, so keys are invalidated twice
+type cacheInvalidations struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (ci *cacheInvalidations) add(keys ...string) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.keys = append(ci.keys, keys...)
+}
+
+func (ci *cacheInvalidations) flush(ctx context.Context, c cache.Cache, generations *cacheGenerations) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	generations.bump(ci.keys...)
+	c.Delete(ctx, ci.keys...)
+	ci.keys = nil
+}
+
+// CachedArtistRepository caches artists read by ID
+// - concurrent misses of the same key run one query (singleflight), detached from the context of the first caller
+// - Create, Update and Delete invalidate the key, a query started before is not cached
+// - errors are not cached
+// In a transaction, reads are not cached because they can see uncommitted writes
+type CachedArtistRepository struct {
+	ArtistRepositoryInterface
+	cache cache.Cache
+	ttl   time.Duration
+	group singleflight.Group
+
+	// Shared with the repositories of the transactions
+	generations *cacheGenerations
+
+	// Set in a transaction, the cache is then only invalidated
+	invalidations *cacheInvalidations
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewCachedArtistRepository decorates the repository with the cache
+func NewCachedArtistRepository(repository ArtistRepositoryInterface, c cache.Cache, ttl time.Duration) *CachedArtistRepository {
+	return &CachedArtistRepository{
+		ArtistRepositoryInterface: repository,
+		cache:                     c,
+		ttl:                       ttl,
+		generations:               newCacheGenerations(),
+	}
+}
+
+func artistCacheKey(id uint) string {
+	return fmt.Sprintf("artist:%d", id)
+}
+
+func (rpt *CachedArtistRepository) GetByID(ctx context.Context, id uint) (*models.Artist, error) {
+	if rpt.invalidations != nil {
+		return rpt.ArtistRepositoryInterface.GetByID(ctx, id)
+	}
+
+	key := artistCacheKey(id)
+	if cached, ok := rpt.cache.Get(ctx, key); ok {
+		rpt.hits.Add(1)
+		artist := *cached.(*models.Artist)
+		return &artist, nil
+	}
+	rpt.misses.Add(1)
+
+	// The query is shared by the callers, a canceled caller must not fail the others
+	loadCtx := context.WithoutCancel(ctx)
+	results := rpt.group.DoChan(key, func() (interface{}, error) {
+		generation := rpt.generations.start(key)
+		artist, err := rpt.ArtistRepositoryInterface.GetByID(loadCtx, id)
+		if err != nil {
+			rpt.generations.done(key, generation, func() {})
+			return nil, err
+		}
+		cached := *artist
+		rpt.generations.done(key, generation, func() {
+			rpt.cache.Set(loadCtx, key, &cached, rpt.ttl)
+		})
+		return artist, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		// Callers sharing the query get their own copy
+		artist := *result.Val.(*models.Artist)
+		return &artist, nil
+	}
+}
+
+func (rpt *CachedArtistRepository) Create(ctx context.Context, artist *models.Artist) error {
+	err := rpt.ArtistRepositoryInterface.Create(ctx, artist)
+	if err != nil {
+		return err
+	}
+	rpt.invalidate(ctx, artistCacheKey(artist.ID))
+	return nil
+}
+
+func (rpt *CachedArtistRepository) Update(ctx context.Context, artist *models.Artist) error {
+	err := rpt.ArtistRepositoryInterface.Update(ctx, artist)
+	if err != nil {
+		return err
+	}
+	rpt.invalidate(ctx, artistCacheKey(artist.ID))
+	return nil
+}
+
+func (rpt *CachedArtistRepository) Delete(ctx context.Context, id uint, version uint) error {
+	err := rpt.ArtistRepositoryInterface.Delete(ctx, id, version)
+	if err != nil {
+		return err
+	}
+	rpt.invalidate(ctx, artistCacheKey(id))
+	return nil
+}
+
+// Stats returns the hit and miss counters
+func (rpt *CachedArtistRepository) Stats() cache.Stats {
+	return cache.Stats{
+		Hits:   rpt.hits.Load(),
+		Misses: rpt.misses.Load(),
+	}
+}
+
+func (rpt *CachedArtistRepository) invalidate(ctx context.Context, keys ...string) {
+	rpt.generations.bump(keys...)
+	rpt.cache.Delete(ctx, keys...)
+	for _, key := range keys {
+		rpt.group.Forget(key)
+	}
+	if rpt.invalidations != nil {
+		rpt.invalidations.add(keys...)
+	}
+}
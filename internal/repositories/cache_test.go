@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/cache"
+	"github.com/sarrooo/go-clean/internal/models"
+)
+
+// countingArtistRepository counts the reads reaching the decorated repository
+type countingArtistRepository struct {
+	ArtistRepositoryInterface
+	reads   atomic.Int32
+	release chan struct{}
+	// Called after a read, before its value is returned
+	afterRead func()
+}
+
+func (rpt *countingArtistRepository) GetByID(ctx context.Context, id uint) (*models.Artist, error) {
+	rpt.reads.Add(1)
+	if rpt.release != nil {
+		<-rpt.release
+	}
+	artist, err := rpt.ArtistRepositoryInterface.GetByID(ctx, id)
+	if rpt.afterRead != nil {
+		rpt.afterRead()
+	}
+	return artist, err
+}
+
+func (suite *RepositorySuiteTest) TestCachedArtistRepository() {
+	ctx := context.Background()
+
+	type expectedType struct {
+		name  string
+		reads int32
+		stats cache.Stats
+	}
+
+	tests := map[string]struct {
+		scenario func(rpt *CachedArtistRepository, id uint)
+		expected expectedType
+	}{
+		"Hit after miss": {
+			scenario: func(rpt *CachedArtistRepository, id uint) {
+				rpt.GetByID(ctx, id)
+			},
+			expected: expectedType{name: "cached", reads: 1, stats: cache.Stats{Hits: 1, Misses: 1}},
+		},
+		"Cached copy is not shared": {
+			scenario: func(rpt *CachedArtistRepository, id uint) {
+				artist, _ := rpt.GetByID(ctx, id)
+				artist.Name = "modified by the caller"
+			},
+			expected: expectedType{name: "cached", reads: 1, stats: cache.Stats{Hits: 1, Misses: 1}},
+		},
+		"Update invalidates": {
+			scenario: func(rpt *CachedArtistRepository, id uint) {
+				rpt.GetByID(ctx, id)
+				rpt.Update(ctx, &models.Artist{Model: models.Model{ID: id, Version: 1}, Name: "updated"})
+			},
+			expected: expectedType{name: "updated", reads: 2, stats: cache.Stats{Misses: 2}},
+		},
+		"Concurrent misses run one query": {
+			scenario: func(rpt *CachedArtistRepository, id uint) {
+				inner := rpt.ArtistRepositoryInterface.(*countingArtistRepository)
+				inner.release = make(chan struct{})
+				var wg sync.WaitGroup
+				for i := 0; i < 5; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						rpt.GetByID(ctx, id)
+					}()
+				}
+				// Let all the readers wait on the first query
+				time.Sleep(50 * time.Millisecond)
+				close(inner.release)
+				wg.Wait()
+			},
+			expected: expectedType{name: "cached", reads: 1, stats: cache.Stats{Hits: 1, Misses: 5}},
+		},
+		"Update during a query is not cached": {
+			scenario: func(rpt *CachedArtistRepository, id uint) {
+				inner := rpt.ArtistRepositoryInterface.(*countingArtistRepository)
+				// The read sees the artist before the update, and returns after its invalidation
+				inner.afterRead = func() {
+					inner.afterRead = nil
+					rpt.Update(ctx, &models.Artist{Model: models.Model{ID: id, Version: 1}, Name: "updated"})
+				}
+				artist, _ := rpt.GetByID(ctx, id)
+				suite.Assert().Equal("cached", artist.Name)
+			},
+			expected: expectedType{name: "updated", reads: 2, stats: cache.Stats{Misses: 2}},
+		},
+		"Canceled caller doesn't cancel the query": {
+			scenario: func(rpt *CachedArtistRepository, id uint) {
+				inner := rpt.ArtistRepositoryInterface.(*countingArtistRepository)
+				inner.release = make(chan struct{})
+				canceled, cancel := context.WithCancel(ctx)
+				cancel()
+
+				_, err := rpt.GetByID(canceled, id)
+				suite.Assert().ErrorIs(err, context.Canceled, "The canceled caller should not wait")
+
+				waiter := make(chan error)
+				go func() {
+					_, err := rpt.GetByID(ctx, id)
+					waiter <- err
+				}()
+				// Let the waiter share the query of the canceled caller
+				time.Sleep(50 * time.Millisecond)
+				close(inner.release)
+				suite.Assert().NoError(<-waiter, "The waiter should get the artist")
+			},
+			expected: expectedType{name: "cached", reads: 1, stats: cache.Stats{Hits: 1, Misses: 2}},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			artist := &models.Artist{Name: "cached"}
+			suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+			// The name is unique among the active artists
+			defer suite.db.Delete(&models.Artist{}, artist.ID)
+			inner := &countingArtistRepository{ArtistRepositoryInterface: &ArtistRepository{DB: suite.db}}
+			rpt := NewCachedArtistRepository(inner, cache.NewLRU(10), time.Minute)
+
+			test.scenario(rpt, artist.ID)
+			got, err := rpt.GetByID(ctx, artist.ID)
+
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expected.name, got.Name, "Artist name should match")
+			suite.Assert().Equal(test.expected.reads, inner.reads.Load(), "Database reads should match")
+			suite.Assert().Equal(test.expected.stats, rpt.Stats(), "Cache stats should match")
+			suite.Assert().Empty(rpt.generations.loads, "Finished loads should not be counted")
+		})
+	}
+}
+
+func (suite *RepositorySuiteTest) TestCachedGlobalRepositoryWithinTx() {
+	ctx := context.Background()
+	gr := NewCachedGlobalRepository(suite.db, cache.NewLRU(10), time.Minute)
+	artist := &models.Artist{Name: "before transaction"}
+	suite.Require().NoError(gr.Artist.Create(ctx, artist))
+	_, err := gr.Artist.GetByID(ctx, artist.ID)
+	suite.Require().NoError(err)
+
+	err = gr.WithinTx(ctx, func(repos *GlobalRepository) error {
+		return repos.WithinTx(ctx, func(nested *GlobalRepository) error {
+			return nested.Artist.Update(ctx, &models.Artist{Model: models.Model{ID: artist.ID, Version: artist.Version}, Name: "updated in transaction"})
+		})
+	})
+	suite.Require().NoError(err, "No error should have occurred")
+
+	got, err := gr.Artist.GetByID(ctx, artist.ID)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("updated in transaction", got.Name, "The write of the transaction should invalidate the cache")
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+)
+
+// ErrStaleVersion is returned when a row is written with a version which is not its current version anymore
+var ErrStaleVersion = errors.New("stale version")
+
+// translateError completes the error translation of the GORM drivers
+// They only translate unique violations, foreign key violations are returned as gorm.ErrForeignKeyViolated
+func translateError(err error) error {
+	var pgError *pgconn.PgError
+	if errors.As(err, &pgError) && pgError.Code == "23503" {
+		return fmt.Errorf("%w: %v", gorm.ErrForeignKeyViolated, err)
+	}
+	var mysqlError *mysql.MySQLError
+	if errors.As(err, &mysqlError) && (mysqlError.Number == 1451 || mysqlError.Number == 1452) {
+		return fmt.Errorf("%w: %v", gorm.ErrForeignKeyViolated, err)
+	}
+	// SQLite reports ON DELETE RESTRICT violations as trigger constraints
+	var sqliteError sqlite3.Error
+	if errors.As(err, &sqliteError) && (sqliteError.ExtendedCode == sqlite3.ErrConstraintForeignKey ||
+		sqliteError.ExtendedCode == sqlite3.ErrConstraintTrigger && strings.HasPrefix(sqliteError.Error(), "FOREIGN KEY")) {
+		return fmt.Errorf("%w: %v", gorm.ErrForeignKeyViolated, err)
+	}
+	return err
+}
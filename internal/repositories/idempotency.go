@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/models"
+	"gorm.io/gorm"
+)
+
+type IdempotencyRepositoryInterface interface {
+	Create(ctx context.Context, key *models.IdempotencyKey) error
+	Get(ctx context.Context, scope, key string) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, id uint, statusCode int, response string) error
+	Delete(ctx context.Context, id uint) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type IdempotencyRepository struct {
+	DB *gorm.DB
+}
+
+// Create stores a key in progress
+// It returns gorm.ErrDuplicatedKey if the key is already stored for the scope
+func (rpt *IdempotencyRepository) Create(ctx context.Context, key *models.IdempotencyKey) error {
+	return rpt.DB.WithContext(ctx).Create(key).Error
+}
+
+// Get returns the key of the scope, gorm.ErrRecordNotFound if it is not stored
+func (rpt *IdempotencyRepository) Get(ctx context.Context, scope, key string) (*models.IdempotencyKey, error) {
+	var idempotencyKey models.IdempotencyKey
+	err := rpt.DB.WithContext(ctx).
+		Where("scope = ? AND idempotency_key = ?", scope, key).
+		First(&idempotencyKey).Error
+	if err != nil {
+		return nil, err
+	}
+	return &idempotencyKey, nil
+}
+
+// Complete stores the response of a key in progress
+func (rpt *IdempotencyRepository) Complete(ctx context.Context, id uint, statusCode int, response string) error {
+	return rpt.DB.WithContext(ctx).Model(&models.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status_code": statusCode, "response": response}).Error
+}
+
+func (rpt *IdempotencyRepository) Delete(ctx context.Context, id uint) error {
+	return rpt.DB.WithContext(ctx).Delete(&models.IdempotencyKey{}, id).Error
+}
+
+// DeleteExpired deletes the keys expired before the given date, and returns their count
+func (rpt *IdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	res := rpt.DB.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.IdempotencyKey{})
+	return res.RowsAffected, res.Error
+}
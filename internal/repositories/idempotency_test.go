@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/models"
+	"gorm.io/gorm"
+)
+
+func (suite *RepositorySuiteTest) TestIdempotencyKey() {
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	key := &models.IdempotencyKey{Scope: "POST /artists anonymous:", Key: "repository key", Fingerprint: "fingerprint", ExpiresAt: expiresAt}
+	suite.Require().NoError(suite.gr.Idempotency.Create(ctx, key))
+
+	duplicate := &models.IdempotencyKey{Scope: key.Scope, Key: key.Key, Fingerprint: "other", ExpiresAt: expiresAt}
+	err := suite.gr.Idempotency.Create(ctx, duplicate)
+	suite.Assert().ErrorIs(err, gorm.ErrDuplicatedKey, "Key should be held once per scope")
+
+	otherScope := &models.IdempotencyKey{Scope: "POST /artists user:1", Key: key.Key, Fingerprint: "fingerprint", ExpiresAt: expiresAt}
+	suite.Require().NoError(suite.gr.Idempotency.Create(ctx, otherScope), "Key should be held by each scope")
+
+	err = suite.gr.Idempotency.Complete(ctx, key.ID, 200, `{"id":1}`)
+	suite.Require().NoError(err)
+	stored, err := suite.gr.Idempotency.Get(ctx, key.Scope, key.Key)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(200, stored.StatusCode, "Status code should be stored")
+	suite.Assert().Equal(`{"id":1}`, stored.Response, "Response should be stored")
+
+	suite.Require().NoError(suite.gr.Idempotency.Delete(ctx, otherScope.ID))
+	_, err = suite.gr.Idempotency.Get(ctx, otherScope.Scope, otherScope.Key)
+	suite.Assert().ErrorIs(err, gorm.ErrRecordNotFound, "Released key should be deleted")
+}
+
+func (suite *RepositorySuiteTest) TestIdempotencyDeleteExpired() {
+	ctx := context.Background()
+	now := time.Now()
+	expired := &models.IdempotencyKey{Scope: "expiration", Key: "expired", ExpiresAt: now.Add(-time.Minute)}
+	active := &models.IdempotencyKey{Scope: "expiration", Key: "active", ExpiresAt: now.Add(time.Minute)}
+	suite.Require().NoError(suite.gr.Idempotency.Create(ctx, expired))
+	suite.Require().NoError(suite.gr.Idempotency.Create(ctx, active))
+
+	deleted, err := suite.gr.Idempotency.DeleteExpired(ctx, now)
+
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Equal(int64(1), deleted, "Only the expired key should be deleted")
+	_, err = suite.gr.Idempotency.Get(ctx, active.Scope, active.Key)
+	suite.Assert().NoError(err, "Active key should be kept")
+}
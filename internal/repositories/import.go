@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/models"
+	"gorm.io/gorm"
+)
+
+type ImportJobRepositoryInterface interface {
+	Create(ctx context.Context, job *models.ImportJob) error
+	Get(ctx context.Context, id uint) (*models.ImportJob, error)
+	Update(ctx context.Context, job *models.ImportJob) error
+	Heartbeat(ctx context.Context, id uint) error
+	FailStale(ctx context.Context, before time.Time, message string) (int64, error)
+}
+
+type ImportJobRepository struct {
+	DB *gorm.DB
+}
+
+func (rpt *ImportJobRepository) Create(ctx context.Context, job *models.ImportJob) error {
+	return rpt.DB.WithContext(ctx).Create(job).Error
+}
+
+// Get returns the job, gorm.ErrRecordNotFound if it doesn't exist
+func (rpt *ImportJobRepository) Get(ctx context.Context, id uint) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := rpt.DB.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Update writes all the fields of the job
+func (rpt *ImportJobRepository) Update(ctx context.Context, job *models.ImportJob) error {
+	return rpt.DB.WithContext(ctx).Save(job).Error
+}
+
+// Heartbeat refreshes the heartbeat of a job which isn't finished, the server running it is alive
+func (rpt *ImportJobRepository) Heartbeat(ctx context.Context, id uint) error {
+	return rpt.DB.WithContext(ctx).Model(&models.ImportJob{}).
+		Where("id = ? AND status IN ?", id, []string{dto.ImportJobPending, dto.ImportJobRunning}).
+		UpdateColumn("heartbeat_at", time.Now()).Error
+}
+
+// FailStale marks the pending and running jobs without heartbeat since before as failed with the message,
+// and returns their count. A job which never had a heartbeat is stale from its creation
+// The jobs of the other servers keep their heartbeat fresh, they are not failed
+func (rpt *ImportJobRepository) FailStale(ctx context.Context, before time.Time, message string) (int64, error) {
+	result := rpt.DB.WithContext(ctx).Model(&models.ImportJob{}).
+		Where("status IN ?", []string{dto.ImportJobPending, dto.ImportJobRunning}).
+		Where("COALESCE(heartbeat_at, created_at) < ?", before).
+		Updates(map[string]interface{}{"status": dto.ImportJobFailed, "error": message, "finished_at": time.Now()})
+	return result.RowsAffected, result.Error
+}
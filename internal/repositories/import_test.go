@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/models"
+	"gorm.io/gorm"
+)
+
+func (suite *RepositorySuiteTest) TestImportJob() {
+	ctx := context.Background()
+	job := &models.ImportJob{EntityType: "artists", Status: dto.ImportJobPending}
+	suite.Require().NoError(suite.gr.ImportJob.Create(ctx, job))
+
+	finishedAt := time.Now()
+	job.Status = dto.ImportJobSucceeded
+	job.Report = `{"total":1}`
+	job.FinishedAt = &finishedAt
+	suite.Require().NoError(suite.gr.ImportJob.Update(ctx, job))
+
+	stored, err := suite.gr.ImportJob.Get(ctx, job.ID)
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Equal(dto.ImportJobSucceeded, stored.Status)
+	suite.Assert().Equal(`{"total":1}`, stored.Report)
+	suite.Assert().NotNil(stored.FinishedAt)
+
+	_, err = suite.gr.ImportJob.Get(ctx, job.ID+1)
+	suite.Assert().ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (suite *RepositorySuiteTest) TestImportJobFailStale() {
+	ctx := context.Background()
+	now := time.Now()
+	lastHeartbeat := now.Add(-10 * time.Minute)
+	stale := &models.ImportJob{EntityType: "artists", Status: dto.ImportJobRunning, HeartbeatAt: &lastHeartbeat}
+	neverStarted := &models.ImportJob{EntityType: "artists", Status: dto.ImportJobPending, CreatedAt: lastHeartbeat}
+	alive := &models.ImportJob{EntityType: "artists", Status: dto.ImportJobRunning, HeartbeatAt: &lastHeartbeat}
+	succeeded := &models.ImportJob{EntityType: "artists", Status: dto.ImportJobSucceeded, FinishedAt: &now}
+	for _, job := range []*models.ImportJob{stale, neverStarted, alive, succeeded} {
+		suite.Require().NoError(suite.gr.ImportJob.Create(ctx, job))
+	}
+	suite.Require().NoError(suite.gr.ImportJob.Heartbeat(ctx, alive.ID))
+	suite.Require().NoError(suite.gr.ImportJob.Heartbeat(ctx, succeeded.ID))
+
+	failed, err := suite.gr.ImportJob.FailStale(ctx, now.Add(-time.Minute), "interrupted")
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().GreaterOrEqual(failed, int64(2))
+
+	for _, job := range []*models.ImportJob{stale, neverStarted} {
+		stored, err := suite.gr.ImportJob.Get(ctx, job.ID)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(dto.ImportJobFailed, stored.Status, "A job without recent heartbeat should be failed")
+		suite.Assert().Equal("interrupted", stored.Error)
+		suite.Assert().NotNil(stored.FinishedAt)
+	}
+	stored, err := suite.gr.ImportJob.Get(ctx, alive.ID)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(dto.ImportJobRunning, stored.Status, "A job of a live server should be kept")
+	stored, err = suite.gr.ImportJob.Get(ctx, succeeded.ID)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(dto.ImportJobSucceeded, stored.Status, "A finished job should be kept")
+	suite.Assert().Nil(stored.HeartbeatAt, "A finished job should have no heartbeat")
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/sarrooo/go-clean/internal/database"
+	"github.com/sarrooo/go-clean/internal/models"
+)
+
+// The library entries survive the migrations rebuilding the tables, e.g. 0005 on SQLite
+func (suite *RepositorySuiteTest) TestMigrationsKeepLibrary() {
+	var before int64
+	suite.Require().NoError(suite.db.Model(&models.UserAlbum{}).Count(&before).Error)
+	suite.Require().NotZero(before, "The test fixtures have no library entry")
+
+	migrator, err := database.NewMigrator(suite.db)
+	suite.Require().NoError(err)
+	statuses, err := migrator.Status(context.Background())
+	suite.Require().NoError(err)
+	steps := 0
+	for _, status := range statuses {
+		if status.Applied && status.Version >= 5 {
+			steps++
+		}
+	}
+	_, err = migrator.Down(context.Background(), steps)
+	suite.Require().NoError(err, "Failed to revert migrations")
+	_, err = migrator.Up(context.Background())
+	suite.Require().NoError(err, "Failed to apply migrations")
+
+	var after int64
+	suite.Require().NoError(suite.db.Model(&models.UserAlbum{}).Count(&after).Error)
+	suite.Assert().Equal(before, after)
+}
@@ -1,22 +1,44 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 )
 
 type GlobalRepository struct {
-	User   UserRepositoryInterface
-	Artist ArtistRepositoryInterface
+	User        UserRepositoryInterface
+	Artist      ArtistRepositoryInterface
+	Trash       TrashRepositoryInterface
+	Audit       AuditRepositoryInterface
+	Idempotency IdempotencyRepositoryInterface
+	Search      SearchRepositoryInterface
+	ImportJob   ImportJobRepositoryInterface
 
 	// Add new repository here
+
+	// Transaction opens transactions spanning all the repositories above
+	Transaction TransactionManagerInterface
 }
 
 func NewGlobalRepository(DB *gorm.DB) *GlobalRepository {
 	gr := &GlobalRepository{
-		User:   &UserRepository{DB: DB},
-		Artist: &ArtistRepository{DB: DB},
+		User:        &UserRepository{DB: DB},
+		Artist:      &ArtistRepository{DB: DB},
+		Trash:       &TrashRepository{DB: DB},
+		Audit:       &AuditRepository{DB: DB},
+		Idempotency: &IdempotencyRepository{DB: DB},
+		Search:      &SearchRepository{DB: DB},
+		ImportJob:   &ImportJobRepository{DB: DB},
 
 		// Add new repository here
+
+		Transaction: &TransactionManager{DB: DB},
 	}
 	return gr
 }
+
+// WithinTx runs fn inside a transaction, see TransactionManager.WithinTx
+func (gr *GlobalRepository) WithinTx(ctx context.Context, fn func(repos *GlobalRepository) error) error {
+	return gr.Transaction.WithinTx(ctx, fn)
+}
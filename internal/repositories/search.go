@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"gorm.io/gorm"
+)
+
+type SearchRepositoryInterface interface {
+	Search(ctx context.Context, query *dto.SearchQuery, params *pagination.Params) (*pagination.Page[*dto.SearchResult], error)
+}
+
+// SearchRepository searches the names indexed by the create_search_indexes migration
+type SearchRepository struct {
+	DB *gorm.DB
+}
+
+// searchResult is a row of the search_results subquery
+// entity_id is not unique across entities, it is the primary key for the pagination
+// which sorts by entity_type first
+type searchResult struct {
+	EntityType string
+	EntityID   uint `gorm:"primaryKey"`
+	Name       string
+	ArtistID   *uint
+	ArtistName *string
+	Score      float64
+}
+
+// searchEntity is a searched table
+type searchEntity struct {
+	Type  string
+	Table string
+	// Columns of the artist of the entity, and the join selecting it
+	Artist     string
+	ArtistJoin string
+}
+
+var searchEntities = []searchEntity{
+	{
+		Type:   dto.SearchTypeArtists,
+		Table:  "artists",
+		Artist: "NULL AS artist_id, NULL AS artist_name",
+	},
+	{
+		Type:       dto.SearchTypeAlbums,
+		Table:      "albums",
+		Artist:     "artists.id AS artist_id, artists.name AS artist_name",
+		ArtistJoin: "JOIN artists ON artists.id = albums.artist_id AND artists.deleted_at IS NULL",
+	},
+}
+
+// searchMatch is how a dialect matches and scores the names of a table
+// Its SQL is written in the order of the query: Score, Join then Where
+type searchMatch struct {
+	Score string
+	Join  string
+	Where string
+	Vars  []interface{}
+}
+
+// Search returns a page of the entities whose name matches all the terms, the most relevant first
+// The page is found by number, the scores can't be compared by cursors
+func (rpt *SearchRepository) Search(ctx context.Context, query *dto.SearchQuery, params *pagination.Params) (*pagination.Page[*dto.SearchResult], error) {
+	db := rpt.DB.WithContext(ctx)
+	match := searchMatcherOf(db)
+
+	branches := make([]string, 0, len(searchEntities))
+	var vars []interface{}
+	for _, entity := range searchEntities {
+		if len(query.Types) != 0 && !slices.Contains(query.Types, entity.Type) {
+			continue
+		}
+		m := match(entity.Table, query.Terms)
+		branches = append(branches, fmt.Sprintf(
+			"SELECT '%[1]s' AS entity_type, %[2]s.id AS entity_id, %[2]s.name AS name, %[3]s, %[4]s AS score FROM %[2]s %[5]s %[6]s WHERE %[2]s.deleted_at IS NULL AND (%[7]s)",
+			entity.Type, entity.Table, entity.Artist, m.Score, m.Join, entity.ArtistJoin, m.Where,
+		))
+		vars = append(vars, m.Vars...)
+	}
+	if len(branches) == 0 {
+		return nil, fmt.Errorf("unknown search types %v", query.Types)
+	}
+
+	sorted := *params
+	sorted.Sort = []pagination.Order{{Column: "score", Desc: true}, {Column: "entity_type"}, {Column: "entity_id"}}
+	if sorted.Page == 0 {
+		sorted.Page = 1
+	}
+	results := db.Table("(?) AS search_results", gorm.Expr(strings.Join(branches, " UNION ALL "), vars...))
+	page, err := pagination.Find[*searchResult](results, &sorted)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*dto.SearchResult, 0, len(page.Items))
+	for _, result := range page.Items {
+		items = append(items, &dto.SearchResult{
+			Type:       result.EntityType,
+			ID:         result.EntityID,
+			Name:       result.Name,
+			ArtistID:   result.ArtistID,
+			ArtistName: result.ArtistName,
+			Score:      result.Score,
+		})
+	}
+	return &pagination.Page[*dto.SearchResult]{Items: items, Info: page.Info}, nil
+}
+
+// searchMatcherOf returns the matcher of the dialect of the database
+// SQLite uses FTS5 if its tables were created, LIKE otherwise
+func searchMatcherOf(db *gorm.DB) func(table string, terms []string) searchMatch {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return postgresSearchMatch
+	case "sqlite":
+		if db.Migrator().HasTable("artists_search") {
+			return sqliteSearchMatch
+		}
+		return likeSearchMatch
+	default:
+		return likeSearchMatch
+	}
+}
+
+// postgresSearchMatch matches the names starting with the terms (full-text)
+// or containing them (trigram), and the similar names to tolerate typos
+func postgresSearchMatch(table string, terms []string) searchMatch {
+	folded := fmt.Sprintf("search_fold(%s.name)", table)
+	vector := fmt.Sprintf("to_tsvector('simple', %s)", folded)
+	prefixes := make([]string, 0, len(terms))
+	substrings := make([]string, 0, len(terms))
+	var vars []interface{}
+	for _, term := range terms {
+		prefixes = append(prefixes, term+":*")
+		substrings = append(substrings, folded+" LIKE ?")
+		vars = append(vars, "%"+term+"%")
+	}
+	tsquery := strings.Join(prefixes, " & ")
+	text := strings.Join(terms, " ")
+	return searchMatch{
+		Score: fmt.Sprintf("ts_rank(%s, to_tsquery('simple', ?)) + similarity(%s, ?)", vector, folded),
+		Where: fmt.Sprintf("%s @@ to_tsquery('simple', ?) OR %s %% ? OR (%s)", vector, folded, strings.Join(substrings, " AND ")),
+		Vars:  append([]interface{}{tsquery, text, tsquery, text}, vars...),
+	}
+}
+
+// sqliteSearchMatch matches the names with words starting with the terms
+func sqliteSearchMatch(table string, terms []string) searchMatch {
+	prefixes := make([]string, 0, len(terms))
+	for _, term := range terms {
+		prefixes = append(prefixes, `"`+term+`"*`)
+	}
+	return searchMatch{
+		Score: fmt.Sprintf("-bm25(%s_search)", table),
+		Join:  fmt.Sprintf("JOIN %[1]s_search ON %[1]s_search.rowid = %[1]s.id", table),
+		Where: fmt.Sprintf("%s_search MATCH ?", table),
+		Vars:  []interface{}{strings.Join(prefixes, " ")},
+	}
+}
+
+// likeSearchMatch matches the names containing the terms, exact then prefix matches first
+// Accents are ignored by the accent insensitive collations of MySQL, not by SQLite
+func likeSearchMatch(table string, terms []string) searchMatch {
+	name := fmt.Sprintf("LOWER(%s.name)", table)
+	substrings := make([]string, 0, len(terms))
+	var vars []interface{}
+	for _, term := range terms {
+		substrings = append(substrings, name+" LIKE ?")
+		vars = append(vars, "%"+term+"%")
+	}
+	text := strings.Join(terms, " ")
+	return searchMatch{
+		Score: fmt.Sprintf("CASE WHEN %[1]s = ? THEN 3 WHEN %[1]s LIKE ? THEN 2 ELSE 1 END", name),
+		Where: strings.Join(substrings, " AND "),
+		Vars:  append([]interface{}{text, text + "%"}, vars...),
+	}
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/pagination"
+)
+
+func (suite *RepositorySuiteTest) TestSearch() {
+	ctx := context.Background()
+	blue, albums := suite.createArtistWithAlbums("Qwzartist Blue", "Qwzalbum Blue Moon")
+	red := &models.Artist{Name: "Qwzartist Reed"}
+	suite.Require().NoError(suite.gr.Artist.Create(ctx, red))
+	// The index follows the updates
+	red.Name = "Qwzartist Red"
+	suite.Require().NoError(suite.gr.Artist.Update(ctx, red))
+	gone := &models.Artist{Name: "Qwzartist Gone"}
+	suite.Require().NoError(suite.gr.Artist.Create(ctx, gone))
+	suite.Require().NoError(suite.db.Delete(gone).Error)
+	params := &pagination.Params{Limit: pagination.MaxLimit}
+
+	type result struct {
+		Type string
+		ID   uint
+	}
+	tests := map[string]struct {
+		query    *dto.SearchQuery
+		expected []result
+	}{
+		"All types": {
+			query: &dto.SearchQuery{Terms: []string{"qwz"}},
+			expected: []result{
+				{dto.SearchTypeArtists, blue.ID},
+				{dto.SearchTypeArtists, red.ID},
+				{dto.SearchTypeAlbums, albums[0].ID},
+			},
+		},
+		"Every term": {
+			query:    &dto.SearchQuery{Terms: []string{"qwz", "blue"}},
+			expected: []result{{dto.SearchTypeArtists, blue.ID}, {dto.SearchTypeAlbums, albums[0].ID}},
+		},
+		"Updated": {
+			query:    &dto.SearchQuery{Terms: []string{"qwzartist", "red"}},
+			expected: []result{{dto.SearchTypeArtists, red.ID}},
+		},
+		"Types": {
+			query:    &dto.SearchQuery{Terms: []string{"qwz"}, Types: []string{dto.SearchTypeAlbums}},
+			expected: []result{{dto.SearchTypeAlbums, albums[0].ID}},
+		},
+		"Nothing": {
+			query:    &dto.SearchQuery{Terms: []string{"qwzunknown"}},
+			expected: []result{},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			page, err := suite.gr.Search.Search(ctx, test.query, params)
+
+			suite.Require().NoError(err, "No error should have occurred")
+			results := make([]result, 0, len(page.Items))
+			for _, item := range page.Items {
+				results = append(results, result{item.Type, item.ID})
+			}
+			suite.Assert().ElementsMatch(test.expected, results)
+		})
+	}
+
+	suite.Run("Album artist", func() {
+		page, err := suite.gr.Search.Search(ctx, &dto.SearchQuery{Terms: []string{"qwzalbum"}}, params)
+
+		suite.Require().NoError(err, "No error should have occurred")
+		suite.Require().Len(page.Items, 1)
+		suite.Assert().Equal("Qwzalbum Blue Moon", page.Items[0].Name)
+		suite.Require().NotNil(page.Items[0].ArtistID)
+		suite.Assert().Equal(blue.ID, *page.Items[0].ArtistID)
+		suite.Assert().Equal("Qwzartist Blue", *page.Items[0].ArtistName)
+	})
+
+	suite.Run("Pages", func() {
+		page, err := suite.gr.Search.Search(ctx, &dto.SearchQuery{Terms: []string{"qwz"}}, &pagination.Params{Limit: 1, Page: 2})
+
+		suite.Require().NoError(err, "No error should have occurred")
+		suite.Assert().Len(page.Items, 1)
+		suite.Require().NotNil(page.Total)
+		suite.Assert().Equal(int64(3), *page.Total)
+		suite.Assert().Equal(3, page.NextNumber)
+		suite.Assert().Equal(1, page.PrevNumber)
+	})
+}
+
+func (suite *RepositorySuiteTest) TestSearchRank() {
+	ctx := context.Background()
+	for _, name := range []string{"Qwzrank Tribute Band", "Qwzrank"} {
+		suite.Require().NoError(suite.gr.Artist.Create(ctx, &models.Artist{Name: name}))
+	}
+
+	page, err := suite.gr.Search.Search(ctx, &dto.SearchQuery{Terms: []string{"qwzrank"}}, &pagination.Params{Limit: 10})
+
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Require().Len(page.Items, 2)
+	suite.Assert().Equal("Qwzrank", page.Items[0].Name, "The exact match should be first")
+	suite.Assert().GreaterOrEqual(page.Items[0].Score, page.Items[1].Score)
+}
+
+func (suite *RepositorySuiteTest) TestSearchAccents() {
+	if suite.db.Dialector.Name() == "sqlite" && !suite.db.Migrator().HasTable("artists_search") {
+		suite.T().Skip("SQLite was built without FTS5, the LIKE search doesn't ignore accents")
+	}
+	ctx := context.Background()
+	artist := &models.Artist{Name: "Qwzéphyr Cœur"}
+	suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+
+	page, err := suite.gr.Search.Search(ctx, &dto.SearchQuery{Terms: []string{"qwzeph"}}, &pagination.Params{Limit: 10})
+
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Require().Len(page.Items, 1)
+	suite.Assert().Equal(artist.ID, page.Items[0].ID)
+}
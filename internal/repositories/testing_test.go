@@ -1,19 +1,85 @@
 package repositories
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sarrooo/go-clean/internal/database"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-var testDB *gorm.DB
+// Databases the repository suite runs on
+// SQLite always runs, Postgres and MySQL run when TEST_POSTGRES_DSN and TEST_MYSQL_DSN are defined
+var testDatabases = map[string]func(t *testing.T) gorm.Dialector{
+	"sqlite memory": func(t *testing.T) gorm.Dialector {
+		return sqlite.Open("file:repositories?mode=memory&cache=shared&_foreign_keys=1")
+	},
+	"sqlite file": func(t *testing.T) gorm.Dialector {
+		return sqlite.Open(fmt.Sprintf("file:%s?_foreign_keys=1&_busy_timeout=5000", filepath.Join(t.TempDir(), "test.db")))
+	},
+	"postgres": func(t *testing.T) gorm.Dialector {
+		if dsn := os.Getenv("TEST_POSTGRES_DSN"); dsn != "" {
+			return postgres.Open(dsn)
+		}
+		return nil
+	},
+	"mysql": func(t *testing.T) gorm.Dialector {
+		if dsn := os.Getenv("TEST_MYSQL_DSN"); dsn != "" {
+			return mysql.Open(dsn)
+		}
+		return nil
+	},
+}
+
+type RepositorySuiteTest struct {
+	suite.Suite
+	dialector gorm.Dialector
+	db        *gorm.DB
+	gr        *GlobalRepository
+	fixtures  database.FixtureRefs
+}
+
+// Open the database, revert the migrations left by a previous run, apply them and load the test fixtures
+func (suite *RepositorySuiteTest) SetupSuite() {
+	db, err := database.OpenTestingDB(suite.dialector)
+	suite.Require().NoError(err, "Failed to open database")
+	migrator, err := database.NewMigrator(db)
+	suite.Require().NoError(err)
+	statuses, err := migrator.Status(context.Background())
+	suite.Require().NoError(err)
+	_, err = migrator.Down(context.Background(), len(statuses))
+	suite.Require().NoError(err, "Failed to revert migrations")
+	_, err = migrator.Up(context.Background())
+	suite.Require().NoError(err, "Failed to apply migrations")
 
-func TestMain(m *testing.M) {
-	testDB = database.TestingSqliteDB()
+	suite.fixtures, err = database.LoadFixtures(context.Background(), db, "test")
+	suite.Require().NoError(err, "Failed to load fixtures")
 
-	database.CreateTestingEntities(testDB)
+	suite.db = db
+	suite.gr = NewGlobalRepository(db)
+}
+
+func (suite *RepositorySuiteTest) TearDownSuite() {
+	if sqlDB, err := suite.db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
 
-	os.Exit(m.Run())
+func TestRepositorySuite(t *testing.T) {
+	for name, dialector := range testDatabases {
+		t.Run(name, func(t *testing.T) {
+			dialector := dialector(t)
+			if dialector == nil {
+				t.Skipf("%s is not configured", name)
+			}
+			suite.Run(t, &RepositorySuiteTest{dialector: dialector})
+		})
+	}
 }
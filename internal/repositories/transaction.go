@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/sarrooo/go-clean/internal/cache"
+	"gorm.io/gorm"
+)
+
+type TransactionManagerInterface interface {
+	WithinTx(ctx context.Context, fn func(repos *GlobalRepository) error) error
+}
+
+type TransactionManager struct {
+	DB *gorm.DB
+
+	// Cache of the cached repositories, invalidated by the writes of the transaction when it ends
+	// It is nil when repositories are not cached
+	Cache cache.Cache
+
+	// Invalidation counters of the cached repositories, see cacheGenerations
+	// Without them, e.g. a manager created outside NewCachedGlobalRepository, no load is invalidated
+	generations *cacheGenerations
+
+	// Set in a transaction, the keys written by nested transactions are invalidated by the outermost one
+	invalidations *cacheInvalidations
+}
+
+// WithinTx runs fn inside a database transaction
+// fn receives a GlobalRepository whose repositories are all bound to the transaction,
+// the transaction is committed if fn returns nil and rolled back otherwise
+// Calling WithinTx on the received GlobalRepository opens a nested transaction using a savepoint,
+// so an error in the nested call only rolls back the work done since the savepoint
+func (tm *TransactionManager) WithinTx(ctx context.Context, fn func(repos *GlobalRepository) error) error {
+	if tm.Cache == nil {
+		return tm.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(NewGlobalRepository(tx))
+		})
+	}
+
+	invalidations := tm.invalidations
+	if invalidations == nil {
+		invalidations = &cacheInvalidations{}
+		defer invalidations.flush(ctx, tm.Cache, tm.generations)
+	}
+	return tm.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repos := NewGlobalRepository(tx)
+		repos.Artist = &CachedArtistRepository{
+			ArtistRepositoryInterface: repos.Artist,
+			cache:                     tm.Cache,
+			generations:               tm.generations,
+			invalidations:             invalidations,
+		}
+
+		// Add new cached repository here
+
+		repos.Transaction = &TransactionManager{DB: tx, Cache: tm.Cache, generations: tm.generations, invalidations: invalidations}
+		return fn(repos)
+	})
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sarrooo/go-clean/internal/models"
+)
+
+func (suite *RepositorySuiteTest) TestWithinTx() {
+	errRollback := errors.New("rollback")
+
+	type expectedType struct {
+		err     error
+		created []string
+		missing []string
+	}
+
+	tests := map[string]struct {
+		fn       func(repos *GlobalRepository) error
+		expected expectedType
+	}{
+		"Commit": {
+			fn: func(repos *GlobalRepository) error {
+				return repos.Artist.Create(context.Background(), &models.Artist{Name: "tx commit"})
+			},
+			expected: expectedType{
+				created: []string{"tx commit"},
+			},
+		},
+		"Rollback": {
+			fn: func(repos *GlobalRepository) error {
+				if err := repos.Artist.Create(context.Background(), &models.Artist{Name: "tx rollback"}); err != nil {
+					return err
+				}
+				return errRollback
+			},
+			expected: expectedType{
+				err:     errRollback,
+				missing: []string{"tx rollback"},
+			},
+		},
+		"Nested rollback to savepoint": {
+			fn: func(repos *GlobalRepository) error {
+				if err := repos.Artist.Create(context.Background(), &models.Artist{Name: "tx outer"}); err != nil {
+					return err
+				}
+				err := repos.WithinTx(context.Background(), func(nested *GlobalRepository) error {
+					if err := nested.Artist.Create(context.Background(), &models.Artist{Name: "tx inner"}); err != nil {
+						return err
+					}
+					return errRollback
+				})
+				if !errors.Is(err, errRollback) {
+					return errors.New("nested error should be returned")
+				}
+				return nil
+			},
+			expected: expectedType{
+				created: []string{"tx outer"},
+				missing: []string{"tx inner"},
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			err := suite.gr.WithinTx(context.Background(), test.fn)
+			if test.expected.err != nil {
+				suite.Assert().True(errors.Is(err, test.expected.err), "Error type should match")
+			} else {
+				suite.Assert().NoError(err, "No error should have occurred")
+			}
+
+			for _, name := range test.expected.created {
+				var count int64
+				suite.db.Model(&models.Artist{}).Where("name = ?", name).Count(&count)
+				suite.Assert().Equal(int64(1), count, "Artist %s should be committed", name)
+			}
+			for _, name := range test.expected.missing {
+				var count int64
+				suite.db.Model(&models.Artist{}).Where("name = ?", name).Count(&count)
+				suite.Assert().Zero(count, "Artist %s should be rolled back", name)
+			}
+		})
+	}
+}
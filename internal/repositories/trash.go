@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/models"
+	"gorm.io/gorm"
+)
+
+type TrashRepositoryInterface interface {
+	List(ctx context.Context, entity string) ([]*dto.TrashItem, error)
+	Restore(ctx context.Context, entity string, id uint) error
+	Purge(ctx context.Context, entity string, id uint) error
+	ListDeletedBefore(ctx context.Context, entity string, before time.Time) ([]uint, error)
+}
+
+// TrashRepository manages soft deleted rows
+// Its queries are unscoped, they see the rows filtered out by GORM
+type TrashRepository struct {
+	DB *gorm.DB
+}
+
+// trashModels lists the models of the trash entities
+func trashModels() map[string]interface{} {
+	return map[string]interface{}{
+		dto.TrashEntityArtists: &models.Artist{},
+		dto.TrashEntityAlbums:  &models.Album{},
+	}
+}
+
+func trashModel(entity string) (interface{}, error) {
+	model, ok := trashModels()[entity]
+	if !ok {
+		return nil, fmt.Errorf("unknown trash entity %s", entity)
+	}
+	return model, nil
+}
+
+// List returns the soft deleted rows, most recently deleted first
+func (rpt *TrashRepository) List(ctx context.Context, entity string) ([]*dto.TrashItem, error) {
+	model, err := trashModel(entity)
+	if err != nil {
+		return nil, err
+	}
+	items := []*dto.TrashItem{}
+	err = rpt.DB.WithContext(ctx).Unscoped().Model(model).
+		Select("id", "name", "deleted_at").
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Restore undeletes a soft deleted row
+// If the row is not in the trash, returns gorm.ErrRecordNotFound
+func (rpt *TrashRepository) Restore(ctx context.Context, entity string, id uint) error {
+	model, err := trashModel(entity)
+	if err != nil {
+		return err
+	}
+	res := rpt.DB.WithContext(ctx).Unscoped().Model(model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purge permanently deletes a soft deleted row, the library entries of an album are deleted with it
+// If the row is not in the trash, returns gorm.ErrRecordNotFound
+// If other rows reference it, returns gorm.ErrForeignKeyViolated
+func (rpt *TrashRepository) Purge(ctx context.Context, entity string, id uint) error {
+	model, err := trashModel(entity)
+	if err != nil {
+		return err
+	}
+	res := rpt.DB.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Delete(model, id)
+	if res.Error != nil {
+		return translateError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListDeletedBefore returns the IDs of the rows soft deleted before the given date
+func (rpt *TrashRepository) ListDeletedBefore(ctx context.Context, entity string, before time.Time) ([]uint, error) {
+	model, err := trashModel(entity)
+	if err != nil {
+		return nil, err
+	}
+	ids := []uint{}
+	err = rpt.DB.WithContext(ctx).Unscoped().Model(model).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("id").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
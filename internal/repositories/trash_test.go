@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/models"
+	"gorm.io/gorm"
+)
+
+func (suite *RepositorySuiteTest) TestTrashList() {
+	ctx := context.Background()
+	artist := &models.Artist{Name: "trash listed"}
+	suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+	suite.Require().NoError(suite.gr.Artist.Delete(ctx, artist.ID, 0))
+
+	items, err := suite.gr.Trash.List(ctx, dto.TrashEntityArtists)
+
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Require().NotEmpty(items, "Deleted artist should be listed")
+	suite.Assert().Equal(artist.ID, items[0].ID, "Most recently deleted artist should be first")
+	suite.Assert().Equal("trash listed", items[0].Name)
+	suite.Assert().False(items[0].DeletedAt.IsZero(), "Deletion date should be set")
+
+	_, err = suite.gr.Trash.List(ctx, "users")
+	suite.Assert().Error(err, "Unknown entity should fail")
+}
+
+func (suite *RepositorySuiteTest) TestTrashRestore() {
+	ctx := context.Background()
+	artistID := suite.fixtures["Artist.eminem"]
+
+	tests := map[string]struct {
+		entity   string
+		setup    func() uint
+		expected error
+	}{
+		"Restore deleted artist": {
+			entity: dto.TrashEntityArtists,
+			setup: func() uint {
+				artist := &models.Artist{Name: "trash restored"}
+				suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+				suite.Require().NoError(suite.gr.Artist.Delete(ctx, artist.ID, 0))
+				return artist.ID
+			},
+		},
+		"Active artist is not in the trash": {
+			entity: dto.TrashEntityArtists,
+			setup: func() uint {
+				return artistID
+			},
+			expected: gorm.ErrRecordNotFound,
+		},
+		"Unique key taken by an active album": {
+			entity: dto.TrashEntityAlbums,
+			setup: func() uint {
+				deleted := &models.Album{Name: "trash duplicated", ArtistID: artistID}
+				suite.Require().NoError(suite.db.Create(deleted).Error)
+				suite.Require().NoError(suite.db.Delete(deleted).Error)
+				// The partial unique index ignores the deleted album
+				suite.Require().NoError(suite.db.Create(&models.Album{Name: "trash duplicated", ArtistID: artistID}).Error)
+				return deleted.ID
+			},
+			expected: gorm.ErrDuplicatedKey,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			id := test.setup()
+
+			err := suite.gr.Trash.Restore(ctx, test.entity, id)
+
+			if test.expected != nil {
+				suite.Assert().ErrorIs(err, test.expected, "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			_, err = suite.gr.Artist.GetByID(ctx, id)
+			suite.Assert().NoError(err, "Restored artist should be found")
+		})
+	}
+}
+
+func (suite *RepositorySuiteTest) TestTrashPurge() {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		setup   func() uint
+		isError bool
+	}{
+		"Purge deleted artist": {
+			setup: func() uint {
+				artist := &models.Artist{Name: "trash purged"}
+				suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+				suite.Require().NoError(suite.gr.Artist.Delete(ctx, artist.ID, 0))
+				return artist.ID
+			},
+		},
+		"Active artist is not purged": {
+			setup: func() uint {
+				return suite.fixtures["Artist.drake"]
+			},
+			isError: true,
+		},
+		"Artist referenced by an album": {
+			setup: func() uint {
+				artist := &models.Artist{Name: "trash referenced"}
+				suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+				suite.Require().NoError(suite.db.Create(&models.Album{Name: "trash album", ArtistID: artist.ID}).Error)
+				suite.Require().NoError(suite.gr.Artist.Delete(ctx, artist.ID, 0))
+				return artist.ID
+			},
+			isError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			id := test.setup()
+
+			err := suite.gr.Trash.Purge(ctx, dto.TrashEntityArtists, id)
+
+			if test.isError {
+				suite.Assert().Error(err, "Error should have occurred")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			var count int64
+			suite.db.Unscoped().Model(&models.Artist{}).Where("id = ?", id).Count(&count)
+			suite.Assert().Zero(count, "Purged artist should be deleted")
+		})
+	}
+}
+
+func (suite *RepositorySuiteTest) TestTrashListDeletedBefore() {
+	ctx := context.Background()
+	old := &models.Artist{Name: "trash old"}
+	recent := &models.Artist{Name: "trash recent"}
+	for _, artist := range []*models.Artist{old, recent} {
+		suite.Require().NoError(suite.gr.Artist.Create(ctx, artist))
+		suite.Require().NoError(suite.gr.Artist.Delete(ctx, artist.ID, 0))
+	}
+	suite.Require().NoError(suite.db.Unscoped().Model(old).Update("deleted_at", time.Now().Add(-48*time.Hour)).Error)
+
+	ids, err := suite.gr.Trash.ListDeletedBefore(ctx, dto.TrashEntityArtists, time.Now().Add(-24*time.Hour))
+
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().Contains(ids, old.ID, "Artist deleted before the date should be listed")
+	suite.Assert().NotContains(ids, recent.ID, "Artist deleted after the date should not be listed")
+}
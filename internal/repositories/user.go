@@ -1,36 +1,50 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/sarrooo/go-clean/internal/models"
 	"gorm.io/gorm"
 )
 
 type UserRepositoryInterface interface {
-	Create(user *models.User) (err error)
-	GetByEmail(email string) (user *models.User, err error)
-	UpdateColumns(user *gorm.Model) (err error)
+	Create(ctx context.Context, user *models.User) (err error)
+	GetByEmail(ctx context.Context, email string) (user *models.User, err error)
+	GetByID(ctx context.Context, id uint) (user *models.User, err error)
+	UpdateColumns(ctx context.Context, user *models.User) (err error)
 }
 
 type UserRepository struct {
 	DB *gorm.DB
 }
 
-func (rpt *UserRepository) Create(user *models.User) (err error) {
-	return rpt.DB.Create(user).Error
+func (rpt *UserRepository) Create(ctx context.Context, user *models.User) (err error) {
+	return rpt.DB.WithContext(ctx).Create(user).Error
 }
 
 // GetByEmail returns user by email
 // If user not found, returns nil
 // If error occurred, returns error
-func (rpt *UserRepository) GetByEmail(email string) (user *models.User, err error) {
+func (rpt *UserRepository) GetByEmail(ctx context.Context, email string) (user *models.User, err error) {
+	user = &models.User{}
+	err = rpt.DB.WithContext(ctx).Where("email = ?", email).Limit(1).Find(user).Error
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetByID returns user by ID
+// If user not found, returns an empty user
+func (rpt *UserRepository) GetByID(ctx context.Context, id uint) (user *models.User, err error) {
 	user = &models.User{}
-	err = rpt.DB.Where("email = ?", email).Limit(1).Find(user).Error
+	err = rpt.DB.WithContext(ctx).Where("id = ?", id).Limit(1).Find(user).Error
 	if err != nil {
 		return nil, err
 	}
 	return user, nil
 }
 
-func (rpt *UserRepository) UpdateColumns(user *gorm.Model) (err error) {
-	return rpt.DB.Model(user).Updates(user).Error
+func (rpt *UserRepository) UpdateColumns(ctx context.Context, user *models.User) (err error) {
+	return rpt.DB.WithContext(ctx).Model(user).Updates(user).Error
 }
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/sarrooo/go-clean/internal/models"
+)
+
+func (suite *RepositorySuiteTest) TestUserGetByEmail() {
+	tests := map[string]struct {
+		email    string
+		expected uint
+	}{
+		"Existing user": {
+			email:    "jhon.doe@mail.com",
+			expected: suite.fixtures["User.john"],
+		},
+		"Unknown user": {
+			email:    "unknown@mail.com",
+			expected: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			user, err := suite.gr.User.GetByEmail(context.Background(), test.email)
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expected, user.ID, "User ID should match")
+		})
+	}
+}
+
+func (suite *RepositorySuiteTest) TestUserCreate() {
+	ctx := context.Background()
+	user := &models.User{
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Email:     "jane.doe@mail.com",
+		Password:  "hash",
+	}
+
+	err := suite.gr.User.Create(ctx, user)
+	suite.Require().NoError(err, "No error should have occurred")
+	suite.Assert().NotZero(user.ID, "Created user should have an ID")
+
+	err = suite.gr.User.Create(ctx, &models.User{Email: "jane.doe@mail.com"})
+	suite.Assert().Error(err, "Email should be unique")
+}
+
+func (suite *RepositorySuiteTest) TestUserUpdateColumns() {
+	ctx := context.Background()
+	user := &models.User{FirstName: "Jack", Email: "jack.doe@mail.com"}
+	suite.Require().NoError(suite.gr.User.Create(ctx, user))
+
+	err := suite.gr.User.UpdateColumns(ctx, &models.User{Model: models.Model{ID: user.ID}, Phone: "0600000000"})
+	suite.Require().NoError(err, "No error should have occurred")
+
+	updated, err := suite.gr.User.GetByEmail(ctx, "jack.doe@mail.com")
+	suite.Require().NoError(err)
+	suite.Assert().Equal("0600000000", updated.Phone, "Phone should be updated")
+	suite.Assert().Equal("Jack", updated.FirstName, "Other columns should be kept")
+}
@@ -0,0 +1,125 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxTerms is the maximum number of words of a search, the next ones are ignored
+const MaxTerms = 8
+
+// Markers of the matched terms in a highlight
+const (
+	MarkOpen  = "<mark>"
+	MarkClose = "</mark>"
+)
+
+// Letters which are not decomposed into a letter and an accent
+var ligatures = map[rune]string{
+	'æ': "ae",
+	'œ': "oe",
+	'ø': "o",
+	'ß': "ss",
+}
+
+// foldRune returns the rune in lower case, without accent
+func foldRune(r rune) string {
+	r = unicode.ToLower(r)
+	if ligature, ok := ligatures[r]; ok {
+		return ligature
+	}
+	if r < utf8.RuneSelf {
+		return string(r)
+	}
+	var folded strings.Builder
+	for _, decomposed := range norm.NFD.String(string(r)) {
+		if !unicode.Is(unicode.Mn, decomposed) {
+			folded.WriteRune(decomposed)
+		}
+	}
+	return folded.String()
+}
+
+// Fold returns the text in lower case, without accents, e.g. "Céline Œuvre" becomes "celine oeuvre"
+func Fold(text string) string {
+	var folded strings.Builder
+	for _, r := range text {
+		folded.WriteString(foldRune(r))
+	}
+	return folded.String()
+}
+
+// Terms returns the distinct folded words of the text
+// Words are made of letters and digits, so they can be used in full-text and LIKE patterns as is
+func Terms(text string) []string {
+	words := strings.FieldsFunc(Fold(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+		if len(terms) == MaxTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// Highlight returns the HTML escaped text with the terms marked, e.g. "<mark>Stro</mark>mae"
+// Terms are matched on the folded text, so "celine" marks "Céline"
+func Highlight(text string, terms []string) string {
+	// Bytes of the text where each byte of the folded text starts and ends
+	var folded strings.Builder
+	var starts, ends []int
+	for i, r := range text {
+		foldedRune := foldRune(r)
+		for j := 0; j < len(foldedRune); j++ {
+			starts = append(starts, i)
+			ends = append(ends, i+utf8.RuneLen(r))
+		}
+		folded.WriteString(foldedRune)
+	}
+
+	marked := make([]bool, len(text))
+	foldedText := folded.String()
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		for offset := 0; offset < len(foldedText); {
+			index := strings.Index(foldedText[offset:], term)
+			if index < 0 {
+				break
+			}
+			start, end := offset+index, offset+index+len(term)
+			for i := starts[start]; i < ends[end-1]; i++ {
+				marked[i] = true
+			}
+			offset = end
+		}
+	}
+
+	var highlight strings.Builder
+	for i := 0; i < len(text); {
+		j := i
+		for j < len(text) && marked[j] == marked[i] {
+			j++
+		}
+		if marked[i] {
+			highlight.WriteString(MarkOpen + html.EscapeString(text[i:j]) + MarkClose)
+		} else {
+			highlight.WriteString(html.EscapeString(text[i:j]))
+		}
+		i = j
+	}
+	return highlight.String()
+}
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		expected string
+	}{
+		"ASCII":     {text: "Stromae", expected: "stromae"},
+		"Accents":   {text: "Céline Dion à Noël", expected: "celine dion a noel"},
+		"Ligatures": {text: "Cœur ÆØ", expected: "coeur aeo"},
+		"Others":    {text: "Ça-va 2", expected: "ca-va 2"},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, Fold(test.text))
+		})
+	}
+}
+
+func TestTerms(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		expected []string
+	}{
+		"Words":       {text: "  Édith   PIAF ", expected: []string{"edith", "piaf"}},
+		"Punctuation": {text: "l'amour%_ à \"la\" plage!", expected: []string{"l", "amour", "a", "la", "plage"}},
+		"Duplicates":  {text: "la la LÀ land", expected: []string{"la", "land"}},
+		"Nothing":     {text: "%!?", expected: []string{}},
+		"Too many":    {text: "a b c d e f g h i j", expected: []string{"a", "b", "c", "d", "e", "f", "g", "h"}},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, Terms(test.text))
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		terms    []string
+		expected string
+	}{
+		"Prefix":      {text: "Stromae", terms: []string{"stro"}, expected: "<mark>Stro</mark>mae"},
+		"Accents":     {text: "Céline Dion", terms: []string{"celine"}, expected: "<mark>Céline</mark> Dion"},
+		"Ligature":    {text: "Cœur de pirate", terms: []string{"coe"}, expected: "<mark>Cœ</mark>ur de pirate"},
+		"Several":     {text: "Édith Piaf", terms: []string{"piaf", "ed"}, expected: "<mark>Éd</mark>ith <mark>Piaf</mark>"},
+		"Occurrences": {text: "La la land", terms: []string{"la"}, expected: "<mark>La</mark> <mark>la</mark> <mark>la</mark>nd"},
+		"Overlap":     {text: "Abba", terms: []string{"ab", "bba"}, expected: "<mark>Abba</mark>"},
+		"Escaped":     {text: "<Tom & Jerry>", terms: []string{"tom"}, expected: "&lt;<mark>Tom</mark> &amp; Jerry&gt;"},
+		"No match":    {text: "Daft Punk", terms: []string{"zz"}, expected: "Daft Punk"},
+		"Cyrillic":    {text: "Жанна Агузарова", terms: Terms("агузар"), expected: "Жанна <mark>Агузар</mark>ова"},
+		"Ideograms":   {text: "東京事変", terms: Terms("東京"), expected: "<mark>東京</mark>事変"},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, Highlight(test.text, test.terms))
+		})
+	}
+}
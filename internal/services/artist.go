@@ -1,39 +1,255 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
 	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/filter"
 	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"github.com/sarrooo/go-clean/internal/repositories"
+	"gorm.io/gorm"
 )
 
-func (svc *Service) CreateArtist(artist *models.Artist) (err error) {
-	err = svc.globalRepository.Artist.Create(artist)
+func (svc *Service) CreateArtist(ctx context.Context, artist *models.Artist) (err error) {
+	return svc.withinTx(ctx, func(repos *repositories.GlobalRepository) error {
+		return svc.createArtist(ctx, repos, artist)
+	})
+}
+
+// BulkCreateArtists creates the artists in the bulk mode, and returns the error of each artist
+func (svc *Service) BulkCreateArtists(ctx context.Context, artists []*models.Artist, mode string) (errs []error, err error) {
+	return svc.runBulk(ctx, mode, len(artists), func(repos *repositories.GlobalRepository, i int) error {
+		return svc.createArtist(ctx, repos, artists[i])
+	})
+}
+
+func (svc *Service) createArtist(ctx context.Context, repos *repositories.GlobalRepository, artist *models.Artist) error {
+	err := repos.Artist.Create(ctx, artist)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%w: an artist is already named %s", errcode.ErrConflict, artist.Name)
+	}
 	if err != nil {
 		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
 	}
+	return svc.audit(ctx, repos, audit.ActionCreate, audit.EntityArtists, artist.ID, nil, artist)
+}
 
-	return nil
+// GetArtist returns an artist, with the preloaded relations if any
+// Only the artist without relation is cached
+func (svc *Service) GetArtist(ctx context.Context, id uint, preloads []string) (artist *models.Artist, err error) {
+	if len(preloads) == 0 {
+		artist, err = svc.globalRepository.Artist.GetByID(ctx, id)
+	} else {
+		artist, err = svc.globalRepository.Artist.GetWithRelations(ctx, id, preloads)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: artist %d", errcode.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return artist, nil
 }
 
-func (svc *Service) GetArtist(id uint) (artist *models.Artist, err error) {
-	artist, err = svc.globalRepository.Artist.GetByID(id)
+// ListArtists returns a page of the artists matching the filters, with the preloaded relations, e.g. Albums
+func (svc *Service) ListArtists(ctx context.Context, filters []filter.Condition, params *pagination.Params, preloads []string) (page *pagination.Page[*models.Artist], err error) {
+	page, err = svc.globalRepository.Artist.List(ctx, filters, params, preloads)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
 	}
+	return page, nil
+}
+
+// UpdateArtist updates the non-zero fields of an artist, at the version the client knows
+// It fails with a failed precondition if the artist was updated meanwhile
+// The artist is then refreshed with its new values and version
+func (svc *Service) UpdateArtist(ctx context.Context, artist *models.Artist) (err error) {
+	return svc.withinTx(ctx, func(repos *repositories.GlobalRepository) error {
+		return svc.updateArtist(ctx, repos, artist)
+	})
+}
+
+// BulkUpdateArtists updates the artists in the bulk mode, and returns the error of each artist
+func (svc *Service) BulkUpdateArtists(ctx context.Context, artists []*models.Artist, mode string) (errs []error, err error) {
+	return svc.runBulk(ctx, mode, len(artists), func(repos *repositories.GlobalRepository, i int) error {
+		return svc.updateArtist(ctx, repos, artists[i])
+	})
+}
+
+func (svc *Service) updateArtist(ctx context.Context, repos *repositories.GlobalRepository, artist *models.Artist) error {
+	before, err := repos.Artist.GetByID(ctx, artist.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: artist %d", errcode.ErrNotFound, artist.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
 
-	if artist.ID == 0 {
-		return nil, fmt.Errorf("%w: %v", errcode.ErrNotFound, err)
+	// Without version (If-Match: *), the current version is updated
+	if artist.Version == 0 {
+		artist.Version = before.Version
+	}
+	err = repos.Artist.Update(ctx, artist)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%w: an artist is already named %s", errcode.ErrConflict, artist.Name)
+	}
+	if err != nil {
+		return versionError(before, err)
 	}
 
-	return artist, nil
+	after, err := repos.Artist.GetByID(ctx, artist.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	*artist = *after
+	return svc.audit(ctx, repos, audit.ActionUpdate, audit.EntityArtists, artist.ID, before, after)
+}
+
+// DeleteArtist soft deletes an artist
+// The strategy chooses what happens to the active albums of the artist:
+// - restrict: the deletion is refused with a conflict giving the count of dependents
+// - cascade: the albums and their library entries are soft deleted with the artist
+// - reassign: the albums are moved to the ReassignTo artist
+func (svc *Service) DeleteArtist(ctx context.Context, deleteArtist *dto.DeleteArtist) (err error) {
+	// The parameters are checked before opening a transaction
+	if _, err = deleteArtistStrategy(deleteArtist); err != nil {
+		return err
+	}
+	return svc.withinTx(ctx, func(repos *repositories.GlobalRepository) error {
+		return svc.deleteArtist(ctx, repos, deleteArtist)
+	})
+}
+
+// BulkDeleteArtists deletes the artists in the bulk mode, and returns the error of each artist
+func (svc *Service) BulkDeleteArtists(ctx context.Context, deleteArtists []*dto.DeleteArtist, mode string) (errs []error, err error) {
+	return svc.runBulk(ctx, mode, len(deleteArtists), func(repos *repositories.GlobalRepository, i int) error {
+		return svc.deleteArtist(ctx, repos, deleteArtists[i])
+	})
+}
+
+// deleteArtistStrategy returns the strategy of the deletion, restrict by default
+func deleteArtistStrategy(deleteArtist *dto.DeleteArtist) (string, error) {
+	strategy := deleteArtist.Strategy
+	if strategy == "" {
+		strategy = dto.DeleteArtistRestrict
+	}
+	if strategy == dto.DeleteArtistReassign && (deleteArtist.ReassignTo == 0 || deleteArtist.ReassignTo == deleteArtist.ID) {
+		return "", fmt.Errorf("%w: albums must be reassigned to another artist", errcode.ErrInvalidParameters)
+	}
+	return strategy, nil
+}
+
+func (svc *Service) deleteArtist(ctx context.Context, repos *repositories.GlobalRepository, deleteArtist *dto.DeleteArtist) error {
+	strategy, err := deleteArtistStrategy(deleteArtist)
+	if err != nil {
+		return err
+	}
+
+	artist, err := repos.Artist.GetByID(ctx, deleteArtist.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: artist %d", errcode.ErrNotFound, deleteArtist.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+
+	if deleteArtist.Version != 0 && deleteArtist.Version != artist.Version {
+		return fmt.Errorf("%w: artist %d is at version %d", errcode.ErrPreconditionFailed, artist.ID, artist.Version)
+	}
+
+	dependents, err := repos.Artist.CountDependents(ctx, deleteArtist.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+
+	if dependents.Albums != 0 {
+		switch strategy {
+		case dto.DeleteArtistRestrict:
+			return errcode.WithContext(
+				fmt.Errorf("%w: artist %d has %d albums", errcode.ErrConflict, deleteArtist.ID, dependents.Albums),
+				map[string]string{
+					"albums":          strconv.FormatInt(dependents.Albums, 10),
+					"library_entries": strconv.FormatInt(dependents.LibraryEntries, 10),
+				},
+			)
+		case dto.DeleteArtistCascade:
+			err = svc.deleteAlbums(ctx, repos, deleteArtist.ID)
+			if err != nil {
+				return err
+			}
+		case dto.DeleteArtistReassign:
+			err = svc.reassignAlbums(ctx, repos, deleteArtist.ID, deleteArtist.ReassignTo)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: unknown strategy %s", errcode.ErrInvalidParameters, strategy)
+		}
+	}
+
+	err = repos.Artist.Delete(ctx, deleteArtist.ID, deleteArtist.Version)
+	if err != nil {
+		return versionError(artist, err)
+	}
+	return svc.audit(ctx, repos, audit.ActionDelete, audit.EntityArtists, artist.ID, artist, nil)
 }
 
-func (svc *Service) DeleteArtist(id uint) (err error) {
-	err = svc.globalRepository.Artist.Delete(id)
+func (svc *Service) reassignAlbums(ctx context.Context, repos *repositories.GlobalRepository, id, toID uint) error {
+	_, err := repos.Artist.GetByID(ctx, toID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: artist %d", errcode.ErrNotFound, toID)
+	}
 	if err != nil {
 		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
 	}
 
+	before, after, err := repos.Artist.ReassignAlbums(ctx, id, toID)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%w: artist %d already has an album of artist %d", errcode.ErrConflict, toID, id)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	for i, album := range after {
+		err = svc.audit(ctx, repos, audit.ActionUpdate, audit.EntityAlbums, album.ID, before[i], album)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// deleteAlbums soft deletes the albums of the artist and their library entries, each one is audited
+func (svc *Service) deleteAlbums(ctx context.Context, repos *repositories.GlobalRepository, id uint) error {
+	albums, entries, err := repos.Artist.DeleteAlbums(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	for _, entry := range entries {
+		err = svc.audit(ctx, repos, audit.ActionDelete, audit.EntityUserAlbums, entry.ID, entry, nil)
+		if err != nil {
+			return err
+		}
+	}
+	for _, album := range albums {
+		err = svc.audit(ctx, repos, audit.ActionDelete, audit.EntityAlbums, album.ID, album, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// versionError maps a stale version to a failed precondition
+func versionError(artist *models.Artist, err error) error {
+	if errors.Is(err, repositories.ErrStaleVersion) {
+		return fmt.Errorf("%w: artist %d was modified", errcode.ErrPreconditionFailed, artist.ID)
+	}
+	return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/filter"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"github.com/sarrooo/go-clean/internal/repositories"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func (suite *ServiceSuiteTest) TestGetArtist() {
+	artist := &models.Artist{Model: models.Model{ID: 1}, Name: "Eminem"}
+
+	tests := map[string]struct {
+		preloads  []string
+		setupMock func()
+		expected  error
+	}{
+		"Without relation": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil).Once()
+			},
+		},
+		"With relations": {
+			preloads: []string{"Albums"},
+			setupMock: func() {
+				suite.globalRepositoryMock.Artist.On("GetWithRelations", mock.Anything, uint(1), []string{"Albums"}).Return(artist, nil).Once()
+			},
+		},
+		"Artist not found": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(nil, gorm.ErrRecordNotFound).Once()
+			},
+			expected: errcode.ErrNotFound,
+		},
+		"Database error": {
+			preloads: []string{"Albums"},
+			setupMock: func() {
+				suite.globalRepositoryMock.Artist.On("GetWithRelations", mock.Anything, uint(1), []string{"Albums"}).Return(nil, errors.New("connection lost")).Once()
+			},
+			expected: errcode.ErrDatabase,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			result, err := suite.svc.GetArtist(context.Background(), 1, test.preloads)
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(artist, result)
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestListArtists() {
+	filters := []filter.Condition{{Column: "name", Operator: filter.Contains, Value: "em"}}
+	params := &pagination.Params{Limit: 2, Sort: []pagination.Order{{Column: "name"}}}
+	page := &pagination.Page[*models.Artist]{Items: []*models.Artist{{Model: models.Model{ID: 1}, Name: "Eminem"}}}
+
+	tests := map[string]struct {
+		setupMock func()
+		expected  error
+	}{
+		"Success": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Artist.On("List", mock.Anything, filters, params, []string{"Albums"}).Return(page, nil)
+			},
+		},
+		"Database error": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Artist.On("List", mock.Anything, filters, params, []string{"Albums"}).Return(nil, errors.New("connection lost"))
+			},
+			expected: errcode.ErrDatabase,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			result, err := suite.svc.ListArtists(context.Background(), filters, params, []string{"Albums"})
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(page, result)
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestUpdateArtist() {
+	current := &models.Artist{Model: models.Model{ID: 1, Version: 3}, Name: "Eminem"}
+	updated := &models.Artist{Model: models.Model{ID: 1, Version: 4}, Name: "Slim Shady"}
+
+	tests := map[string]struct {
+		artist          *models.Artist
+		setupMock       func()
+		expected        error
+		expectedVersion uint
+	}{
+		"Success": {
+			artist: &models.Artist{Model: models.Model{ID: 1, Version: 3}, Name: "Slim Shady"},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(current, nil).Once()
+				suite.globalRepositoryMock.Artist.On("Update", mock.Anything, &models.Artist{Model: models.Model{ID: 1, Version: 3}, Name: "Slim Shady"}).Return(nil)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(updated, nil).Once()
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionUpdate, audit.EntityArtists, 1)
+			},
+			expectedVersion: 4,
+		},
+		"Any version": {
+			artist: &models.Artist{Model: models.Model{ID: 1}, Name: "Slim Shady"},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(current, nil).Once()
+				suite.globalRepositoryMock.Artist.On("Update", mock.Anything, &models.Artist{Model: models.Model{ID: 1, Version: 3}, Name: "Slim Shady"}).Return(nil)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(updated, nil).Once()
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionUpdate, audit.EntityArtists, 1)
+			},
+			expectedVersion: 4,
+		},
+		"Stale version": {
+			artist: &models.Artist{Model: models.Model{ID: 1, Version: 2}, Name: "Slim Shady"},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(current, nil).Once()
+				suite.globalRepositoryMock.Artist.On("Update", mock.Anything, mock.Anything).Return(repositories.ErrStaleVersion)
+			},
+			expected: errcode.ErrPreconditionFailed,
+		},
+		"Name taken": {
+			artist: &models.Artist{Model: models.Model{ID: 1, Version: 3}, Name: "Slim Shady"},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(current, nil).Once()
+				suite.globalRepositoryMock.Artist.On("Update", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey)
+			},
+			expected: errcode.ErrConflict,
+		},
+		"Unknown artist": {
+			artist: &models.Artist{Model: models.Model{ID: 1, Version: 3}, Name: "Slim Shady"},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expected: errcode.ErrNotFound,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			err := suite.svc.UpdateArtist(context.Background(), test.artist)
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expectedVersion, test.artist.Version, "Artist should be refreshed")
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestDeleteArtist() {
+	artist := &models.Artist{Model: models.Model{ID: 1, Version: 1}, Name: "Eminem"}
+	noDependents := &dto.ArtistDependents{}
+	dependents := &dto.ArtistDependents{Albums: 2, LibraryEntries: 3}
+	albums := []*models.Album{
+		{Model: models.Model{ID: 10, Version: 1}, Name: "Recovery", ArtistID: 1},
+		{Model: models.Model{ID: 11, Version: 1}, Name: "Revival", ArtistID: 1},
+	}
+	reassigned := []*models.Album{
+		{Model: models.Model{ID: 10, Version: 2}, Name: "Recovery", ArtistID: 2},
+		{Model: models.Model{ID: 11, Version: 2}, Name: "Revival", ArtistID: 2},
+	}
+	entries := []*models.UserAlbum{{Model: models.Model{ID: 20}, UserID: 1, AlbumID: 10}}
+
+	tests := map[string]struct {
+		deleteArtist    *dto.DeleteArtist
+		setupMock       func()
+		expected        error
+		expectedContext map[string]string
+	}{
+		"Without dependents": {
+			deleteArtist: &dto.DeleteArtist{ID: 1},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil)
+				suite.globalRepositoryMock.Artist.On("CountDependents", mock.Anything, uint(1)).Return(noDependents, nil)
+				suite.globalRepositoryMock.Artist.On("Delete", mock.Anything, uint(1), uint(0)).Return(nil)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionDelete, audit.EntityArtists, 1)
+			},
+		},
+		"Restrict with dependents": {
+			deleteArtist: &dto.DeleteArtist{ID: 1},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil)
+				suite.globalRepositoryMock.Artist.On("CountDependents", mock.Anything, uint(1)).Return(dependents, nil)
+			},
+			expected:        errcode.ErrConflict,
+			expectedContext: map[string]string{"albums": "2", "library_entries": "3"},
+		},
+		"Cascade": {
+			deleteArtist: &dto.DeleteArtist{ID: 1, Strategy: dto.DeleteArtistCascade},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil)
+				suite.globalRepositoryMock.Artist.On("CountDependents", mock.Anything, uint(1)).Return(dependents, nil)
+				suite.globalRepositoryMock.Artist.On("DeleteAlbums", mock.Anything, uint(1)).Return(albums, entries, nil)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionDelete, audit.EntityUserAlbums, 20)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionDelete, audit.EntityAlbums, 10)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionDelete, audit.EntityAlbums, 11)
+				suite.globalRepositoryMock.Artist.On("Delete", mock.Anything, uint(1), uint(0)).Return(nil)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionDelete, audit.EntityArtists, 1)
+			},
+		},
+		"Reassign": {
+			deleteArtist: &dto.DeleteArtist{ID: 1, Strategy: dto.DeleteArtistReassign, ReassignTo: 2},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil)
+				suite.globalRepositoryMock.Artist.On("CountDependents", mock.Anything, uint(1)).Return(dependents, nil)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(2)).Return(&models.Artist{Model: models.Model{ID: 2}}, nil)
+				suite.globalRepositoryMock.Artist.On("ReassignAlbums", mock.Anything, uint(1), uint(2)).Return(albums, reassigned, nil)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionUpdate, audit.EntityAlbums, 10)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionUpdate, audit.EntityAlbums, 11)
+				suite.globalRepositoryMock.Artist.On("Delete", mock.Anything, uint(1), uint(0)).Return(nil)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionDelete, audit.EntityArtists, 1)
+			},
+		},
+		"Reassign to unknown artist": {
+			deleteArtist: &dto.DeleteArtist{ID: 1, Strategy: dto.DeleteArtistReassign, ReassignTo: 2},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil)
+				suite.globalRepositoryMock.Artist.On("CountDependents", mock.Anything, uint(1)).Return(dependents, nil)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(2)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expected: errcode.ErrNotFound,
+		},
+		"Reassign to an artist with the same album": {
+			deleteArtist: &dto.DeleteArtist{ID: 1, Strategy: dto.DeleteArtistReassign, ReassignTo: 2},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil)
+				suite.globalRepositoryMock.Artist.On("CountDependents", mock.Anything, uint(1)).Return(dependents, nil)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(2)).Return(&models.Artist{Model: models.Model{ID: 2}}, nil)
+				suite.globalRepositoryMock.Artist.On("ReassignAlbums", mock.Anything, uint(1), uint(2)).Return(nil, nil, gorm.ErrDuplicatedKey)
+			},
+			expected: errcode.ErrConflict,
+		},
+		"Unknown artist": {
+			deleteArtist: &dto.DeleteArtist{ID: 3},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(3)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expected: errcode.ErrNotFound,
+		},
+		"Stale version": {
+			deleteArtist: &dto.DeleteArtist{ID: 1, Version: 2},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil)
+			},
+			expected: errcode.ErrPreconditionFailed,
+		},
+		"Updated meanwhile": {
+			deleteArtist: &dto.DeleteArtist{ID: 1, Version: 1},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil)
+				suite.globalRepositoryMock.Artist.On("CountDependents", mock.Anything, uint(1)).Return(noDependents, nil)
+				suite.globalRepositoryMock.Artist.On("Delete", mock.Anything, uint(1), uint(1)).Return(repositories.ErrStaleVersion)
+			},
+			expected: errcode.ErrPreconditionFailed,
+		},
+		"Reassign to itself": {
+			deleteArtist: &dto.DeleteArtist{ID: 1, Strategy: dto.DeleteArtistReassign, ReassignTo: 1},
+			setupMock:    func() {},
+			expected:     errcode.ErrInvalidParameters,
+		},
+		"Database error": {
+			deleteArtist: &dto.DeleteArtist{ID: 1},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByID", mock.Anything, uint(1)).Return(artist, nil)
+				suite.globalRepositoryMock.Artist.On("CountDependents", mock.Anything, uint(1)).Return(nil, errors.New("connection lost"))
+			},
+			expected: errcode.ErrDatabase,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			err := suite.svc.DeleteArtist(context.Background(), test.deleteArtist)
+
+			if test.expected == nil {
+				suite.Assert().NoError(err, "No error should have occurred")
+				return
+			}
+			suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+			if test.expectedContext != nil {
+				var contextError *errcode.ContextError
+				suite.Require().True(errors.As(err, &contextError), "Error should have a context")
+				suite.Assert().Equal(test.expectedContext, contextError.Context, "Error context should match")
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/repositories"
+)
+
+// Number of audit logs listed when the filter has no limit
+const defaultAuditLimit = 50
+
+// audit records a mutation with the actor, request ID and IP of the context
+// Call it with the repositories of the transaction of the mutation, so the mutation is never committed without its log
+// before is nil for a creation and after is nil for a deletion
+func (svc *Service) audit(ctx context.Context, repos *repositories.GlobalRepository, action, entityType string, entityID uint, before, after interface{}) error {
+	changes, err := audit.Diff(before, after)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrAudit, err)
+	}
+
+	metadata := audit.FromContext(ctx)
+	err = repos.Audit.Create(ctx, &models.AuditLog{
+		ActorType:  metadata.Actor.Type,
+		ActorID:    metadata.Actor.ID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		RequestID:  metadata.RequestID,
+		IP:         metadata.IP,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return nil
+}
+
+// ListAuditLogs returns the audit logs matching the filter, most recent first
+func (svc *Service) ListAuditLogs(ctx context.Context, filter *dto.AuditFilter) (logs []*models.AuditLog, err error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	logs, err = svc.globalRepository.Audit.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return logs, nil
+}
+
+// PurgeAuditLogs deletes the audit logs created before the given date
+func (svc *Service) PurgeAuditLogs(ctx context.Context, before time.Time) (purged int64, err error) {
+	purged, err = svc.globalRepository.Audit.DeleteBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return purged, nil
+}
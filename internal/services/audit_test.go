@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceSuiteTest) TestAuditMetadata() {
+	ctx := audit.WithMetadata(context.Background(), audit.Metadata{
+		Actor:     audit.Actor{Type: audit.ActorUser, ID: "7"},
+		RequestID: "request",
+		IP:        "127.0.0.1",
+	})
+	artist := &models.Artist{Name: "Eminem"}
+
+	suite.Run("Create artist", func() {
+		suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+		suite.globalRepositoryMock.Artist.On("Create", mock.Anything, artist).Return(nil)
+		suite.globalRepositoryMock.Audit.On("Create", mock.Anything, &models.AuditLog{
+			ActorType:  audit.ActorUser,
+			ActorID:    "7",
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityArtists,
+			Changes:    `{"created_at":{"before":null,"after":"0001-01-01T00:00:00Z"},"deleted_at":{"before":null,"after":null},"id":{"before":null,"after":0},"name":{"before":null,"after":"Eminem"},"updated_at":{"before":null,"after":"0001-01-01T00:00:00Z"},"version":{"before":null,"after":0}}`,
+			RequestID:  "request",
+			IP:         "127.0.0.1",
+		}).Return(nil)
+
+		err := suite.svc.CreateArtist(ctx, artist)
+
+		suite.Assert().NoError(err, "No error should have occurred")
+	})
+
+	suite.Run("Audit failure fails the operation", func() {
+		suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+		suite.globalRepositoryMock.Artist.On("Create", mock.Anything, artist).Return(nil)
+		suite.globalRepositoryMock.Audit.On("Create", mock.Anything, mock.Anything).Return(errors.New("connection lost"))
+
+		err := suite.svc.CreateArtist(ctx, artist)
+
+		suite.Assert().True(errors.Is(err, errcode.ErrDatabase), "Error type should match")
+	})
+}
+
+func (suite *ServiceSuiteTest) TestListAuditLogs() {
+	logs := []*models.AuditLog{{ID: 1}}
+
+	tests := map[string]struct {
+		filter    *dto.AuditFilter
+		setupMock func()
+		expected  error
+	}{
+		"Default limit": {
+			filter: &dto.AuditFilter{Action: audit.ActionDelete},
+			setupMock: func() {
+				suite.globalRepositoryMock.Audit.On("List", mock.Anything, &dto.AuditFilter{Action: audit.ActionDelete, Limit: defaultAuditLimit}).Return(logs, nil)
+			},
+		},
+		"Database error": {
+			filter: &dto.AuditFilter{Limit: 10},
+			setupMock: func() {
+				suite.globalRepositoryMock.Audit.On("List", mock.Anything, &dto.AuditFilter{Limit: 10}).Return(nil, errors.New("connection lost"))
+			},
+			expected: errcode.ErrDatabase,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			result, err := suite.svc.ListAuditLogs(context.Background(), test.filter)
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(logs, result)
+		})
+	}
+}
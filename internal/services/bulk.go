@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/repositories"
+	"github.com/spf13/viper"
+)
+
+// bulkBatchSize is BULK_BATCH_SIZE, the number of items of a best effort transaction (100 by default)
+func bulkBatchSize() int {
+	if viper.GetInt("BULK_BATCH_SIZE") <= 0 {
+		return 100
+	}
+	return viper.GetInt("BULK_BATCH_SIZE")
+}
+
+// runBulk runs fn for the count items of a bulk operation, and returns the error of each item
+// - atomic (default): the items run in one transaction, the first failure stops it and rolls it back,
+// the other items fail with ErrBulkAborted
+// - best_effort: the items run in transactions of BULK_BATCH_SIZE items, each item in a savepoint,
+// so a failed item is rolled back alone
+func (svc *Service) runBulk(ctx context.Context, mode string, count int, fn func(repos *repositories.GlobalRepository, i int) error) (errs []error, err error) {
+	switch mode {
+	case "", dto.BulkAtomic:
+		return svc.runBulkAtomic(ctx, count, fn), nil
+	case dto.BulkBestEffort:
+		return svc.runBulkBestEffort(ctx, count, fn), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown bulk mode %s", errcode.ErrInvalidParameters, mode)
+	}
+}
+
+func (svc *Service) runBulkAtomic(ctx context.Context, count int, fn func(repos *repositories.GlobalRepository, i int) error) []error {
+	errs := make([]error, count)
+	failed := -1
+	err := svc.withinTx(ctx, func(repos *repositories.GlobalRepository) error {
+		for i := 0; i < count; i++ {
+			if err := fn(repos, i); err != nil {
+				failed = i
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return errs
+	}
+
+	for i := range errs {
+		switch {
+		case i == failed:
+			errs[i] = err
+		case failed == -1:
+			// The commit failed, no item was done
+			errs[i] = err
+		default:
+			errs[i] = fmt.Errorf("%w: item %d failed", errcode.ErrBulkAborted, failed)
+		}
+	}
+	return errs
+}
+
+func (svc *Service) runBulkBestEffort(ctx context.Context, count int, fn func(repos *repositories.GlobalRepository, i int) error) []error {
+	errs := make([]error, count)
+	batchSize := bulkBatchSize()
+	for start := 0; start < count; start += batchSize {
+		end := min(start+batchSize, count)
+		err := svc.withinTx(ctx, func(repos *repositories.GlobalRepository) error {
+			runBulkItems(ctx, repos, errs, start, end, fn)
+			return nil
+		})
+		if err != nil {
+			// The commit failed, the items of the batch which succeeded were not done
+			for i := start; i < end; i++ {
+				if errs[i] == nil {
+					errs[i] = err
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// errDryRun rolls back the transaction of a dry run
+var errDryRun = errors.New("dry run")
+
+// runDryRun runs fn for the count items like the best_effort mode, but in one transaction rolled back at the end
+// The errors are the ones the items would have
+func (svc *Service) runDryRun(ctx context.Context, count int, fn func(repos *repositories.GlobalRepository, i int) error) []error {
+	errs := make([]error, count)
+	err := svc.globalRepository.WithinTx(ctx, func(repos *repositories.GlobalRepository) error {
+		runBulkItems(ctx, repos, errs, 0, count, fn)
+		return errDryRun
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = txError(err)
+			}
+		}
+	}
+	return errs
+}
+
+// runBulkItems runs fn for the items from start to end, each item in a savepoint
+func runBulkItems(ctx context.Context, repos *repositories.GlobalRepository, errs []error, start, end int, fn func(repos *repositories.GlobalRepository, i int) error) {
+	for i := start; i < end; i++ {
+		errs[i] = txError(repos.WithinTx(ctx, func(repos *repositories.GlobalRepository) error {
+			return fn(repos, i)
+		}))
+	}
+}
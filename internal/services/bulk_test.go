@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *ServiceSuiteTest) TestBulkCreateArtists() {
+	named := func(name string) interface{} {
+		return mock.MatchedBy(func(artist *models.Artist) bool { return artist.Name == name })
+	}
+	expectCreate := func(name string, id uint) {
+		suite.globalRepositoryMock.Artist.On("Create", mock.Anything, named(name)).Run(func(args mock.Arguments) {
+			args.Get(1).(*models.Artist).ID = id
+		}).Return(nil).Once()
+		suite.globalRepositoryMock.ExpectAudit(audit.ActionCreate, audit.EntityArtists, id).Once()
+	}
+
+	tests := map[string]struct {
+		mode      string
+		batchSize int
+		setupMock func()
+		expected  []error
+		expectErr error
+	}{
+		"Atomic": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository).Once()
+				expectCreate("Eminem", 1)
+				expectCreate("Dr. Dre", 2)
+			},
+			expected: []error{nil, nil},
+		},
+		"Atomic failure": {
+			mode: dto.BulkAtomic,
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository).Once()
+				expectCreate("Eminem", 1)
+				suite.globalRepositoryMock.Artist.On("Create", mock.Anything, named("Dr. Dre")).Return(errors.New("connection lost")).Once()
+			},
+			expected: []error{errcode.ErrBulkAborted, errcode.ErrDatabase},
+		},
+		"Commit failure": {
+			mode: dto.BulkAtomic,
+			setupMock: func() {
+				suite.globalRepositoryMock.Transaction.On("WithinTx", mock.Anything, mock.Anything).Return(errors.New("commit failed")).Once()
+			},
+			expected: []error{errcode.ErrDatabase, errcode.ErrDatabase},
+		},
+		"Best effort": {
+			mode: dto.BulkBestEffort,
+			setupMock: func() {
+				// The batch and a savepoint by item
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository).Times(3)
+				expectCreate("Eminem", 1)
+				suite.globalRepositoryMock.Artist.On("Create", mock.Anything, named("Dr. Dre")).Return(errors.New("connection lost")).Once()
+			},
+			expected: []error{nil, errcode.ErrDatabase},
+		},
+		"Best effort batches": {
+			mode:      dto.BulkBestEffort,
+			batchSize: 1,
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository).Times(4)
+				expectCreate("Eminem", 1)
+				expectCreate("Dr. Dre", 2)
+			},
+			expected: []error{nil, nil},
+		},
+		"Unknown mode": {
+			mode:      "sometimes",
+			setupMock: func() {},
+			expectErr: errcode.ErrInvalidParameters,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			viper.Set("BULK_BATCH_SIZE", test.batchSize)
+			defer viper.Set("BULK_BATCH_SIZE", nil)
+			test.setupMock()
+
+			artists := []*models.Artist{{Name: "Eminem"}, {Name: "Dr. Dre"}}
+			errs, err := suite.svc.BulkCreateArtists(context.Background(), artists, test.mode)
+
+			if test.expectErr != nil {
+				suite.Assert().True(errors.Is(err, test.expectErr), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Require().Len(errs, len(test.expected))
+			for i, expected := range test.expected {
+				if expected == nil {
+					suite.Assert().NoError(errs[i], "Item %d should have succeeded", i)
+				} else {
+					suite.Assert().True(errors.Is(errs[i], expected), "Error type of item %d should match", i)
+				}
+			}
+		})
+	}
+}
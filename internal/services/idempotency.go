@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// Interval at which a request waits for the request holding its idempotency key
+var idempotencyPollInterval = 50 * time.Millisecond
+
+// Number of attempts to take an idempotency key released or expired meanwhile
+const idempotencyAttempts = 3
+
+// idempotencyTTL is IDEMPOTENCY_TTL, how long a response is replayed (24 hours by default)
+func idempotencyTTL() time.Duration {
+	if !viper.IsSet("IDEMPOTENCY_TTL") {
+		return 24 * time.Hour
+	}
+	return viper.GetDuration("IDEMPOTENCY_TTL")
+}
+
+// idempotencyLockTimeout is IDEMPOTENCY_LOCK_TIMEOUT, how long a request holds its key (30 seconds by default)
+// A key held for longer is considered abandoned by a crashed request, and is taken by the next retry
+func idempotencyLockTimeout() time.Duration {
+	if !viper.IsSet("IDEMPOTENCY_LOCK_TIMEOUT") {
+		return 30 * time.Second
+	}
+	return viper.GetDuration("IDEMPOTENCY_LOCK_TIMEOUT")
+}
+
+// BeginIdempotentRequest takes the idempotency key of the request, or returns the response stored for it
+// - a new key is held by the request (its ID is set) until CompleteIdempotentRequest or ReleaseIdempotentRequest, the response is nil
+// - a key held by a request in progress is waited for, until the request ends or the key is abandoned
+// - a key used by another request (another fingerprint) is a ErrIdempotencyKeyReused error
+func (svc *Service) BeginIdempotentRequest(ctx context.Context, request *dto.IdempotentRequest) (response *dto.IdempotentResponse, err error) {
+	ttl := idempotencyTTL()
+	lockTimeout := idempotencyLockTimeout()
+
+	for attempt := 0; attempt < idempotencyAttempts; attempt++ {
+		key := &models.IdempotencyKey{
+			Scope:       request.Scope,
+			Key:         request.Key,
+			Fingerprint: request.Fingerprint,
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+		err = svc.globalRepository.Idempotency.Create(ctx, key)
+		if err == nil {
+			request.ID = key.ID
+			return nil, nil
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+		}
+
+		stored, err := svc.globalRepository.Idempotency.Get(ctx, request.Scope, request.Key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Released meanwhile
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+		}
+
+		// An expired or abandoned key is taken again
+		expired := !time.Now().Before(stored.ExpiresAt)
+		abandoned := stored.StatusCode == 0 && !time.Now().Before(stored.CreatedAt.Add(lockTimeout))
+		if expired || abandoned {
+			err = svc.globalRepository.Idempotency.Delete(ctx, stored.ID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+			}
+			continue
+		}
+
+		if stored.Fingerprint != request.Fingerprint {
+			return nil, fmt.Errorf("%w: key %s", errcode.ErrIdempotencyKeyReused, request.Key)
+		}
+		stored, err = svc.waitIdempotentRequest(ctx, stored, lockTimeout)
+		if err != nil {
+			return nil, err
+		}
+		if stored == nil {
+			// Released by the request in progress
+			continue
+		}
+		return &dto.IdempotentResponse{StatusCode: stored.StatusCode, Body: []byte(stored.Response)}, nil
+	}
+	return nil, fmt.Errorf("%w: key %s", errcode.ErrIdempotencyInFlight, request.Key)
+}
+
+// waitIdempotentRequest waits for the request holding the key to store its response
+// It returns nil if the key was released, and a ErrIdempotencyInFlight error if the key is
+// abandoned or the context is done before
+func (svc *Service) waitIdempotentRequest(ctx context.Context, key *models.IdempotencyKey, lockTimeout time.Duration) (*models.IdempotencyKey, error) {
+	deadline := key.CreatedAt.Add(lockTimeout)
+	ticker := time.NewTicker(idempotencyPollInterval)
+	defer ticker.Stop()
+
+	for key.StatusCode == 0 {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %v", errcode.ErrIdempotencyInFlight, ctx.Err())
+		case <-ticker.C:
+		}
+		if !time.Now().Before(deadline) {
+			return nil, fmt.Errorf("%w: key %s", errcode.ErrIdempotencyInFlight, key.Key)
+		}
+
+		var err error
+		key, err = svc.globalRepository.Idempotency.Get(ctx, key.Scope, key.Key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+		}
+	}
+	return key, nil
+}
+
+// CompleteIdempotentRequest stores the response of a request holding its idempotency key
+// The response is replayed to the retries of the request until the key expires
+func (svc *Service) CompleteIdempotentRequest(ctx context.Context, request *dto.IdempotentRequest, response *dto.IdempotentResponse) (err error) {
+	err = svc.globalRepository.Idempotency.Complete(ctx, request.ID, response.StatusCode, string(response.Body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return nil
+}
+
+// ReleaseIdempotentRequest releases the idempotency key of a failed request, so a retry is processed again
+func (svc *Service) ReleaseIdempotentRequest(ctx context.Context, request *dto.IdempotentRequest) (err error) {
+	err = svc.globalRepository.Idempotency.Delete(ctx, request.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeys deletes the idempotency keys expired before the given date
+func (svc *Service) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (purged int64, err error) {
+	purged, err = svc.globalRepository.Idempotency.DeleteExpired(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func (suite *ServiceSuiteTest) TestBeginIdempotentRequest() {
+	now := time.Now()
+	completed := &models.IdempotencyKey{ID: 1, CreatedAt: now, Scope: "scope", Key: "key", Fingerprint: "fingerprint", StatusCode: 200, Response: `{"id":1}`, ExpiresAt: now.Add(time.Hour)}
+	inProgress := &models.IdempotencyKey{ID: 1, CreatedAt: now, Scope: "scope", Key: "key", Fingerprint: "fingerprint", ExpiresAt: now.Add(time.Hour)}
+	expired := &models.IdempotencyKey{ID: 1, CreatedAt: now.Add(-2 * time.Hour), Scope: "scope", Key: "key", Fingerprint: "other", StatusCode: 200, ExpiresAt: now.Add(-time.Hour)}
+	abandoned := &models.IdempotencyKey{ID: 1, CreatedAt: now.Add(-time.Hour), Scope: "scope", Key: "key", Fingerprint: "fingerprint", ExpiresAt: now.Add(time.Hour)}
+	reserve := func(args mock.Arguments) {
+		args.Get(1).(*models.IdempotencyKey).ID = 2
+	}
+
+	tests := map[string]struct {
+		timeout          time.Duration
+		setupMock        func()
+		expected         error
+		expectedResponse *dto.IdempotentResponse
+		expectedID       uint
+	}{
+		"New key": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Run(reserve).Return(nil)
+			},
+			expectedID: 2,
+		},
+		"Completed key": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey)
+				suite.globalRepositoryMock.Idempotency.On("Get", mock.Anything, "scope", "key").Return(completed, nil)
+			},
+			expectedResponse: &dto.IdempotentResponse{StatusCode: 200, Body: []byte(`{"id":1}`)},
+		},
+		"Key in progress": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey)
+				suite.globalRepositoryMock.Idempotency.On("Get", mock.Anything, "scope", "key").Return(inProgress, nil).Twice()
+				suite.globalRepositoryMock.Idempotency.On("Get", mock.Anything, "scope", "key").Return(completed, nil).Once()
+			},
+			expectedResponse: &dto.IdempotentResponse{StatusCode: 200, Body: []byte(`{"id":1}`)},
+		},
+		"Key in progress until the request is canceled": {
+			timeout: 3 * idempotencyPollInterval,
+			setupMock: func() {
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey)
+				suite.globalRepositoryMock.Idempotency.On("Get", mock.Anything, "scope", "key").Return(inProgress, nil)
+			},
+			expected: errcode.ErrIdempotencyInFlight,
+		},
+		"Key released by the request in progress": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey).Once()
+				suite.globalRepositoryMock.Idempotency.On("Get", mock.Anything, "scope", "key").Return(inProgress, nil).Once()
+				suite.globalRepositoryMock.Idempotency.On("Get", mock.Anything, "scope", "key").Return(nil, gorm.ErrRecordNotFound).Once()
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Run(reserve).Return(nil).Once()
+			},
+			expectedID: 2,
+		},
+		"Key used by another request": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey)
+				suite.globalRepositoryMock.Idempotency.On("Get", mock.Anything, "scope", "key").Return(&models.IdempotencyKey{
+					ID: 1, CreatedAt: now, Fingerprint: "other", StatusCode: 200, ExpiresAt: now.Add(time.Hour),
+				}, nil)
+			},
+			expected: errcode.ErrIdempotencyKeyReused,
+		},
+		"Expired key": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey).Once()
+				suite.globalRepositoryMock.Idempotency.On("Get", mock.Anything, "scope", "key").Return(expired, nil).Once()
+				suite.globalRepositoryMock.Idempotency.On("Delete", mock.Anything, uint(1)).Return(nil)
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Run(reserve).Return(nil).Once()
+			},
+			expectedID: 2,
+		},
+		"Abandoned key": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey).Once()
+				suite.globalRepositoryMock.Idempotency.On("Get", mock.Anything, "scope", "key").Return(abandoned, nil).Once()
+				suite.globalRepositoryMock.Idempotency.On("Delete", mock.Anything, uint(1)).Return(nil)
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Run(reserve).Return(nil).Once()
+			},
+			expectedID: 2,
+		},
+		"Database error": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Idempotency.On("Create", mock.Anything, mock.Anything).Return(errors.New("connection lost"))
+			},
+			expected: errcode.ErrDatabase,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+			ctx := context.Background()
+			if test.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+			request := &dto.IdempotentRequest{Scope: "scope", Key: "key", Fingerprint: "fingerprint"}
+
+			response, err := suite.svc.BeginIdempotentRequest(ctx, request)
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expectedResponse, response, "Response should match")
+			suite.Assert().Equal(test.expectedID, request.ID, "Held key should match")
+		})
+	}
+}
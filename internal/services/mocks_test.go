@@ -1,27 +1,44 @@
 package services
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/sarrooo/go-clean/internal/models"
 	"github.com/sarrooo/go-clean/internal/repositories"
 	"github.com/sarrooo/go-clean/mocks"
+	"github.com/stretchr/testify/mock"
 )
 
 type GlobalRepositoryMocks struct {
-	User   *mocks.UserRepositoryInterface
-	Artist *mocks.ArtistRepositoryInterface
+	User        *mocks.UserRepositoryInterface
+	Artist      *mocks.ArtistRepositoryInterface
+	Trash       *mocks.TrashRepositoryInterface
+	Audit       *mocks.AuditRepositoryInterface
+	Idempotency *mocks.IdempotencyRepositoryInterface
+	Search      *mocks.SearchRepositoryInterface
+	ImportJob   *mocks.ImportJobRepositoryInterface
 
 	// Add new repository here
+
+	Transaction *mocks.TransactionManagerInterface
 }
 
 // Create new GlobalRepository with all mocks
 func newGlobalRepositoryTesting() *repositories.GlobalRepository {
 	gr := &repositories.GlobalRepository{
-		User:   &mocks.UserRepositoryInterface{},
-		Artist: &mocks.ArtistRepositoryInterface{},
+		User:        &mocks.UserRepositoryInterface{},
+		Artist:      &mocks.ArtistRepositoryInterface{},
+		Trash:       &mocks.TrashRepositoryInterface{},
+		Audit:       &mocks.AuditRepositoryInterface{},
+		Idempotency: &mocks.IdempotencyRepositoryInterface{},
+		Search:      &mocks.SearchRepositoryInterface{},
+		ImportJob:   &mocks.ImportJobRepositoryInterface{},
 
 		// Add new repository here
+
+		Transaction: &mocks.TransactionManagerInterface{},
 	}
 	return gr
 }
@@ -30,13 +47,37 @@ func newGlobalRepositoryTesting() *repositories.GlobalRepository {
 // This function allow to access to all mock expectations
 func castMockGlobalRepository(gr *repositories.GlobalRepository) *GlobalRepositoryMocks {
 	return &GlobalRepositoryMocks{
-		User:   gr.User.(*mocks.UserRepositoryInterface),
-		Artist: gr.Artist.(*mocks.ArtistRepositoryInterface),
+		User:        gr.User.(*mocks.UserRepositoryInterface),
+		Artist:      gr.Artist.(*mocks.ArtistRepositoryInterface),
+		Trash:       gr.Trash.(*mocks.TrashRepositoryInterface),
+		Audit:       gr.Audit.(*mocks.AuditRepositoryInterface),
+		Idempotency: gr.Idempotency.(*mocks.IdempotencyRepositoryInterface),
+		Search:      gr.Search.(*mocks.SearchRepositoryInterface),
+		ImportJob:   gr.ImportJob.(*mocks.ImportJobRepositoryInterface),
 
 		// Add new repository here
+
+		Transaction: gr.Transaction.(*mocks.TransactionManagerInterface),
 	}
 }
 
+// Expect a transaction
+// The callback is executed with the given GlobalRepository, so the expectations
+// set on the repository mocks also apply inside the transaction
+func (gr *GlobalRepositoryMocks) ExpectWithinTx(repos *repositories.GlobalRepository) *mock.Call {
+	return gr.Transaction.On("WithinTx", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(repos *repositories.GlobalRepository) error) error {
+			return fn(repos)
+		})
+}
+
+// Expect an audit log of the action on the entity
+func (gr *GlobalRepositoryMocks) ExpectAudit(action, entityType string, entityID uint) *mock.Call {
+	return gr.Audit.On("Create", mock.Anything, mock.MatchedBy(func(log *models.AuditLog) bool {
+		return log.Action == action && log.EntityType == entityType && log.EntityID == entityID
+	})).Return(nil)
+}
+
 // Clear all mock expectations and calls
 func (gr *GlobalRepositoryMocks) ResetMockCalls() {
 	v := reflect.ValueOf(gr).Elem()
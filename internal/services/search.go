@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/pagination"
+	"github.com/sarrooo/go-clean/internal/search"
+)
+
+// Search returns a page of the artists and albums whose name matches every word of the text, the most relevant first
+// Accents and case are ignored, and the matched words are highlighted in the results
+func (svc *Service) Search(ctx context.Context, text string, types []string, params *pagination.Params) (page *pagination.Page[*dto.SearchResult], err error) {
+	terms := search.Terms(text)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: no word to search in %q", errcode.ErrInvalidParameters, text)
+	}
+
+	page, err = svc.globalRepository.Search.Search(ctx, &dto.SearchQuery{Terms: terms, Types: types}, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	for _, result := range page.Items {
+		result.Highlight = search.Highlight(result.Name, terms)
+	}
+	return page, nil
+}
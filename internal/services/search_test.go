@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/pagination"
+)
+
+func (suite *ServiceSuiteTest) TestSearch() {
+	params := &pagination.Params{Limit: 2, Page: 1}
+	types := []string{dto.SearchTypeArtists}
+	query := &dto.SearchQuery{Terms: []string{"celine", "dion"}, Types: types}
+
+	tests := map[string]struct {
+		text              string
+		setupMock         func()
+		expected          error
+		expectedHighlight string
+	}{
+		"Success": {
+			text: "Céline  DION!",
+			setupMock: func() {
+				suite.globalRepositoryMock.Search.On("Search", context.Background(), query, params).Return(&pagination.Page[*dto.SearchResult]{
+					Items: []*dto.SearchResult{{Type: dto.SearchTypeArtists, ID: 1, Name: "Céline Dion"}},
+				}, nil).Once()
+			},
+			expectedHighlight: "<mark>Céline</mark> <mark>Dion</mark>",
+		},
+		"No word": {
+			text:      "?!",
+			setupMock: func() {},
+			expected:  errcode.ErrInvalidParameters,
+		},
+		"Database error": {
+			text: "celine dion",
+			setupMock: func() {
+				suite.globalRepositoryMock.Search.On("Search", context.Background(), query, params).Return(nil, errors.New("connection lost")).Once()
+			},
+			expected: errcode.ErrDatabase,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			page, err := suite.svc.Search(context.Background(), test.text, types, params)
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Require().Len(page.Items, 1)
+			suite.Assert().Equal(test.expectedHighlight, page.Items[0].Highlight)
+		})
+	}
+}
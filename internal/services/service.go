@@ -1,8 +1,18 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
 	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/filter"
 	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/pagination"
 	"github.com/sarrooo/go-clean/internal/repositories"
 	"go.uber.org/zap"
 )
@@ -10,21 +20,56 @@ import (
 // TODO: Can we find a way to split this object into different object to avoid having 1000+ methods on the same space
 type ServiceInterface interface {
 	/* User */
-	RegisterUser(registerUser *dto.RegisterUser) (user *models.User, err error)
-	LoginUser(email, password string) (user *models.User, err error)
+	RegisterUser(ctx context.Context, registerUser *dto.RegisterUser) (user *models.User, err error)
+	LoginUser(ctx context.Context, email, password string) (user *models.User, err error)
+	GetUserByEmail(ctx context.Context, email string) (user *models.User, err error)
 
 	/* Token */
-	GenerateToken(user *models.User) (tokenString string, err error)
+	GenerateToken(ctx context.Context, user *models.User) (tokenString string, err error)
+	AuthenticateToken(ctx context.Context, tokenString string) (user *models.User, err error)
 
 	/* Artist */
-	CreateArtist(artist *models.Artist) (err error)
-	GetArtist(id uint) (artist *models.Artist, err error)
-	DeleteArtist(id uint) (err error)
+	CreateArtist(ctx context.Context, artist *models.Artist) (err error)
+	GetArtist(ctx context.Context, id uint, preloads []string) (artist *models.Artist, err error)
+	ListArtists(ctx context.Context, filters []filter.Condition, params *pagination.Params, preloads []string) (page *pagination.Page[*models.Artist], err error)
+	UpdateArtist(ctx context.Context, artist *models.Artist) (err error)
+	DeleteArtist(ctx context.Context, deleteArtist *dto.DeleteArtist) (err error)
+	BulkCreateArtists(ctx context.Context, artists []*models.Artist, mode string) (errs []error, err error)
+	BulkUpdateArtists(ctx context.Context, artists []*models.Artist, mode string) (errs []error, err error)
+	BulkDeleteArtists(ctx context.Context, deleteArtists []*dto.DeleteArtist, mode string) (errs []error, err error)
+
+	/* Trash */
+	ListTrash(ctx context.Context, entity string) (items []*dto.TrashItem, err error)
+	RestoreTrash(ctx context.Context, entity string, id uint) (err error)
+	PurgeTrash(ctx context.Context, entity string, id uint) (err error)
+	PurgeExpiredTrash(ctx context.Context, before time.Time) (purged int, err error)
+
+	/* Audit */
+	ListAuditLogs(ctx context.Context, filter *dto.AuditFilter) (logs []*models.AuditLog, err error)
+	PurgeAuditLogs(ctx context.Context, before time.Time) (purged int64, err error)
+
+	/* Idempotency */
+	BeginIdempotentRequest(ctx context.Context, request *dto.IdempotentRequest) (response *dto.IdempotentResponse, err error)
+	CompleteIdempotentRequest(ctx context.Context, request *dto.IdempotentRequest, response *dto.IdempotentResponse) (err error)
+	ReleaseIdempotentRequest(ctx context.Context, request *dto.IdempotentRequest) (err error)
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (purged int64, err error)
+
+	/* Search */
+	Search(ctx context.Context, text string, types []string, params *pagination.Params) (page *pagination.Page[*dto.SearchResult], err error)
+
+	/* Import & Export */
+	ExportArtists(ctx context.Context, format string, w io.Writer) (err error)
+	ImportArtists(ctx context.Context, imp *dto.Import) (report *dto.ImportReport, err error)
+	StartImportArtists(ctx context.Context, imp *dto.Import) (job *models.ImportJob, err error)
+	GetImportJob(ctx context.Context, id uint) (job *models.ImportJob, err error)
 }
 
 type Service struct {
 	logger           *zap.Logger
 	globalRepository *repositories.GlobalRepository
+
+	// Jobs running in background
+	jobs sync.WaitGroup
 }
 
 func New(
@@ -32,8 +77,42 @@ func New(
 	globalRepository *repositories.GlobalRepository,
 ) *Service {
 	service := &Service{
-		logger,
-		globalRepository,
+		logger:           logger,
+		globalRepository: globalRepository,
 	}
 	return service
 }
+
+// Close waits for the jobs running in background, until the context is done
+// It is called once the server stopped accepting requests, no job is started meanwhile
+func (svc *Service) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		svc.jobs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background jobs still running: %w", ctx.Err())
+	}
+}
+
+// withinTx runs fn in a transaction spanning all repositories
+// Errors which are not a GoCleanError (e.g. commit failure) are wrapped as database errors
+func (svc *Service) withinTx(ctx context.Context, fn func(repos *repositories.GlobalRepository) error) error {
+	return txError(svc.globalRepository.WithinTx(ctx, fn))
+}
+
+// txError wraps the transaction errors which are not a GoCleanError as database errors
+func txError(err error) error {
+	if err != nil {
+		var goCleanError errcode.GoCleanError
+		if !errors.As(err, &goCleanError) {
+			return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+		}
+		return err
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,7 +12,7 @@ import (
 	"github.com/spf13/viper"
 )
 
-func (svc *Service) GenerateToken(user *models.User) (tokenString string, err error) {
+func (svc *Service) GenerateToken(ctx context.Context, user *models.User) (tokenString string, err error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub":   user.ID,
 		"email": user.Email,
@@ -25,3 +27,34 @@ func (svc *Service) GenerateToken(user *models.User) (tokenString string, err er
 
 	return tokenString, nil
 }
+
+// AuthenticateToken verifies the token and returns the user it was issued for
+// The user is read from the database, so a deleted user or a revoked admin right is taken into account
+func (svc *Service) AuthenticateToken(ctx context.Context, tokenString string) (user *models.User, err error) {
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return []byte(viper.GetString("JWT_SECRET")), nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, fmt.Errorf("%w: %v", errcode.ErrTokenExpirated, err)
+		}
+		return nil, fmt.Errorf("%w: %v", errcode.ErrInvalidToken, err)
+	}
+
+	sub, ok := claims["sub"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing subject", errcode.ErrInvalidToken)
+	}
+	user, err = svc.globalRepository.User.GetByID(ctx, uint(sub))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	if user.ID == 0 {
+		return nil, fmt.Errorf("%w: unknown user %d", errcode.ErrInvalidToken, uint(sub))
+	}
+	return user, nil
+}
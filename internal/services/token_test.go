@@ -1,9 +1,15 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/sarrooo/go-clean/internal/errcode"
 	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
 )
 
 func (suite *ServiceSuiteTest) TestGenerateToken() {
@@ -36,7 +42,7 @@ func (suite *ServiceSuiteTest) TestGenerateToken() {
 
 	for testName, test := range tests {
 		suite.Run(testName, func() {
-			tokenString, err := suite.svc.GenerateToken(test.parameters.user)
+			tokenString, err := suite.svc.GenerateToken(context.Background(), test.parameters.user)
 
 			if test.expected.err != nil {
 				suite.Assert().Error(err, "Error should have occurred")
@@ -49,3 +55,70 @@ func (suite *ServiceSuiteTest) TestGenerateToken() {
 		})
 	}
 }
+
+func (suite *ServiceSuiteTest) TestAuthenticateToken() {
+	viper.Set("JWT_SECRET", "secret")
+	sign := func(method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		suite.Require().NoError(err)
+		return token
+	}
+	admin := &models.User{Model: models.Model{ID: 1}, IsAdmin: true}
+
+	type expectedType struct {
+		user *models.User
+		err  error
+	}
+
+	tests := map[string]struct {
+		setupMock func()
+		token     string
+		expected  expectedType
+	}{
+		"Success": {
+			setupMock: func() {
+				suite.globalRepositoryMock.User.On("GetByID", mock.Anything, uint(1)).Return(admin, nil)
+			},
+			token:    sign(jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": 1, "exp": time.Now().Add(time.Hour).Unix()}),
+			expected: expectedType{user: admin},
+		},
+		"Expired token": {
+			setupMock: func() {},
+			token:     sign(jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": 1, "exp": time.Now().Add(-time.Hour).Unix()}),
+			expected:  expectedType{err: errcode.ErrTokenExpirated},
+		},
+		"Wrong secret": {
+			setupMock: func() {},
+			token:     sign(jwt.SigningMethodHS256, []byte("other"), jwt.MapClaims{"sub": 1}),
+			expected:  expectedType{err: errcode.ErrInvalidToken},
+		},
+		"Unsigned token": {
+			setupMock: func() {},
+			token:     sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": 1}),
+			expected:  expectedType{err: errcode.ErrInvalidToken},
+		},
+		"Unknown user": {
+			setupMock: func() {
+				suite.globalRepositoryMock.User.On("GetByID", mock.Anything, uint(2)).Return(&models.User{}, nil)
+			},
+			token:    sign(jwt.SigningMethodHS256, []byte("secret"), jwt.MapClaims{"sub": 2}),
+			expected: expectedType{err: errcode.ErrInvalidToken},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			user, err := suite.svc.AuthenticateToken(context.Background(), test.token)
+
+			if test.expected.err != nil {
+				suite.Assert().True(errors.Is(err, test.expected.err), "Error type should match")
+				suite.Assert().Nil(user, "User should be nil")
+			} else {
+				suite.Assert().NoError(err, "No error should have occurred")
+				suite.Assert().Equal(test.expected.user, user, "User should match")
+			}
+		})
+	}
+}
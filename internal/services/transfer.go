@@ -0,0 +1,327 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/repositories"
+	"github.com/sarrooo/go-clean/internal/transfer"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Columns of the exported artists, the files can be imported again
+var artistColumns = []string{"id", "name", "albums", "version", "created_at", "updated_at"}
+
+// Number of artists read by query of an export
+const exportBatchSize = 500
+
+// Tag of the line errors of a malformed line
+const importTagFormat = "format"
+
+// recordValidator validates the imported records, the fields are named by their JSON name
+var recordValidator = newRecordValidator()
+
+func newRecordValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	})
+	return validate
+}
+
+// ExportArtists writes the artists and the names of their albums in the format
+// The artists are written by batches, so the export is streamed
+func (svc *Service) ExportArtists(ctx context.Context, format string, w io.Writer) (err error) {
+	writer, err := transfer.NewWriter(format, w, artistColumns)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+	}
+
+	// The write errors are not database errors, e.g. the client is gone
+	var writeErr error
+	err = svc.globalRepository.Artist.FindInBatches(ctx, exportBatchSize, func(artists []*models.Artist) error {
+		for _, artist := range artists {
+			albums := make([]string, 0, len(artist.Albums))
+			for _, album := range artist.Albums {
+				albums = append(albums, album.Name)
+			}
+			writeErr = writer.Write(artist.ID, artist.Name, albums, artist.Version, artist.CreatedAt, artist.UpdatedAt)
+			if writeErr != nil {
+				return writeErr
+			}
+		}
+		writeErr = writer.Flush()
+		return writeErr
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return writer.Flush()
+}
+
+// ImportArtists imports the artists of a file, and returns the outcome of its lines
+// The artists are upserted by name, and their missing albums are added
+// The invalid lines are reported and skipped, the valid lines are executed in best effort mode
+func (svc *Service) ImportArtists(ctx context.Context, imp *dto.Import) (report *dto.ImportReport, err error) {
+	reader, err := transfer.NewReader(imp.Format, bytes.NewReader(imp.Content), imp.Mapping)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+	}
+
+	report = &dto.ImportReport{Errors: []*dto.ImportLineError{}}
+	var records []*dto.ArtistRecord
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var lineError *transfer.LineError
+		if errors.As(err, &lineError) {
+			report.Total++
+			report.Fail(&dto.ImportLineError{Line: lineError.Line, Tag: importTagFormat, Message: lineError.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errcode.ErrInvalidParameters, err)
+		}
+
+		report.Total++
+		artistRecord := &dto.ArtistRecord{
+			Name:   transfer.String(record.Fields["name"]),
+			Albums: transfer.List(record.Fields["albums"]),
+		}
+		if lineErrors := validateRecord(record.Line, artistRecord); len(lineErrors) != 0 {
+			report.Fail(lineErrors...)
+			continue
+		}
+		records = append(records, artistRecord)
+		lines = append(lines, record.Line)
+	}
+
+	outcomes := make([]string, len(records))
+	upsert := func(repos *repositories.GlobalRepository, i int) (err error) {
+		outcomes[i], err = svc.upsertArtist(ctx, repos, records[i])
+		return err
+	}
+	var errs []error
+	if imp.DryRun {
+		errs = svc.runDryRun(ctx, len(records), upsert)
+	} else {
+		errs, err = svc.runBulk(ctx, dto.BulkBestEffort, len(records), upsert)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			report.Fail(&dto.ImportLineError{Line: lines[i], Message: errorMessage(err)})
+			continue
+		}
+		switch outcomes[i] {
+		case dto.ImportCreated:
+			report.Created++
+		case dto.ImportUpdated:
+			report.Updated++
+		default:
+			report.Unchanged++
+		}
+	}
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Line < report.Errors[j].Line
+	})
+	return report, nil
+}
+
+// validateRecord returns the validation errors of an imported line
+func validateRecord(line int, record interface{}) []*dto.ImportLineError {
+	var verr validator.ValidationErrors
+	if !errors.As(recordValidator.Struct(record), &verr) {
+		return nil
+	}
+	lineErrors := make([]*dto.ImportLineError, 0, len(verr))
+	for _, fieldError := range verr {
+		lineErrors = append(lineErrors, &dto.ImportLineError{
+			Line:    line,
+			Field:   fieldError.Field(),
+			Tag:     fieldError.Tag(),
+			Param:   fieldError.Param(),
+			Message: fieldError.Error(),
+		})
+	}
+	return lineErrors
+}
+
+// errorMessage returns the message of an error given to the client, like the error responses
+func errorMessage(err error) string {
+	var goCleanError errcode.GoCleanError
+	if errors.As(err, &goCleanError) {
+		return goCleanError.Error()
+	}
+	return "internal error"
+}
+
+// upsertArtist creates the artist of the record if there is no artist with its name, and adds its missing albums
+// It returns the outcome of the record. An artist created meanwhile by another request is a conflict of the record,
+// the unique index on the name refuses the second one
+func (svc *Service) upsertArtist(ctx context.Context, repos *repositories.GlobalRepository, record *dto.ArtistRecord) (string, error) {
+	outcome := dto.ImportUnchanged
+	artist, err := repos.Artist.GetByName(ctx, record.Name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		artist = &models.Artist{Name: record.Name}
+		if err := svc.createArtist(ctx, repos, artist); err != nil {
+			return "", err
+		}
+		outcome = dto.ImportCreated
+	} else if err != nil {
+		return "", fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+
+	albums, err := repos.Artist.AddAlbums(ctx, artist.ID, record.Albums)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return "", fmt.Errorf("%w: an album of artist %d was added meanwhile", errcode.ErrConflict, artist.ID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	for _, album := range albums {
+		if err := svc.audit(ctx, repos, audit.ActionCreate, audit.EntityAlbums, album.ID, nil, album); err != nil {
+			return "", err
+		}
+	}
+	if len(albums) != 0 && outcome == dto.ImportUnchanged {
+		outcome = dto.ImportUpdated
+	}
+	return outcome, nil
+}
+
+// importHeartbeatInterval is IMPORT_HEARTBEAT_INTERVAL, how often a running import job refreshes its heartbeat
+// (30 seconds by default)
+func importHeartbeatInterval() time.Duration {
+	if viper.GetDuration("IMPORT_HEARTBEAT_INTERVAL") <= 0 {
+		return 30 * time.Second
+	}
+	return viper.GetDuration("IMPORT_HEARTBEAT_INTERVAL")
+}
+
+// A job which missed this number of heartbeats is interrupted, its server is gone
+const importHeartbeatMisses = 3
+
+// StartImportArtists creates a job importing the artists in background, see ImportArtists
+// The job keeps the audit metadata of the context, and goes on after the end of the request
+func (svc *Service) StartImportArtists(ctx context.Context, imp *dto.Import) (job *models.ImportJob, err error) {
+	now := time.Now()
+	job = &models.ImportJob{EntityType: audit.EntityArtists, DryRun: imp.DryRun, Status: dto.ImportJobPending, HeartbeatAt: &now}
+	err = svc.globalRepository.ImportJob.Create(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+
+	// The job updates its own copy
+	running := *job
+	jobCtx := context.WithoutCancel(ctx)
+	svc.jobs.Add(1)
+	go func() {
+		defer svc.jobs.Done()
+		svc.runImportJob(jobCtx, &running, imp)
+	}()
+	return job, nil
+}
+
+func (svc *Service) runImportJob(ctx context.Context, job *models.ImportJob, imp *dto.Import) {
+	now := time.Now()
+	job.Status = dto.ImportJobRunning
+	job.HeartbeatAt = &now
+	if err := svc.globalRepository.ImportJob.Update(ctx, job); err != nil {
+		svc.logger.Error("import job update failed", zap.Error(err), zap.Uint("job_id", job.ID))
+	}
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		svc.heartbeatImportJob(heartbeatCtx, job.ID)
+	}()
+	report, err := svc.ImportArtists(ctx, imp)
+	stopHeartbeat()
+	<-heartbeatDone
+	if err == nil {
+		var encoded []byte
+		encoded, err = json.Marshal(report)
+		job.Report = string(encoded)
+	}
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	job.Status = dto.ImportJobSucceeded
+	if err != nil {
+		svc.logger.Error("import job failed", zap.Error(err), zap.Uint("job_id", job.ID))
+		job.Status = dto.ImportJobFailed
+		job.Error = errorMessage(err)
+		// The file errors are given to the client, e.g. a missing header
+		if errors.Is(err, errcode.ErrInvalidParameters) {
+			job.Error = err.Error()
+		}
+	}
+	if err := svc.globalRepository.ImportJob.Update(ctx, job); err != nil {
+		svc.logger.Error("import job update failed", zap.Error(err), zap.Uint("job_id", job.ID))
+	}
+}
+
+// heartbeatImportJob refreshes the heartbeat of a running job until the context is canceled
+func (svc *Service) heartbeatImportJob(ctx context.Context, id uint) {
+	ticker := time.NewTicker(importHeartbeatInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := svc.globalRepository.ImportJob.Heartbeat(ctx, id); err != nil && ctx.Err() == nil {
+				svc.logger.Error("import job heartbeat failed", zap.Error(err), zap.Uint("job_id", id))
+			}
+		}
+	}
+}
+
+// FailInterruptedImportJobs marks as failed the import jobs left pending or running by a server which is gone
+// A job runs in a server process, it doesn't survive a crash or a shutdown timeout. The jobs whose heartbeat is
+// fresh are running on another server, they are kept
+func (svc *Service) FailInterruptedImportJobs(ctx context.Context) (failed int64, err error) {
+	before := time.Now().Add(-importHeartbeatMisses * importHeartbeatInterval())
+	failed, err = svc.globalRepository.ImportJob.FailStale(ctx, before, "the import was interrupted by a stop of its server")
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return failed, nil
+}
+
+// GetImportJob returns an import job with its report
+func (svc *Service) GetImportJob(ctx context.Context, id uint) (job *models.ImportJob, err error) {
+	job, err = svc.globalRepository.ImportJob.Get(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: import job %d", errcode.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return job, nil
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func (suite *ServiceSuiteTest) TestExportArtists() {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	artists := []*models.Artist{{
+		Model:  models.Model{ID: 1, Version: 2, CreatedAt: createdAt, UpdatedAt: createdAt},
+		Name:   "Eminem",
+		Albums: []*models.Album{{Name: "Recovery"}, {Name: "Kamikaze"}},
+	}}
+	findInBatches := func(err error) func(ctx context.Context, batchSize int, fn func([]*models.Artist) error) error {
+		return func(ctx context.Context, batchSize int, fn func([]*models.Artist) error) error {
+			if err != nil {
+				return err
+			}
+			return fn(artists)
+		}
+	}
+
+	tests := map[string]struct {
+		format    string
+		setupMock func()
+		expected  string
+		expectErr error
+	}{
+		"CSV": {
+			format: "csv",
+			setupMock: func() {
+				suite.globalRepositoryMock.Artist.On("FindInBatches", mock.Anything, exportBatchSize, mock.Anything).Return(findInBatches(nil))
+			},
+			expected: "id,name,albums,version,created_at,updated_at\n1,Eminem,Recovery;Kamikaze,2,2024-03-01T10:00:00Z,2024-03-01T10:00:00Z\n",
+		},
+		"JSON Lines": {
+			format: "jsonl",
+			setupMock: func() {
+				suite.globalRepositoryMock.Artist.On("FindInBatches", mock.Anything, exportBatchSize, mock.Anything).Return(findInBatches(nil))
+			},
+			expected: `{"id":1,"name":"Eminem","albums":["Recovery","Kamikaze"],"version":2,"created_at":"2024-03-01T10:00:00Z","updated_at":"2024-03-01T10:00:00Z"}` + "\n",
+		},
+		"Unknown format": {
+			format:    "xlsx",
+			setupMock: func() {},
+			expectErr: errcode.ErrInvalidParameters,
+		},
+		"Database error": {
+			format: "csv",
+			setupMock: func() {
+				suite.globalRepositoryMock.Artist.On("FindInBatches", mock.Anything, exportBatchSize, mock.Anything).Return(findInBatches(errors.New("connection lost")))
+			},
+			expectErr: errcode.ErrDatabase,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+			var output bytes.Buffer
+
+			err := suite.svc.ExportArtists(context.Background(), test.format, &output)
+
+			if test.expectErr != nil {
+				suite.Assert().True(errors.Is(err, test.expectErr), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expected, output.String())
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestImportArtists() {
+	content := "name,albums\nEminem,Recovery;Kamikaze\nDr. Dre,\n,Compton\nNew,\n"
+	eminem := &models.Artist{Model: models.Model{ID: 1}, Name: "Eminem"}
+	dre := &models.Artist{Model: models.Model{ID: 2}, Name: "Dr. Dre"}
+	setupMock := func() {
+		suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+		suite.globalRepositoryMock.Artist.On("GetByName", mock.Anything, "Eminem").Return(eminem, nil)
+		suite.globalRepositoryMock.Artist.On("AddAlbums", mock.Anything, uint(1), []string{"Recovery", "Kamikaze"}).
+			Return([]*models.Album{{Model: models.Model{ID: 7}, Name: "Kamikaze", ArtistID: 1}}, nil)
+		suite.globalRepositoryMock.ExpectAudit(audit.ActionCreate, audit.EntityAlbums, 7)
+		suite.globalRepositoryMock.Artist.On("GetByName", mock.Anything, "Dr. Dre").Return(dre, nil)
+		suite.globalRepositoryMock.Artist.On("AddAlbums", mock.Anything, uint(2), []string{}).Return(nil, nil)
+		suite.globalRepositoryMock.Artist.On("GetByName", mock.Anything, "New").Return(nil, gorm.ErrRecordNotFound)
+		suite.globalRepositoryMock.Artist.On("Create", mock.Anything, mock.MatchedBy(func(artist *models.Artist) bool { return artist.Name == "New" })).
+			Run(func(args mock.Arguments) { args.Get(1).(*models.Artist).ID = 3 }).Return(nil)
+		suite.globalRepositoryMock.ExpectAudit(audit.ActionCreate, audit.EntityArtists, 3)
+		suite.globalRepositoryMock.Artist.On("AddAlbums", mock.Anything, uint(3), []string{}).Return(nil, nil)
+	}
+	expected := &dto.ImportReport{
+		Total:     4,
+		Created:   1,
+		Updated:   1,
+		Unchanged: 1,
+		Failed:    1,
+		Errors: []*dto.ImportLineError{{
+			Line:    4,
+			Field:   "name",
+			Tag:     "required",
+			Message: "Key: 'ArtistRecord.name' Error:Field validation for 'name' failed on the 'required' tag",
+		}},
+	}
+
+	tests := map[string]struct {
+		imp       *dto.Import
+		setupMock func()
+		expected  *dto.ImportReport
+		expectErr error
+	}{
+		"Success": {
+			imp:       &dto.Import{Format: "csv", Content: []byte(content)},
+			setupMock: setupMock,
+			expected:  expected,
+		},
+		"Dry run": {
+			imp:       &dto.Import{Format: "csv", Content: []byte(content), DryRun: true},
+			setupMock: setupMock,
+			expected:  expected,
+		},
+		"Mapping": {
+			imp: &dto.Import{Format: "jsonl", Content: []byte(`{"artist":"Eminem"}` + "\n" + `"name"`), Mapping: map[string]string{"artist": "name"}},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByName", mock.Anything, "Eminem").Return(eminem, nil)
+				suite.globalRepositoryMock.Artist.On("AddAlbums", mock.Anything, uint(1), []string{}).Return(nil, nil)
+			},
+			expected: &dto.ImportReport{
+				Total:     2,
+				Unchanged: 1,
+				Failed:    1,
+				Errors:    []*dto.ImportLineError{{Line: 2, Tag: importTagFormat, Message: "the line is not a JSON object"}},
+			},
+		},
+		"Database error": {
+			imp: &dto.Import{Format: "csv", Content: []byte("name\nEminem\n")},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByName", mock.Anything, "Eminem").Return(nil, errors.New("connection lost"))
+			},
+			expected: &dto.ImportReport{
+				Total:  1,
+				Failed: 1,
+				Errors: []*dto.ImportLineError{{Line: 2, Message: errcode.ErrDatabase.Error()}},
+			},
+		},
+		"Artist created meanwhile": {
+			imp: &dto.Import{Format: "csv", Content: []byte("name\nNew\n")},
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Artist.On("GetByName", mock.Anything, "New").Return(nil, gorm.ErrRecordNotFound)
+				suite.globalRepositoryMock.Artist.On("Create", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey)
+			},
+			expected: &dto.ImportReport{
+				Total:  1,
+				Failed: 1,
+				Errors: []*dto.ImportLineError{{Line: 2, Message: errcode.ErrConflict.Error()}},
+			},
+		},
+		"Missing header": {
+			imp:       &dto.Import{Format: "csv"},
+			setupMock: func() {},
+			expectErr: errcode.ErrInvalidParameters,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			report, err := suite.svc.ImportArtists(context.Background(), test.imp)
+
+			if test.expectErr != nil {
+				suite.Assert().True(errors.Is(err, test.expectErr), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expected, report)
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestStartImportArtists() {
+	isStatus := func(status string) interface{} {
+		return mock.MatchedBy(func(job *models.ImportJob) bool { return job.Status == status })
+	}
+
+	tests := map[string]struct {
+		content        string
+		setupMock      func()
+		expectedStatus string
+	}{
+		"Success": {
+			content: "name\n",
+			setupMock: func() {
+				suite.globalRepositoryMock.ImportJob.On("Create", mock.Anything, isStatus(dto.ImportJobPending)).
+					Run(func(args mock.Arguments) { args.Get(1).(*models.ImportJob).ID = 1 }).Return(nil).Once()
+				suite.globalRepositoryMock.ImportJob.On("Update", mock.Anything, isStatus(dto.ImportJobRunning)).Return(nil).Once()
+				suite.globalRepositoryMock.ImportJob.On("Update", mock.Anything, mock.MatchedBy(func(job *models.ImportJob) bool {
+					return job.Status == dto.ImportJobSucceeded && job.FinishedAt != nil && job.Report != ""
+				})).Return(nil).Once()
+			},
+			expectedStatus: dto.ImportJobPending,
+		},
+		"Invalid file": {
+			content: "",
+			setupMock: func() {
+				suite.globalRepositoryMock.ImportJob.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				suite.globalRepositoryMock.ImportJob.On("Update", mock.Anything, isStatus(dto.ImportJobRunning)).Return(nil).Once()
+				suite.globalRepositoryMock.ImportJob.On("Update", mock.Anything, mock.MatchedBy(func(job *models.ImportJob) bool {
+					return job.Status == dto.ImportJobFailed && job.Error != ""
+				})).Return(nil).Once()
+			},
+			expectedStatus: dto.ImportJobPending,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+			ctx, cancel := context.WithCancel(context.Background())
+
+			job, err := suite.svc.StartImportArtists(ctx, &dto.Import{Format: "csv", Content: []byte(test.content)})
+			// The job goes on after the end of the request
+			cancel()
+			suite.Require().NoError(suite.svc.Close(context.Background()))
+
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(test.expectedStatus, job.Status, "The returned job should not be updated by the background job")
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestCloseTimeout() {
+	suite.svc.jobs.Add(1)
+	defer suite.svc.jobs.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	err := suite.svc.Close(ctx)
+
+	suite.Assert().ErrorIs(err, context.DeadlineExceeded, "A running job should not be waited after the timeout")
+}
+
+func (suite *ServiceSuiteTest) TestFailInterruptedImportJobs() {
+	tests := map[string]struct {
+		setupMock func()
+		expected  error
+	}{
+		"Success": {
+			setupMock: func() {
+				// The jobs which missed 3 heartbeats of 30 seconds
+				isStale := mock.MatchedBy(func(before time.Time) bool {
+					return time.Since(before) >= 90*time.Second && time.Since(before) < 91*time.Second
+				})
+				suite.globalRepositoryMock.ImportJob.On("FailStale", mock.Anything, isStale, mock.Anything).Return(int64(2), nil).Once()
+			},
+		},
+		"Database error": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ImportJob.On("FailStale", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), errors.New("connection lost")).Once()
+			},
+			expected: errcode.ErrDatabase,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			failed, err := suite.svc.FailInterruptedImportJobs(context.Background())
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(int64(2), failed)
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestGetImportJob() {
+	tests := map[string]struct {
+		setupMock func()
+		expected  error
+	}{
+		"Success": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ImportJob.On("Get", mock.Anything, uint(1)).Return(&models.ImportJob{ID: 1}, nil)
+			},
+		},
+		"Unknown job": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ImportJob.On("Get", mock.Anything, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expected: errcode.ErrNotFound,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			job, err := suite.svc.GetImportJob(context.Background(), 1)
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+				return
+			}
+			suite.Require().NoError(err, "No error should have occurred")
+			suite.Assert().Equal(uint(1), job.ID)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/sarrooo/go-clean/internal/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Trash entities in purge order, an entity is purged before the entities it references
+var trashEntities = []string{dto.TrashEntityAlbums, dto.TrashEntityArtists}
+
+// ListTrash returns the soft deleted entities
+func (svc *Service) ListTrash(ctx context.Context, entity string) (items []*dto.TrashItem, err error) {
+	items, err = svc.globalRepository.Trash.List(ctx, entity)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	return items, nil
+}
+
+// RestoreTrash restores a soft deleted entity
+// It fails with a conflict if an active entity took its unique key meanwhile
+func (svc *Service) RestoreTrash(ctx context.Context, entity string, id uint) (err error) {
+	return svc.withinTx(ctx, func(repos *repositories.GlobalRepository) error {
+		err := repos.Trash.Restore(ctx, entity, id)
+		if err != nil {
+			return trashError(entity, id, err)
+		}
+		return svc.audit(ctx, repos, audit.ActionRestore, entity, id, nil, nil)
+	})
+}
+
+// PurgeTrash permanently deletes a soft deleted entity
+// It fails with a conflict if other entities still reference it
+func (svc *Service) PurgeTrash(ctx context.Context, entity string, id uint) (err error) {
+	return svc.withinTx(ctx, func(repos *repositories.GlobalRepository) error {
+		err := repos.Trash.Purge(ctx, entity, id)
+		if err != nil {
+			return trashError(entity, id, err)
+		}
+		return svc.audit(ctx, repos, audit.ActionPurge, entity, id, nil, nil)
+	})
+}
+
+// PurgeExpiredTrash permanently deletes the entities soft deleted before the given date
+// Entities which can't be purged (still referenced) are logged and skipped
+func (svc *Service) PurgeExpiredTrash(ctx context.Context, before time.Time) (purged int, err error) {
+	for _, entity := range trashEntities {
+		ids, err := svc.globalRepository.Trash.ListDeletedBefore(ctx, entity, before)
+		if err != nil {
+			return purged, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+		}
+		for _, id := range ids {
+			err = svc.PurgeTrash(ctx, entity, id)
+			if err != nil {
+				svc.logger.Warn("trash purge skipped", zap.String("entity", entity), zap.Uint("id", id), zap.Error(err))
+				continue
+			}
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func trashError(entity string, id uint, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return fmt.Errorf("%w: %s %d is not in the trash", errcode.ErrNotFound, entity, id)
+	case errors.Is(err, gorm.ErrDuplicatedKey), errors.Is(err, gorm.ErrForeignKeyViolated):
+		return fmt.Errorf("%w: %s %d: %v", errcode.ErrConflict, entity, id, err)
+	default:
+		return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/audit"
+	"github.com/sarrooo/go-clean/internal/dto"
+	"github.com/sarrooo/go-clean/internal/errcode"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func (suite *ServiceSuiteTest) TestRestoreTrash() {
+	tests := map[string]struct {
+		setupMock func()
+		expected  error
+	}{
+		"Success": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Trash.On("Restore", mock.Anything, dto.TrashEntityArtists, uint(1)).Return(nil)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionRestore, dto.TrashEntityArtists, 1)
+			},
+			expected: nil,
+		},
+		"Not in the trash": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Trash.On("Restore", mock.Anything, dto.TrashEntityArtists, uint(1)).Return(gorm.ErrRecordNotFound)
+			},
+			expected: errcode.ErrNotFound,
+		},
+		"Unique key taken": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Trash.On("Restore", mock.Anything, dto.TrashEntityArtists, uint(1)).Return(gorm.ErrDuplicatedKey)
+			},
+			expected: errcode.ErrConflict,
+		},
+		"Database error": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Trash.On("Restore", mock.Anything, dto.TrashEntityArtists, uint(1)).Return(errors.New("connection lost"))
+			},
+			expected: errcode.ErrDatabase,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			err := suite.svc.RestoreTrash(context.Background(), dto.TrashEntityArtists, 1)
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+			} else {
+				suite.Assert().NoError(err, "No error should have occurred")
+			}
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestPurgeTrash() {
+	tests := map[string]struct {
+		setupMock func()
+		expected  error
+	}{
+		"Success": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Trash.On("Purge", mock.Anything, dto.TrashEntityArtists, uint(1)).Return(nil)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionPurge, dto.TrashEntityArtists, 1)
+			},
+			expected: nil,
+		},
+		"Still referenced": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.Trash.On("Purge", mock.Anything, dto.TrashEntityArtists, uint(1)).Return(gorm.ErrForeignKeyViolated)
+			},
+			expected: errcode.ErrConflict,
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			err := suite.svc.PurgeTrash(context.Background(), dto.TrashEntityArtists, 1)
+
+			if test.expected != nil {
+				suite.Assert().True(errors.Is(err, test.expected), "Error type should match")
+			} else {
+				suite.Assert().NoError(err, "No error should have occurred")
+			}
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestPurgeExpiredTrash() {
+	before := time.Now()
+
+	type expectedType struct {
+		purged int
+		err    error
+	}
+
+	tests := map[string]struct {
+		setupMock func()
+		expected  expectedType
+	}{
+		"Purge albums then artists, skip referenced": {
+			setupMock: func() {
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				call := suite.globalRepositoryMock.Trash.On("ListDeletedBefore", mock.Anything, dto.TrashEntityAlbums, before).Return([]uint{1}, nil)
+				suite.globalRepositoryMock.Trash.On("Purge", mock.Anything, dto.TrashEntityAlbums, uint(1)).Return(nil).NotBefore(call)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionPurge, dto.TrashEntityAlbums, 1)
+				call = suite.globalRepositoryMock.Trash.On("ListDeletedBefore", mock.Anything, dto.TrashEntityArtists, before).Return([]uint{2, 3}, nil)
+				suite.globalRepositoryMock.Trash.On("Purge", mock.Anything, dto.TrashEntityArtists, uint(2)).Return(gorm.ErrForeignKeyViolated).NotBefore(call)
+				suite.globalRepositoryMock.Trash.On("Purge", mock.Anything, dto.TrashEntityArtists, uint(3)).Return(nil).NotBefore(call)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionPurge, dto.TrashEntityArtists, 3)
+			},
+			expected: expectedType{purged: 2},
+		},
+		"Database error": {
+			setupMock: func() {
+				suite.globalRepositoryMock.Trash.On("ListDeletedBefore", mock.Anything, dto.TrashEntityAlbums, before).Return(nil, errors.New("connection lost"))
+			},
+			expected: expectedType{err: errcode.ErrDatabase},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
+
+			purged, err := suite.svc.PurgeExpiredTrash(context.Background(), before)
+
+			suite.Assert().Equal(test.expected.purged, purged, "Purged count should match")
+			if test.expected.err != nil {
+				suite.Assert().True(errors.Is(err, test.expected.err), "Error type should match")
+			} else {
+				suite.Assert().NoError(err, "No error should have occurred")
+			}
+		})
+	}
+}
@@ -1,39 +1,49 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/sarrooo/go-clean/internal/audit"
 	"github.com/sarrooo/go-clean/internal/dto"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/sarrooo/go-clean/internal/errcode"
 	"github.com/sarrooo/go-clean/internal/models"
+	"github.com/sarrooo/go-clean/internal/repositories"
 )
 
 // RegisterUser creates a new user in the database
-func (svc *Service) RegisterUser(registerUser *dto.RegisterUser) (user *models.User, err error) {
-	user, err = svc.globalRepository.User.GetByEmail(registerUser.Email)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
-	}
-
-	// if user already exists, return error
-	if user.ID != 0 {
-		return nil, fmt.Errorf("%w", errcode.ErrUserAlreadyExists)
-	}
-
+// The existence check, the creation and its audit log run in the same transaction
+func (svc *Service) RegisterUser(ctx context.Context, registerUser *dto.RegisterUser) (user *models.User, err error) {
 	user, err = svc.formatRegisterUser(registerUser)
 	if err != nil {
 		return nil, err
 	}
 
-	// create user in database
-	err = svc.globalRepository.User.Create(user)
+	err = svc.withinTx(ctx, func(repos *repositories.GlobalRepository) error {
+		existingUser, err := repos.User.GetByEmail(ctx, user.Email)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+		}
+
+		// if user already exists, return error
+		if existingUser.ID != 0 {
+			return fmt.Errorf("%w", errcode.ErrUserAlreadyExists)
+		}
+
+		// create user in database
+		err = repos.User.Create(ctx, user)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+		}
+		return svc.audit(ctx, repos, audit.ActionCreate, audit.EntityUsers, user.ID, nil, user)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+		return nil, err
 	}
 
 	return user, nil
@@ -41,9 +51,9 @@ func (svc *Service) RegisterUser(registerUser *dto.RegisterUser) (user *models.U
 
 // LoginUser checks if the user exists and if the password is correct
 // If the user exists and the password is correct, it returns the user, otherwise it returns an error
-func (svc *Service) LoginUser(email, password string) (user *models.User, err error) {
+func (svc *Service) LoginUser(ctx context.Context, email, password string) (user *models.User, err error) {
 	// check if email already exists
-	user, err = svc.globalRepository.User.GetByEmail(email)
+	user, err = svc.globalRepository.User.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
 	}
@@ -62,16 +72,28 @@ func (svc *Service) LoginUser(email, password string) (user *models.User, err er
 	return user, nil
 }
 
+// GetUserByEmail returns the user with the given email
+// If the user does not exist, it returns a not found error
+func (svc *Service) GetUserByEmail(ctx context.Context, email string) (user *models.User, err error) {
+	user, err = svc.globalRepository.User.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errcode.ErrDatabase, err)
+	}
+	if user.ID == 0 {
+		return nil, fmt.Errorf("%w: user %s", errcode.ErrNotFound, email)
+	}
+	return user, nil
+}
+
 func (svc *Service) formatRegisterUser(registerUser *dto.RegisterUser) (user *models.User, err error) {
 	// format email
 	registerUser.Email = strings.ToLower(strings.TrimSpace(registerUser.Email))
 
 	// password hashing
-	passwordHash, err := bcrypt.GenerateFromPassword([]byte(registerUser.Password), 10)
+	registerUser.Password, err = models.HashPassword(registerUser.Password)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", errcode.ErrExternalLib, err)
 	}
-	registerUser.Password = string(passwordHash)
 
 	// parse birth date
 	var parsedBirthDate time.Time
@@ -89,6 +111,7 @@ func (svc *Service) formatRegisterUser(registerUser *dto.RegisterUser) (user *mo
 		LastName:  registerUser.LastName,
 		Phone:     registerUser.Phone,
 		BirthDate: parsedBirthDate,
+		IsAdmin:   registerUser.IsAdmin,
 	}
 	return user, nil
 }
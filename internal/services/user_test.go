@@ -1,8 +1,11 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"strings"
 
+	"github.com/sarrooo/go-clean/internal/audit"
 	"github.com/sarrooo/go-clean/internal/dto"
 	"github.com/sarrooo/go-clean/internal/errcode"
 	"github.com/sarrooo/go-clean/internal/models"
@@ -40,8 +43,10 @@ func (suite *ServiceSuiteTest) TestRegisterUser() {
 	}{
 		"Success": {
 			setupMock: func() {
-				suite.globalRepositoryMock.User.On("GetByEmail", sampleDtoUser.Email).Return(&models.User{}, nil)
-				suite.globalRepositoryMock.User.On("Create", mock.AnythingOfType("*models.User")).Return(nil)
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(&models.User{}, nil)
+				suite.globalRepositoryMock.User.On("Create", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil)
+				suite.globalRepositoryMock.ExpectAudit(audit.ActionCreate, audit.EntityUsers, 0)
 			},
 			parameters: parametersType{
 				registerUser: sampleDtoUser,
@@ -53,7 +58,8 @@ func (suite *ServiceSuiteTest) TestRegisterUser() {
 		},
 		"Error in GetByEmail": {
 			setupMock: func() {
-				suite.globalRepositoryMock.User.On("GetByEmail", sampleDtoUser.Email).Return(nil, errcode.ErrDatabase)
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(nil, errcode.ErrDatabase)
 			},
 			parameters: parametersType{
 				registerUser: sampleDtoUser,
@@ -65,7 +71,8 @@ func (suite *ServiceSuiteTest) TestRegisterUser() {
 		},
 		"User already exist": {
 			setupMock: func() {
-				suite.globalRepositoryMock.User.On("GetByEmail", sampleDtoUser.Email).Return(sampleModelUser, nil)
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(sampleModelUser, nil)
 			},
 			parameters: parametersType{
 				registerUser: sampleDtoUser,
@@ -77,8 +84,9 @@ func (suite *ServiceSuiteTest) TestRegisterUser() {
 		},
 		"Error in Create": {
 			setupMock: func() {
-				suite.globalRepositoryMock.User.On("GetByEmail", sampleDtoUser.Email).Return(&models.User{}, nil)
-				suite.globalRepositoryMock.User.On("Create", mock.AnythingOfType("*models.User")).Return(errcode.ErrDatabase)
+				suite.globalRepositoryMock.ExpectWithinTx(suite.svc.globalRepository)
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(&models.User{}, nil)
+				suite.globalRepositoryMock.User.On("Create", mock.Anything, mock.AnythingOfType("*models.User")).Return(errcode.ErrDatabase)
 			},
 			parameters: parametersType{
 				registerUser: sampleDtoUser,
@@ -89,9 +97,7 @@ func (suite *ServiceSuiteTest) TestRegisterUser() {
 			},
 		},
 		"Wrong Birth Date format": {
-			setupMock: func() {
-				suite.globalRepositoryMock.User.On("GetByEmail", sampleDtoUser.Email).Return(&models.User{}, nil)
-			},
+			setupMock: func() {},
 			parameters: parametersType{
 				registerUser: &dto.RegisterUser{
 					Email:     sampleDtoUser.Email,
@@ -110,7 +116,7 @@ func (suite *ServiceSuiteTest) TestRegisterUser() {
 		suite.Run(testName, func() {
 			test.setupMock()
 
-			user, err := suite.svc.RegisterUser(test.parameters.registerUser)
+			user, err := suite.svc.RegisterUser(context.Background(), test.parameters.registerUser)
 
 			if test.expected.err != nil {
 				suite.Assert().Error(err, "Error should have occurred")
@@ -140,7 +146,7 @@ func (suite *ServiceSuiteTest) TestLoginUser() {
 	}{
 		"Success": {
 			setupMock: func() {
-				suite.globalRepositoryMock.User.On("GetByEmail", sampleDtoUser.Email).Return(sampleModelUser, nil)
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(sampleModelUser, nil)
 			},
 			parameters: parametersType{
 				email:    sampleDtoUser.Email,
@@ -153,7 +159,7 @@ func (suite *ServiceSuiteTest) TestLoginUser() {
 		},
 		"Error in GetByEmail": {
 			setupMock: func() {
-				suite.globalRepositoryMock.User.On("GetByEmail", sampleDtoUser.Email).Return(nil, errcode.ErrDatabase)
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(nil, errcode.ErrDatabase)
 			},
 			parameters: parametersType{
 				email:    sampleDtoUser.Email,
@@ -166,7 +172,7 @@ func (suite *ServiceSuiteTest) TestLoginUser() {
 		},
 		"User not exist": {
 			setupMock: func() {
-				suite.globalRepositoryMock.User.On("GetByEmail", sampleDtoUser.Email).Return(nil, nil)
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(nil, nil)
 			},
 			parameters: parametersType{
 				email:    sampleDtoUser.Email,
@@ -179,7 +185,7 @@ func (suite *ServiceSuiteTest) TestLoginUser() {
 		},
 		"Wrong Password": {
 			setupMock: func() {
-				suite.globalRepositoryMock.User.On("GetByEmail", sampleDtoUser.Email).Return(sampleModelUser, nil)
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(sampleModelUser, nil)
 			},
 			parameters: parametersType{
 				email:    sampleDtoUser.Email,
@@ -196,14 +202,71 @@ func (suite *ServiceSuiteTest) TestLoginUser() {
 		suite.Run(testName, func() {
 			test.setupMock()
 
-			user, err := suite.svc.LoginUser(test.parameters.email, test.parameters.password)
+			user, err := suite.svc.LoginUser(context.Background(), test.parameters.email, test.parameters.password)
+
+			if test.expected.err != nil {
+				suite.Assert().Error(err, "Error should have occurred")
+				suite.Assert().True(errors.Is(err, test.expected.err), "Error type should match")
+				suite.Assert().Nil(user, "User should be nil")
+			} else {
+				suite.Assert().NoError(err, "No error should have occurred")
+			}
+		})
+	}
+}
+
+func (suite *ServiceSuiteTest) TestGetUserByEmail() {
+	type expectedType struct {
+		user *models.User
+		err  error
+	}
+
+	tests := map[string]struct {
+		setupMock func()
+		email     string
+		expected  expectedType
+	}{
+		"Success": {
+			setupMock: func() {
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(sampleModelUser, nil)
+			},
+			email: " " + strings.ToUpper(sampleDtoUser.Email),
+			expected: expectedType{
+				user: sampleModelUser,
+			},
+		},
+		"Error in GetByEmail": {
+			setupMock: func() {
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(nil, errcode.ErrDatabase)
+			},
+			email: sampleDtoUser.Email,
+			expected: expectedType{
+				err: errcode.ErrDatabase,
+			},
+		},
+		"User not exist": {
+			setupMock: func() {
+				suite.globalRepositoryMock.User.On("GetByEmail", mock.Anything, sampleDtoUser.Email).Return(&models.User{}, nil)
+			},
+			email: sampleDtoUser.Email,
+			expected: expectedType{
+				err: errcode.ErrNotFound,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		suite.Run(testName, func() {
+			test.setupMock()
 
+			user, err := suite.svc.GetUserByEmail(context.Background(), test.email)
 			if test.expected.err != nil {
 				suite.Assert().Error(err, "Error should have occurred")
 				suite.Assert().True(errors.Is(err, test.expected.err), "Error type should match")
 				suite.Assert().Nil(user, "User should be nil")
 			} else {
 				suite.Assert().NoError(err, "No error should have occurred")
+				suite.Assert().Equal(test.expected.user, user)
 			}
 		})
 	}
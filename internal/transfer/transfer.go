@@ -0,0 +1,368 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of the imported and exported files
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// ListSeparator separates the values of a list in a CSV cell, e.g. Recovery;Kamikaze
+const ListSeparator = ";"
+
+// Mapping value of a column which is not imported
+const Ignored = "-"
+
+// ErrFormat is returned for an unknown format
+var ErrFormat = errors.New("unknown format")
+
+// Byte order mark written by spreadsheets at the start of UTF-8 files
+var bom = []byte{0xEF, 0xBB, 0xBF}
+
+// FormatOf returns the format of a file name from its extension, "" if it is not known
+func FormatOf(fileName string) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv":
+		return FormatCSV
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	default:
+		return ""
+	}
+}
+
+// ContentType returns the MIME type of the format
+func ContentType(format string) string {
+	if format == FormatJSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Record is a line of an imported file
+// Fields maps the field names to strings, or to lists for JSON arrays
+type Record struct {
+	Line   int
+	Fields map[string]interface{}
+}
+
+// LineError is an invalid line, the next lines can still be read
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads the records of an imported file, it returns io.EOF after the last record
+type Reader interface {
+	Read() (*Record, error)
+}
+
+// NewReader returns the reader of the format
+// mapping maps the columns (CSV headers or JSON keys) to field names, Ignored skips a column
+// An unmapped column is named by its normalized name, e.g. "Artist Name" is artist_name
+func NewReader(format string, r io.Reader, mapping map[string]string) (Reader, error) {
+	buffered := bufio.NewReader(r)
+	if prefix, err := buffered.Peek(len(bom)); err == nil && bytes.Equal(prefix, bom) {
+		_, _ = buffered.Discard(len(bom))
+	}
+
+	switch format {
+	case FormatCSV:
+		return newCSVReader(buffered, mapping)
+	case FormatJSONL:
+		return &jsonlReader{scanner: newLineScanner(buffered), mapping: mapping}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrFormat, format)
+	}
+}
+
+// fieldName returns the field of a column, "" if it is ignored
+func fieldName(column string, mapping map[string]string) string {
+	column = strings.TrimSpace(column)
+	if field, ok := mapping[column]; ok {
+		if field == Ignored {
+			return ""
+		}
+		return field
+	}
+	return strings.ReplaceAll(strings.ToLower(column), " ", "_")
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	fields []string
+}
+
+func newCSVReader(r io.Reader, mapping map[string]string) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the header is missing")
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, len(header))
+	for i, column := range header {
+		fields[i] = fieldName(column, mapping)
+	}
+	return &csvReader{reader: reader, fields: fields}, nil
+}
+
+func (r *csvReader) Read() (*Record, error) {
+	values, err := r.reader.Read()
+	if err != nil {
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			return nil, &LineError{Line: parseError.StartLine, Err: parseError.Err}
+		}
+		return nil, err
+	}
+	line, _ := r.reader.FieldPos(0)
+	if len(values) != len(r.fields) {
+		return nil, &LineError{Line: line, Err: fmt.Errorf("%d values for %d columns", len(values), len(r.fields))}
+	}
+	record := &Record{Line: line, Fields: make(map[string]interface{}, len(values))}
+	for i, value := range values {
+		if r.fields[i] != "" {
+			record.Fields[r.fields[i]] = unescapeFormula(value)
+		}
+	}
+	return record, nil
+}
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	mapping map[string]string
+	line    int
+}
+
+// Maximum size of a JSON line
+const maxLineSize = 1 << 20
+
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return scanner
+}
+
+func (r *jsonlReader) Read() (*Record, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var object map[string]interface{}
+		if err := json.Unmarshal(line, &object); err != nil {
+			return nil, &LineError{Line: r.line, Err: errors.New("the line is not a JSON object")}
+		}
+		record := &Record{Line: r.line, Fields: make(map[string]interface{}, len(object))}
+		for key, value := range object {
+			field := fieldName(key, r.mapping)
+			if field == "" {
+				continue
+			}
+			record.Fields[field] = jsonValue(value)
+		}
+		return record, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// jsonValue converts a JSON value to a string, or to a list of strings for an array
+func jsonValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			values = append(values, String(jsonValue(item)))
+		}
+		return values
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+}
+
+// String returns the string of a record field, the values of a list are joined
+func String(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case []string:
+		return strings.Join(value, ListSeparator)
+	default:
+		return ""
+	}
+}
+
+// List returns the values of a record field, a string is split by ListSeparator
+// The values are trimmed, and the empty values are skipped
+func List(value interface{}) []string {
+	var values []string
+	switch value := value.(type) {
+	case string:
+		values = strings.Split(value, ListSeparator)
+	case []string:
+		values = value
+	}
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// Writer writes the records of an exported file
+type Writer interface {
+	// Write writes a record, its values are in the order of the columns
+	Write(values ...interface{}) error
+	// Flush writes the buffered records
+	Flush() error
+}
+
+// NewWriter returns the writer of the format, the CSV header is written with the first record
+func NewWriter(format string, w io.Writer, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w), columns: columns}, nil
+	case FormatJSONL:
+		return &jsonlWriter{writer: bufio.NewWriter(w), columns: columns}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrFormat, format)
+	}
+}
+
+type csvWriter struct {
+	writer      *csv.Writer
+	columns     []string
+	wroteHeader bool
+}
+
+func (w *csvWriter) Write(values ...interface{}) error {
+	if !w.wroteHeader {
+		if err := w.writer.Write(w.columns); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = csvValue(value)
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Flush() error {
+	if !w.wroteHeader {
+		// An empty export still has its header
+		if err := w.writer.Write(w.columns); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func csvValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return escapeFormula(value)
+	case []string:
+		return escapeFormula(strings.Join(value, ListSeparator))
+	case time.Time:
+		return value.UTC().Format(time.RFC3339)
+	default:
+		return escapeFormula(fmt.Sprint(value))
+	}
+}
+
+// First characters of a cell which a spreadsheet evaluates as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula prefixes a cell which would be evaluated as a formula by a spreadsheet with a quote, e.g. =HYPERLINK()
+// The quote makes it a text, and is removed by the CSV reader
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeFormula removes the quote added by escapeFormula
+func unescapeFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+type jsonlWriter struct {
+	writer  *bufio.Writer
+	columns []string
+}
+
+func (w *jsonlWriter) Write(values ...interface{}) error {
+	// The object is written field by field to keep the order of the columns
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		key, _ := json.Marshal(w.columns[i])
+		line.Write(key)
+		line.WriteByte(':')
+		if t, ok := value.(time.Time); ok {
+			value = t.UTC().Format(time.RFC3339)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		line.Write(encoded)
+	}
+	line.WriteString("}\n")
+	_, err := w.writer.Write(line.Bytes())
+	return err
+}
+
+func (w *jsonlWriter) Flush() error {
+	return w.writer.Flush()
+}
@@ -0,0 +1,168 @@
+package transfer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readAll returns the records and the line errors of a file
+func readAll(reader Reader) (records []*Record, lineErrors []int, err error) {
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, lineErrors, nil
+		}
+		var lineError *LineError
+		if errors.As(err, &lineError) {
+			lineErrors = append(lineErrors, lineError.Line)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+func TestReader(t *testing.T) {
+	tests := map[string]struct {
+		format             string
+		content            string
+		mapping            map[string]string
+		expected           []*Record
+		expectedLineErrors []int
+		expectedErr        bool
+	}{
+		"CSV": {
+			format:  FormatCSV,
+			content: "\xEF\xBB\xBFName,Albums\nEminem,Recovery;Kamikaze\n\"Dr. Dre\",\n",
+			expected: []*Record{
+				{Line: 2, Fields: map[string]interface{}{"name": "Eminem", "albums": "Recovery;Kamikaze"}},
+				{Line: 3, Fields: map[string]interface{}{"name": "Dr. Dre", "albums": ""}},
+			},
+		},
+		"CSV mapping": {
+			format:   FormatCSV,
+			content:  "Artist Name,Id,Country\nEminem,1,US\n",
+			mapping:  map[string]string{"Artist Name": "name", "Id": Ignored},
+			expected: []*Record{{Line: 2, Fields: map[string]interface{}{"name": "Eminem", "country": "US"}}},
+		},
+		"CSV invalid line": {
+			format:             FormatCSV,
+			content:            "name\nEminem,Recovery\nDr. Dre\n",
+			expected:           []*Record{{Line: 3, Fields: map[string]interface{}{"name": "Dr. Dre"}}},
+			expectedLineErrors: []int{2},
+		},
+		"CSV escaped formula": {
+			format:   FormatCSV,
+			content:  "name,albums\n'=1+1,'-\n",
+			expected: []*Record{{Line: 2, Fields: map[string]interface{}{"name": "=1+1", "albums": "-"}}},
+		},
+		"CSV without header": {
+			format:      FormatCSV,
+			content:     "",
+			expectedErr: true,
+		},
+		"JSON Lines": {
+			format:  FormatJSONL,
+			content: "{\"name\":\"Eminem\",\"albums\":[\"Recovery\",\"Kamikaze\"],\"id\":1}\n\n{\"Name\":\"Dr. Dre\"}\n",
+			expected: []*Record{
+				{Line: 1, Fields: map[string]interface{}{"name": "Eminem", "albums": []string{"Recovery", "Kamikaze"}, "id": "1"}},
+				{Line: 3, Fields: map[string]interface{}{"name": "Dr. Dre"}},
+			},
+		},
+		"JSON Lines invalid line": {
+			format:             FormatJSONL,
+			content:            "[1]\n{\"name\":\"Eminem\"}\n",
+			mapping:            map[string]string{"name": "artist"},
+			expected:           []*Record{{Line: 2, Fields: map[string]interface{}{"artist": "Eminem"}}},
+			expectedLineErrors: []int{1},
+		},
+		"Unknown format": {
+			format:      "xlsx",
+			expectedErr: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			reader, err := NewReader(test.format, strings.NewReader(test.content), test.mapping)
+			if test.expectedErr {
+				assert.Error(t, err, "An error should have occurred")
+				return
+			}
+			require.NoError(t, err, "No error should have occurred")
+
+			records, lineErrors, err := readAll(reader)
+
+			require.NoError(t, err, "No error should have occurred")
+			assert.Equal(t, test.expected, records)
+			assert.Equal(t, test.expectedLineErrors, lineErrors)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	assert.Equal(t, []string{"Recovery", "Kamikaze"}, List(" Recovery ;;Kamikaze"))
+	assert.Equal(t, []string{"Recovery"}, List([]string{"Recovery", " "}))
+	assert.Empty(t, List(""))
+}
+
+func TestWriter(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "name", "albums", "created_at"}
+
+	tests := map[string]struct {
+		format   string
+		records  [][]interface{}
+		expected string
+	}{
+		"CSV": {
+			format:   FormatCSV,
+			records:  [][]interface{}{{uint(1), "Eminem, Slim", []string{"Recovery", "Kamikaze"}, createdAt}},
+			expected: "id,name,albums,created_at\n1,\"Eminem, Slim\",Recovery;Kamikaze,2024-03-01T10:00:00Z\n",
+		},
+		"CSV formula": {
+			format:   FormatCSV,
+			records:  [][]interface{}{{uint(2), "=HYPERLINK(\"http://evil\")", []string{"@SUM(A1)", "+1"}, createdAt}, {uint(3), "-2", []string{"\tTab"}, createdAt}},
+			expected: "id,name,albums,created_at\n2,\"'=HYPERLINK(\"\"http://evil\"\")\",'@SUM(A1);+1,2024-03-01T10:00:00Z\n3,'-2,'\tTab,2024-03-01T10:00:00Z\n",
+		},
+		"CSV without record": {
+			format:   FormatCSV,
+			expected: "id,name,albums,created_at\n",
+		},
+		"JSON Lines": {
+			format:   FormatJSONL,
+			records:  [][]interface{}{{uint(1), "Eminem", []string{"Recovery"}, createdAt}},
+			expected: "{\"id\":1,\"name\":\"Eminem\",\"albums\":[\"Recovery\"],\"created_at\":\"2024-03-01T10:00:00Z\"}\n",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var output bytes.Buffer
+			writer, err := NewWriter(test.format, &output, columns)
+			require.NoError(t, err, "No error should have occurred")
+
+			for _, record := range test.records {
+				require.NoError(t, writer.Write(record...))
+			}
+			require.NoError(t, writer.Flush())
+
+			assert.Equal(t, test.expected, output.String())
+		})
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	assert.Equal(t, FormatCSV, FormatOf("artists.CSV"))
+	assert.Equal(t, FormatJSONL, FormatOf("artists.ndjson"))
+	assert.Equal(t, "", FormatOf("artists.xlsx"))
+}
@@ -1,11 +1,21 @@
 package viewmodel
 
+import (
+	"time"
+
+	"github.com/sarrooo/go-clean/internal/fieldset"
+	"github.com/sarrooo/go-clean/internal/filter"
+	"github.com/sarrooo/go-clean/internal/pagination"
+)
+
 // swagger:parameters createArtistController
 type CreateArtistRequest struct {
-	// The artist name.
-	// Required: true
 	// in:body
-	Name string `json:"name" binding:"required"`
+	Body struct {
+		// The artist name.
+		// Required: true
+		Name string `json:"name" binding:"required"`
+	} `json:"body" binding:"required"`
 }
 
 // swagger:response createArtistController
@@ -20,6 +30,8 @@ type CreateArtistResponse struct {
 
 // swagger:parameters getArtistController
 type GetArtistRequest struct {
+	fieldset.Request
+
 	// The artist id.
 	// Required: true
 	// in:path
@@ -37,6 +49,105 @@ type GetArtistResponse struct {
 		// The artist name.
 		// Required: true
 		Name string `json:"name"`
+
+		// The artist version, also sent as ETag.
+		// Required: true
+		Version uint `json:"version"`
+
+		// The artist albums, with expand=albums.
+		Albums []*Album `json:"albums,omitempty"`
+	} `json:"body"`
+}
+
+// swagger:parameters listArtistsController
+type ListArtistsRequest struct {
+	pagination.Request
+	fieldset.Fieldset
+
+	// The artist ids: id=1 or id[in]=1,2.
+	// in:query
+	ID filter.Field[uint] `json:"id" form:"-" filter:"id" operators:"eq,in"`
+
+	// The artist name: name=, name[ne]=, name[contains]=, name[starts_with]= or name[in]=.
+	// in:query
+	Name filter.Field[string] `json:"name" form:"-" filter:"name" operators:"eq,ne,contains,starts_with,in"`
+
+	// The creation date (RFC 3339 or 2006-01-02): created_at[gte]=, created_at[lt]=, ...
+	// in:query
+	CreatedAt filter.Field[time.Time] `json:"created_at" form:"-" filter:"created_at" operators:"gt,gte,lt,lte"`
+}
+
+type Artist struct {
+	// The artist id.
+	// Required: true
+	ID uint `json:"id"`
+
+	// The artist name.
+	// Required: true
+	Name string `json:"name"`
+
+	// The artist version.
+	// Required: true
+	Version uint `json:"version"`
+
+	// The artist albums, with expand=albums.
+	Albums []*Album `json:"albums,omitempty"`
+}
+
+type Album struct {
+	// The album id.
+	// Required: true
+	ID uint `json:"id"`
+
+	// The album name.
+	// Required: true
+	Name string `json:"name"`
+}
+
+// swagger:response listArtistsController
+type ListArtistsResponse struct {
+	// in:body
+	Body struct {
+		// The artists of the page.
+		// Required: true
+		Artists []*Artist `json:"artists"`
+
+		// The pagination of the artists.
+		// Required: true
+		Pagination pagination.Envelope `json:"pagination"`
+	} `json:"body"`
+}
+
+// swagger:parameters updateArtistController
+type UpdateArtistRequest struct {
+	// The artist id.
+	// Required: true
+	// in:path
+	ID uint `json:"id" uri:"id" binding:"required"`
+
+	// in:body
+	Body struct {
+		// The artist name.
+		// Required: true
+		Name string `json:"name" binding:"required"`
+	} `json:"body" binding:"required"`
+}
+
+// swagger:response updateArtistController
+type UpdateArtistResponse struct {
+	// in:body
+	Body struct {
+		// The artist id.
+		// Required: true
+		ID uint `json:"id"`
+
+		// The artist name.
+		// Required: true
+		Name string `json:"name"`
+
+		// The new artist version, also sent as ETag.
+		// Required: true
+		Version uint `json:"version"`
 	} `json:"body"`
 }
 
@@ -46,6 +157,14 @@ type DeleteArtistRequest struct {
 	// Required: true
 	// in:path
 	ID uint `json:"id" uri:"id" binding:"required"`
+
+	// What happens to the albums of the artist: restrict (default), cascade or reassign.
+	// in:query
+	Strategy string `json:"strategy" form:"strategy" binding:"omitempty,oneof=restrict cascade reassign"`
+
+	// The artist receiving the albums, required with the reassign strategy.
+	// in:query
+	ReassignTo uint `json:"reassign_to" form:"reassign_to" binding:"required_if=Strategy reassign"`
 }
 
 // swagger:response deleteArtistController
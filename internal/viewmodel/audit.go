@@ -0,0 +1,94 @@
+package viewmodel
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// swagger:parameters listAuditController
+type ListAuditRequest struct {
+	// The actor type: user, api_key, anonymous or system.
+	// in:query
+	ActorType string `json:"actor_type" form:"actor_type" binding:"omitempty,oneof=user api_key anonymous system"`
+
+	// The actor id.
+	// in:query
+	ActorID string `json:"actor_id" form:"actor_id"`
+
+	// The action: create, update, delete, restore or purge.
+	// in:query
+	Action string `json:"action" form:"action" binding:"omitempty,oneof=create update delete restore purge"`
+
+	// The entity type, e.g. artists.
+	// in:query
+	EntityType string `json:"entity_type" form:"entity_type"`
+
+	// The entity id.
+	// in:query
+	EntityID uint `json:"entity_id" form:"entity_id"`
+
+	// The logs created at or after this date (RFC 3339).
+	// in:query
+	From time.Time `json:"from" form:"from"`
+
+	// The logs created before this date (RFC 3339).
+	// in:query
+	To time.Time `json:"to" form:"to"`
+
+	// The maximum number of logs, 50 by default.
+	// in:query
+	Limit int `json:"limit" form:"limit" binding:"omitempty,min=1,max=500"`
+
+	// The number of logs to skip.
+	// in:query
+	Offset int `json:"offset" form:"offset" binding:"omitempty,min=0"`
+}
+
+type AuditLog struct {
+	// The log id.
+	// Required: true
+	ID uint `json:"id"`
+
+	// The date of the operation.
+	// Required: true
+	CreatedAt time.Time `json:"created_at"`
+
+	// The actor type: user, api_key, anonymous or system.
+	// Required: true
+	ActorType string `json:"actor_type"`
+
+	// The actor id, empty for anonymous and system actors.
+	ActorID string `json:"actor_id,omitempty"`
+
+	// The action: create, update, delete, restore or purge.
+	// Required: true
+	Action string `json:"action"`
+
+	// The entity type.
+	// Required: true
+	EntityType string `json:"entity_type"`
+
+	// The entity id.
+	// Required: true
+	EntityID uint `json:"entity_id"`
+
+	// The changed fields, by column, with their value before and after the operation.
+	// Required: true
+	Changes json.RawMessage `json:"changes"`
+
+	// The id of the HTTP request, empty for system operations.
+	RequestID string `json:"request_id,omitempty"`
+
+	// The IP of the client, empty for system operations.
+	IP string `json:"ip,omitempty"`
+}
+
+// swagger:response listAuditController
+type ListAuditResponse struct {
+	// in:body
+	Body struct {
+		// The audit logs, most recent first.
+		// Required: true
+		Logs []*AuditLog `json:"logs"`
+	} `json:"body"`
+}
@@ -0,0 +1,132 @@
+package viewmodel
+
+import "net/http"
+
+// BulkRequest is the mode of the bulk requests
+type BulkRequest struct {
+	// How the items are executed: atomic (default), all the items or none, or best_effort, each item alone.
+	Mode string `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+}
+
+// swagger:parameters bulkCreateArtistsController
+type BulkCreateArtistsRequest struct {
+	// in:body
+	Body struct {
+		BulkRequest
+
+		// The created artists, validated one by one.
+		// Required: true
+		Items []BulkCreateArtistItem `json:"items" binding:"required,min=1"`
+	} `json:"body" binding:"required"`
+}
+
+type BulkCreateArtistItem struct {
+	// The artist name.
+	// Required: true
+	Name string `json:"name" binding:"required"`
+}
+
+// swagger:parameters bulkUpdateArtistsController
+type BulkUpdateArtistsRequest struct {
+	// in:body
+	Body struct {
+		BulkRequest
+
+		// The updated artists, validated one by one.
+		// Required: true
+		Items []BulkUpdateArtistItem `json:"items" binding:"required,min=1"`
+	} `json:"body" binding:"required"`
+}
+
+type BulkUpdateArtistItem struct {
+	// The artist id.
+	// Required: true
+	ID uint `json:"id" binding:"required"`
+
+	// The artist name.
+	// Required: true
+	Name string `json:"name" binding:"required"`
+
+	// The updated version of the artist.
+	// Required: true
+	Version uint `json:"version" binding:"required"`
+}
+
+// swagger:parameters bulkDeleteArtistsController
+type BulkDeleteArtistsRequest struct {
+	// in:body
+	Body struct {
+		BulkRequest
+
+		// The deleted artists, validated one by one.
+		// Required: true
+		Items []BulkDeleteArtistItem `json:"items" binding:"required,min=1"`
+	} `json:"body" binding:"required"`
+}
+
+type BulkDeleteArtistItem struct {
+	// The artist id.
+	// Required: true
+	ID uint `json:"id" binding:"required"`
+
+	// The deleted version of the artist.
+	// Required: true
+	Version uint `json:"version" binding:"required"`
+
+	// What happens to the albums of the artist: restrict (default), cascade or reassign.
+	Strategy string `json:"strategy" binding:"omitempty,oneof=restrict cascade reassign"`
+
+	// The artist receiving the albums, required with the reassign strategy.
+	ReassignTo uint `json:"reassign_to" binding:"required_if=Strategy reassign"`
+}
+
+type BulkError struct {
+	// The error message, like the error responses.
+	// Required: true
+	Message string `json:"message"`
+
+	// The error context, like the error responses.
+	Context map[string]string `json:"context,omitempty"`
+}
+
+type BulkResult struct {
+	// The index of the item in the request.
+	// Required: true
+	Index int `json:"index"`
+
+	// The HTTP status code of the item.
+	// Required: true
+	Status int `json:"status"`
+
+	// The artist id, if the item succeeded.
+	ID uint `json:"id,omitempty"`
+
+	// The artist version, if the item succeeded.
+	Version uint `json:"version,omitempty"`
+
+	// The error of the item, if it failed.
+	Error *BulkError `json:"error,omitempty"`
+}
+
+// swagger:response bulkResponse
+type BulkResponse struct {
+	// in:body
+	Body struct {
+		// The result of each item, in the order of the request.
+		// Required: true
+		Results []*BulkResult `json:"results"`
+
+		// The number of items which succeeded.
+		// Required: true
+		Succeeded int `json:"succeeded"`
+
+		// The number of items which failed.
+		// Required: true
+		Failed int `json:"failed"`
+	} `json:"body"`
+}
+
+// StatusCodes are 200 if every item succeeded, 207 otherwise
+func (BulkResponse) StatusCodes() []int {
+	return []int{http.StatusOK, http.StatusMultiStatus}
+}
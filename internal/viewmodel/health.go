@@ -0,0 +1,32 @@
+package viewmodel
+
+import "net/http"
+
+// swagger:response livenessController
+type LivenessResponse struct {
+	// in:body
+	Body struct {
+		// The server status.
+		// Required: true
+		Status string `json:"status"`
+	} `json:"body"`
+}
+
+// swagger:response readinessController
+type ReadinessResponse struct {
+	// in:body
+	Body struct {
+		// The server status, ok or unavailable.
+		// Required: true
+		Status string `json:"status"`
+
+		// The status of each dependency, ok or the error.
+		// Required: true
+		Checks map[string]string `json:"checks"`
+	} `json:"body"`
+}
+
+// StatusCodes are 200 if every dependency is ready, 503 otherwise
+func (ReadinessResponse) StatusCodes() []int {
+	return []int{http.StatusOK, http.StatusServiceUnavailable}
+}
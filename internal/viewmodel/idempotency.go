@@ -0,0 +1,8 @@
+package viewmodel
+
+import "encoding/json"
+
+// ReplayedResponse is the response stored for an idempotency key, sent again to the retries of the request
+type ReplayedResponse struct {
+	Body json.RawMessage `json:"body"`
+}
@@ -0,0 +1,70 @@
+package viewmodel
+
+import (
+	"github.com/sarrooo/go-clean/internal/fieldset"
+	"github.com/sarrooo/go-clean/internal/pagination"
+)
+
+// swagger:parameters searchController
+type SearchRequest struct {
+	fieldset.Request
+
+	// The searched words, accents and case are ignored.
+	// Required: true
+	// in:query
+	Q string `json:"q" form:"q" binding:"required,max=100"`
+
+	// The searched entity, artists or albums. Both by default.
+	// in:query
+	Type string `json:"type" form:"type" binding:"omitempty,oneof=artists albums"`
+
+	// The maximum number of results, 20 by default.
+	// in:query
+	Limit int `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
+
+	// The page number, starting at 1.
+	// in:query
+	Page int `json:"page" form:"page" binding:"omitempty,min=1"`
+}
+
+type SearchResult struct {
+	// The entity, artists or albums.
+	// Required: true
+	Type string `json:"type"`
+
+	// The entity id.
+	// Required: true
+	ID uint `json:"id"`
+
+	// The entity name.
+	// Required: true
+	Name string `json:"name"`
+
+	// The HTML escaped name, with the matched words in <mark> elements.
+	// Required: true
+	Highlight string `json:"highlight"`
+
+	// The relevance of the result, only comparable within a search.
+	// Required: true
+	Score float64 `json:"score"`
+
+	// The artist id of an album.
+	ArtistID *uint `json:"artist_id,omitempty"`
+
+	// The artist name of an album.
+	ArtistName *string `json:"artist_name,omitempty"`
+}
+
+// swagger:response searchController
+type SearchResponse struct {
+	// in:body
+	Body struct {
+		// The results of the page, the most relevant first.
+		// Required: true
+		Results []*SearchResult `json:"results"`
+
+		// The pagination of the results.
+		// Required: true
+		Pagination pagination.Envelope `json:"pagination"`
+	} `json:"body"`
+}
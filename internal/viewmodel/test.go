@@ -1,5 +1,10 @@
 package viewmodel
 
+import (
+	"github.com/sarrooo/go-clean/internal/filter"
+	"github.com/sarrooo/go-clean/internal/params"
+)
+
 type TestBodyViewModelRequest struct {
 	// in: body
 	Body struct {
@@ -11,6 +16,17 @@ type TestUrlParamsViewModelRequest struct {
 	Field string `form:"field" binding:"required,min=1"`
 }
 
+type TestFilterViewModelRequest struct {
+	Name filter.Field[string] `json:"name" form:"-" filter:"name" operators:"eq,contains"`
+}
+
+type TestParamsViewModelRequest struct {
+	ID      uint        `uri:"id" binding:"required"`
+	Limit   *int        `query:"limit" binding:"omitempty,max=100"`
+	IDs     []uint      `query:"ids"`
+	Version params.UUID `header:"X-Version"`
+}
+
 type TestViewModelResponse struct {
 	// in: body
 	Body struct {
@@ -19,3 +35,10 @@ type TestViewModelResponse struct {
 }
 
 type TestNoBodyViewModelResponse struct{}
+
+type TestContractViewModelResponse struct {
+	// in: body
+	Body struct {
+		Status string `json:"status" binding:"oneof=active archived"`
+	} `json:"body"`
+}
@@ -0,0 +1,126 @@
+package viewmodel
+
+import (
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+// swagger:response exportArtistsResponse
+type ExportArtistsResponse struct {
+	// The exported file, with the columns id, name, albums, version, created_at and updated_at.
+	// in:body
+	Body []byte
+}
+
+// swagger:parameters importArtistsController
+type ImportArtistsRequest struct {
+	// The imported file, csv or jsonl.
+	// Required: true
+	// in:formData
+	// swagger:file
+	File *multipart.FileHeader `json:"file" form:"file" binding:"required"`
+
+	// The file format, csv or jsonl, by default the format of the file extension.
+	// in:formData
+	Format string `json:"format" form:"format" binding:"omitempty,oneof=csv jsonl"`
+
+	// A JSON object mapping the columns of the file to the fields, "-" ignores a column,
+	// e.g. {"Artist Name":"name","Genre":"-"}.
+	// in:formData
+	Mapping map[string]string `json:"mapping" form:"mapping"`
+
+	// Validate and execute the lines without saving them.
+	// in:formData
+	DryRun bool `json:"dry_run" form:"dry_run"`
+
+	// Import the file in background, the large files are always imported in background.
+	// in:formData
+	Async bool `json:"async" form:"async"`
+}
+
+type ImportLineError struct {
+	// The line of the file, starting at 1 with the CSV header.
+	// Required: true
+	Line int `json:"line"`
+
+	// The invalid field, if the error is a validation error.
+	Field string `json:"field,omitempty"`
+
+	// The error message.
+	// Required: true
+	Message string `json:"message"`
+}
+
+type ImportReport struct {
+	// The number of imported lines.
+	// Required: true
+	Total int `json:"total"`
+
+	// The number of created artists.
+	// Required: true
+	Created int `json:"created"`
+
+	// The number of existing artists with added albums.
+	// Required: true
+	Updated int `json:"updated"`
+
+	// The number of existing artists left unchanged.
+	// Required: true
+	Unchanged int `json:"unchanged"`
+
+	// The number of failed lines.
+	// Required: true
+	Failed int `json:"failed"`
+
+	// The errors of the failed lines, by line, limited to the first 1000.
+	// Required: true
+	Errors []*ImportLineError `json:"errors"`
+}
+
+type ImportJob struct {
+	// The job id, absent for an import which is not run in background.
+	ID uint `json:"id,omitempty"`
+
+	// The job status: pending, running, succeeded or failed.
+	// Required: true
+	Status string `json:"status"`
+
+	// Whether the import is a dry run.
+	// Required: true
+	DryRun bool `json:"dry_run"`
+
+	// The report of the import, once it succeeded.
+	Report *ImportReport `json:"report,omitempty"`
+
+	// The error which stopped the import, if it failed.
+	Error string `json:"error,omitempty"`
+
+	// The end time of the import, once it is finished.
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// swagger:response importArtistsController
+type ImportArtistsResponse struct {
+	// in:body
+	Body ImportJob `json:"body"`
+}
+
+// StatusCodes are 200 for the report of an import, 202 for the job of a background import
+func (ImportArtistsResponse) StatusCodes() []int {
+	return []int{http.StatusOK, http.StatusAccepted}
+}
+
+// swagger:parameters getImportJobController
+type GetImportJobRequest struct {
+	// The job id.
+	// Required: true
+	// in:path
+	ID uint `json:"id" uri:"id" binding:"required"`
+}
+
+// swagger:response getImportJobController
+type GetImportJobResponse struct {
+	// in:body
+	Body ImportJob `json:"body"`
+}
@@ -0,0 +1,67 @@
+package viewmodel
+
+import "github.com/sarrooo/go-clean/internal/dto"
+
+// swagger:parameters listTrashController
+type ListTrashRequest struct {
+	// The entity type, artists or albums.
+	// Required: true
+	// in:path
+	Entity string `json:"entity" uri:"entity" binding:"required,oneof=artists albums"`
+}
+
+// swagger:response listTrashController
+type ListTrashResponse struct {
+	// in:body
+	Body struct {
+		// The soft deleted entities, most recently deleted first.
+		// Required: true
+		Items []*dto.TrashItem `json:"items"`
+	} `json:"body"`
+}
+
+// swagger:parameters restoreTrashController
+type RestoreTrashRequest struct {
+	// The entity type, artists or albums.
+	// Required: true
+	// in:path
+	Entity string `json:"entity" uri:"entity" binding:"required,oneof=artists albums"`
+
+	// The entity id.
+	// Required: true
+	// in:path
+	ID uint `json:"id" uri:"id" binding:"required"`
+}
+
+// swagger:response restoreTrashController
+type RestoreTrashResponse struct {
+	// in:body
+	Body struct {
+		// The restored entity id.
+		// Required: true
+		ID uint `json:"id"`
+	} `json:"body"`
+}
+
+// swagger:parameters purgeTrashController
+type PurgeTrashRequest struct {
+	// The entity type, artists or albums.
+	// Required: true
+	// in:path
+	Entity string `json:"entity" uri:"entity" binding:"required,oneof=artists albums"`
+
+	// The entity id.
+	// Required: true
+	// in:path
+	ID uint `json:"id" uri:"id" binding:"required"`
+}
+
+// swagger:response purgeTrashController
+type PurgeTrashResponse struct {
+	// in:body
+	Body struct {
+		// The purged entity id.
+		// Required: true
+		ID uint `json:"id"`
+	} `json:"body"`
+}
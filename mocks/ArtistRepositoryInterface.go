@@ -0,0 +1,728 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/sarrooo/go-clean/internal/dto"
+	filter "github.com/sarrooo/go-clean/internal/filter"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sarrooo/go-clean/internal/models"
+
+	pagination "github.com/sarrooo/go-clean/internal/pagination"
+)
+
+// ArtistRepositoryInterface is an autogenerated mock type for the ArtistRepositoryInterface type
+type ArtistRepositoryInterface struct {
+	mock.Mock
+}
+
+type ArtistRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ArtistRepositoryInterface) EXPECT() *ArtistRepositoryInterface_Expecter {
+	return &ArtistRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// AddAlbums provides a mock function with given fields: ctx, id, names
+func (_m *ArtistRepositoryInterface) AddAlbums(ctx context.Context, id uint, names []string) ([]*models.Album, error) {
+	ret := _m.Called(ctx, id, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAlbums")
+	}
+
+	var r0 []*models.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) ([]*models.Album, error)); ok {
+		return rf(ctx, id, names)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) []*models.Album); ok {
+		r0 = rf(ctx, id, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string) error); ok {
+		r1 = rf(ctx, id, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArtistRepositoryInterface_AddAlbums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAlbums'
+type ArtistRepositoryInterface_AddAlbums_Call struct {
+	*mock.Call
+}
+
+// AddAlbums is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+//   - names []string
+func (_e *ArtistRepositoryInterface_Expecter) AddAlbums(ctx interface{}, id interface{}, names interface{}) *ArtistRepositoryInterface_AddAlbums_Call {
+	return &ArtistRepositoryInterface_AddAlbums_Call{Call: _e.mock.On("AddAlbums", ctx, id, names)}
+}
+
+func (_c *ArtistRepositoryInterface_AddAlbums_Call) Run(run func(ctx context.Context, id uint, names []string)) *ArtistRepositoryInterface_AddAlbums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_AddAlbums_Call) Return(_a0 []*models.Album, _a1 error) *ArtistRepositoryInterface_AddAlbums_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_AddAlbums_Call) RunAndReturn(run func(context.Context, uint, []string) ([]*models.Album, error)) *ArtistRepositoryInterface_AddAlbums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountDependents provides a mock function with given fields: ctx, id
+func (_m *ArtistRepositoryInterface) CountDependents(ctx context.Context, id uint) (*dto.ArtistDependents, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDependents")
+	}
+
+	var r0 *dto.ArtistDependents
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*dto.ArtistDependents, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *dto.ArtistDependents); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.ArtistDependents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArtistRepositoryInterface_CountDependents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDependents'
+type ArtistRepositoryInterface_CountDependents_Call struct {
+	*mock.Call
+}
+
+// CountDependents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *ArtistRepositoryInterface_Expecter) CountDependents(ctx interface{}, id interface{}) *ArtistRepositoryInterface_CountDependents_Call {
+	return &ArtistRepositoryInterface_CountDependents_Call{Call: _e.mock.On("CountDependents", ctx, id)}
+}
+
+func (_c *ArtistRepositoryInterface_CountDependents_Call) Run(run func(ctx context.Context, id uint)) *ArtistRepositoryInterface_CountDependents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_CountDependents_Call) Return(_a0 *dto.ArtistDependents, _a1 error) *ArtistRepositoryInterface_CountDependents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_CountDependents_Call) RunAndReturn(run func(context.Context, uint) (*dto.ArtistDependents, error)) *ArtistRepositoryInterface_CountDependents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, artist
+func (_m *ArtistRepositoryInterface) Create(ctx context.Context, artist *models.Artist) error {
+	ret := _m.Called(ctx, artist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Artist) error); ok {
+		r0 = rf(ctx, artist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArtistRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ArtistRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - artist *models.Artist
+func (_e *ArtistRepositoryInterface_Expecter) Create(ctx interface{}, artist interface{}) *ArtistRepositoryInterface_Create_Call {
+	return &ArtistRepositoryInterface_Create_Call{Call: _e.mock.On("Create", ctx, artist)}
+}
+
+func (_c *ArtistRepositoryInterface_Create_Call) Run(run func(ctx context.Context, artist *models.Artist)) *ArtistRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Artist))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_Create_Call) Return(_a0 error) *ArtistRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_Create_Call) RunAndReturn(run func(context.Context, *models.Artist) error) *ArtistRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id, version
+func (_m *ArtistRepositoryInterface) Delete(ctx context.Context, id uint, version uint) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArtistRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ArtistRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+//   - version uint
+func (_e *ArtistRepositoryInterface_Expecter) Delete(ctx interface{}, id interface{}, version interface{}) *ArtistRepositoryInterface_Delete_Call {
+	return &ArtistRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", ctx, id, version)}
+}
+
+func (_c *ArtistRepositoryInterface_Delete_Call) Run(run func(ctx context.Context, id uint, version uint)) *ArtistRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_Delete_Call) Return(_a0 error) *ArtistRepositoryInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_Delete_Call) RunAndReturn(run func(context.Context, uint, uint) error) *ArtistRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAlbums provides a mock function with given fields: ctx, id
+func (_m *ArtistRepositoryInterface) DeleteAlbums(ctx context.Context, id uint) ([]*models.Album, []*models.UserAlbum, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlbums")
+	}
+
+	var r0 []*models.Album
+	var r1 []*models.UserAlbum
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*models.Album, []*models.UserAlbum, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*models.Album); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) []*models.UserAlbum); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*models.UserAlbum)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ArtistRepositoryInterface_DeleteAlbums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlbums'
+type ArtistRepositoryInterface_DeleteAlbums_Call struct {
+	*mock.Call
+}
+
+// DeleteAlbums is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *ArtistRepositoryInterface_Expecter) DeleteAlbums(ctx interface{}, id interface{}) *ArtistRepositoryInterface_DeleteAlbums_Call {
+	return &ArtistRepositoryInterface_DeleteAlbums_Call{Call: _e.mock.On("DeleteAlbums", ctx, id)}
+}
+
+func (_c *ArtistRepositoryInterface_DeleteAlbums_Call) Run(run func(ctx context.Context, id uint)) *ArtistRepositoryInterface_DeleteAlbums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_DeleteAlbums_Call) Return(_a0 []*models.Album, _a1 []*models.UserAlbum, _a2 error) *ArtistRepositoryInterface_DeleteAlbums_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_DeleteAlbums_Call) RunAndReturn(run func(context.Context, uint) ([]*models.Album, []*models.UserAlbum, error)) *ArtistRepositoryInterface_DeleteAlbums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindInBatches provides a mock function with given fields: ctx, batchSize, fn
+func (_m *ArtistRepositoryInterface) FindInBatches(ctx context.Context, batchSize int, fn func([]*models.Artist) error) error {
+	ret := _m.Called(ctx, batchSize, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindInBatches")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func([]*models.Artist) error) error); ok {
+		r0 = rf(ctx, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArtistRepositoryInterface_FindInBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindInBatches'
+type ArtistRepositoryInterface_FindInBatches_Call struct {
+	*mock.Call
+}
+
+// FindInBatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchSize int
+//   - fn func([]*models.Artist) error
+func (_e *ArtistRepositoryInterface_Expecter) FindInBatches(ctx interface{}, batchSize interface{}, fn interface{}) *ArtistRepositoryInterface_FindInBatches_Call {
+	return &ArtistRepositoryInterface_FindInBatches_Call{Call: _e.mock.On("FindInBatches", ctx, batchSize, fn)}
+}
+
+func (_c *ArtistRepositoryInterface_FindInBatches_Call) Run(run func(ctx context.Context, batchSize int, fn func([]*models.Artist) error)) *ArtistRepositoryInterface_FindInBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(func([]*models.Artist) error))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_FindInBatches_Call) Return(_a0 error) *ArtistRepositoryInterface_FindInBatches_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_FindInBatches_Call) RunAndReturn(run func(context.Context, int, func([]*models.Artist) error) error) *ArtistRepositoryInterface_FindInBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *ArtistRepositoryInterface) GetByID(ctx context.Context, id uint) (*models.Artist, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.Artist, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.Artist); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArtistRepositoryInterface_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type ArtistRepositoryInterface_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *ArtistRepositoryInterface_Expecter) GetByID(ctx interface{}, id interface{}) *ArtistRepositoryInterface_GetByID_Call {
+	return &ArtistRepositoryInterface_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *ArtistRepositoryInterface_GetByID_Call) Run(run func(ctx context.Context, id uint)) *ArtistRepositoryInterface_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_GetByID_Call) Return(_a0 *models.Artist, _a1 error) *ArtistRepositoryInterface_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_GetByID_Call) RunAndReturn(run func(context.Context, uint) (*models.Artist, error)) *ArtistRepositoryInterface_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByName provides a mock function with given fields: ctx, name
+func (_m *ArtistRepositoryInterface) GetByName(ctx context.Context, name string) (*models.Artist, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByName")
+	}
+
+	var r0 *models.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Artist, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Artist); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArtistRepositoryInterface_GetByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByName'
+type ArtistRepositoryInterface_GetByName_Call struct {
+	*mock.Call
+}
+
+// GetByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *ArtistRepositoryInterface_Expecter) GetByName(ctx interface{}, name interface{}) *ArtistRepositoryInterface_GetByName_Call {
+	return &ArtistRepositoryInterface_GetByName_Call{Call: _e.mock.On("GetByName", ctx, name)}
+}
+
+func (_c *ArtistRepositoryInterface_GetByName_Call) Run(run func(ctx context.Context, name string)) *ArtistRepositoryInterface_GetByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_GetByName_Call) Return(_a0 *models.Artist, _a1 error) *ArtistRepositoryInterface_GetByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_GetByName_Call) RunAndReturn(run func(context.Context, string) (*models.Artist, error)) *ArtistRepositoryInterface_GetByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithRelations provides a mock function with given fields: ctx, id, preloads
+func (_m *ArtistRepositoryInterface) GetWithRelations(ctx context.Context, id uint, preloads []string) (*models.Artist, error) {
+	ret := _m.Called(ctx, id, preloads)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithRelations")
+	}
+
+	var r0 *models.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) (*models.Artist, error)); ok {
+		return rf(ctx, id, preloads)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) *models.Artist); ok {
+		r0 = rf(ctx, id, preloads)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string) error); ok {
+		r1 = rf(ctx, id, preloads)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArtistRepositoryInterface_GetWithRelations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithRelations'
+type ArtistRepositoryInterface_GetWithRelations_Call struct {
+	*mock.Call
+}
+
+// GetWithRelations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+//   - preloads []string
+func (_e *ArtistRepositoryInterface_Expecter) GetWithRelations(ctx interface{}, id interface{}, preloads interface{}) *ArtistRepositoryInterface_GetWithRelations_Call {
+	return &ArtistRepositoryInterface_GetWithRelations_Call{Call: _e.mock.On("GetWithRelations", ctx, id, preloads)}
+}
+
+func (_c *ArtistRepositoryInterface_GetWithRelations_Call) Run(run func(ctx context.Context, id uint, preloads []string)) *ArtistRepositoryInterface_GetWithRelations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_GetWithRelations_Call) Return(_a0 *models.Artist, _a1 error) *ArtistRepositoryInterface_GetWithRelations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_GetWithRelations_Call) RunAndReturn(run func(context.Context, uint, []string) (*models.Artist, error)) *ArtistRepositoryInterface_GetWithRelations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filters, params, preloads
+func (_m *ArtistRepositoryInterface) List(ctx context.Context, filters []filter.Condition, params *pagination.Params, preloads []string) (*pagination.Page[*models.Artist], error) {
+	ret := _m.Called(ctx, filters, params, preloads)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *pagination.Page[*models.Artist]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []filter.Condition, *pagination.Params, []string) (*pagination.Page[*models.Artist], error)); ok {
+		return rf(ctx, filters, params, preloads)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []filter.Condition, *pagination.Params, []string) *pagination.Page[*models.Artist]); ok {
+		r0 = rf(ctx, filters, params, preloads)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Page[*models.Artist])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []filter.Condition, *pagination.Params, []string) error); ok {
+		r1 = rf(ctx, filters, params, preloads)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArtistRepositoryInterface_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ArtistRepositoryInterface_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters []filter.Condition
+//   - params *pagination.Params
+//   - preloads []string
+func (_e *ArtistRepositoryInterface_Expecter) List(ctx interface{}, filters interface{}, params interface{}, preloads interface{}) *ArtistRepositoryInterface_List_Call {
+	return &ArtistRepositoryInterface_List_Call{Call: _e.mock.On("List", ctx, filters, params, preloads)}
+}
+
+func (_c *ArtistRepositoryInterface_List_Call) Run(run func(ctx context.Context, filters []filter.Condition, params *pagination.Params, preloads []string)) *ArtistRepositoryInterface_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]filter.Condition), args[2].(*pagination.Params), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_List_Call) Return(_a0 *pagination.Page[*models.Artist], _a1 error) *ArtistRepositoryInterface_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_List_Call) RunAndReturn(run func(context.Context, []filter.Condition, *pagination.Params, []string) (*pagination.Page[*models.Artist], error)) *ArtistRepositoryInterface_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReassignAlbums provides a mock function with given fields: ctx, id, toID
+func (_m *ArtistRepositoryInterface) ReassignAlbums(ctx context.Context, id uint, toID uint) ([]*models.Album, []*models.Album, error) {
+	ret := _m.Called(ctx, id, toID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignAlbums")
+	}
+
+	var r0 []*models.Album
+	var r1 []*models.Album
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) ([]*models.Album, []*models.Album, error)); ok {
+		return rf(ctx, id, toID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []*models.Album); ok {
+		r0 = rf(ctx, id, toID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) []*models.Album); ok {
+		r1 = rf(ctx, id, toID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*models.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint, uint) error); ok {
+		r2 = rf(ctx, id, toID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ArtistRepositoryInterface_ReassignAlbums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReassignAlbums'
+type ArtistRepositoryInterface_ReassignAlbums_Call struct {
+	*mock.Call
+}
+
+// ReassignAlbums is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+//   - toID uint
+func (_e *ArtistRepositoryInterface_Expecter) ReassignAlbums(ctx interface{}, id interface{}, toID interface{}) *ArtistRepositoryInterface_ReassignAlbums_Call {
+	return &ArtistRepositoryInterface_ReassignAlbums_Call{Call: _e.mock.On("ReassignAlbums", ctx, id, toID)}
+}
+
+func (_c *ArtistRepositoryInterface_ReassignAlbums_Call) Run(run func(ctx context.Context, id uint, toID uint)) *ArtistRepositoryInterface_ReassignAlbums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_ReassignAlbums_Call) Return(before []*models.Album, after []*models.Album, err error) *ArtistRepositoryInterface_ReassignAlbums_Call {
+	_c.Call.Return(before, after, err)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_ReassignAlbums_Call) RunAndReturn(run func(context.Context, uint, uint) ([]*models.Album, []*models.Album, error)) *ArtistRepositoryInterface_ReassignAlbums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, artist
+func (_m *ArtistRepositoryInterface) Update(ctx context.Context, artist *models.Artist) error {
+	ret := _m.Called(ctx, artist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Artist) error); ok {
+		r0 = rf(ctx, artist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArtistRepositoryInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ArtistRepositoryInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - artist *models.Artist
+func (_e *ArtistRepositoryInterface_Expecter) Update(ctx interface{}, artist interface{}) *ArtistRepositoryInterface_Update_Call {
+	return &ArtistRepositoryInterface_Update_Call{Call: _e.mock.On("Update", ctx, artist)}
+}
+
+func (_c *ArtistRepositoryInterface_Update_Call) Run(run func(ctx context.Context, artist *models.Artist)) *ArtistRepositoryInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Artist))
+	})
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_Update_Call) Return(_a0 error) *ArtistRepositoryInterface_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ArtistRepositoryInterface_Update_Call) RunAndReturn(run func(context.Context, *models.Artist) error) *ArtistRepositoryInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewArtistRepositoryInterface creates a new instance of ArtistRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewArtistRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ArtistRepositoryInterface {
+	mock := &ArtistRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
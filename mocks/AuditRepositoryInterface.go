@@ -0,0 +1,204 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/sarrooo/go-clean/internal/dto"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sarrooo/go-clean/internal/models"
+
+	time "time"
+)
+
+// AuditRepositoryInterface is an autogenerated mock type for the AuditRepositoryInterface type
+type AuditRepositoryInterface struct {
+	mock.Mock
+}
+
+type AuditRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditRepositoryInterface) EXPECT() *AuditRepositoryInterface_Expecter {
+	return &AuditRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, log
+func (_m *AuditRepositoryInterface) Create(ctx context.Context, log *models.AuditLog) error {
+	ret := _m.Called(ctx, log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type AuditRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - log *models.AuditLog
+func (_e *AuditRepositoryInterface_Expecter) Create(ctx interface{}, log interface{}) *AuditRepositoryInterface_Create_Call {
+	return &AuditRepositoryInterface_Create_Call{Call: _e.mock.On("Create", ctx, log)}
+}
+
+func (_c *AuditRepositoryInterface_Create_Call) Run(run func(ctx context.Context, log *models.AuditLog)) *AuditRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.AuditLog))
+	})
+	return _c
+}
+
+func (_c *AuditRepositoryInterface_Create_Call) Return(_a0 error) *AuditRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditRepositoryInterface_Create_Call) RunAndReturn(run func(context.Context, *models.AuditLog) error) *AuditRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBefore provides a mock function with given fields: ctx, before
+func (_m *AuditRepositoryInterface) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRepositoryInterface_DeleteBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBefore'
+type AuditRepositoryInterface_DeleteBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *AuditRepositoryInterface_Expecter) DeleteBefore(ctx interface{}, before interface{}) *AuditRepositoryInterface_DeleteBefore_Call {
+	return &AuditRepositoryInterface_DeleteBefore_Call{Call: _e.mock.On("DeleteBefore", ctx, before)}
+}
+
+func (_c *AuditRepositoryInterface_DeleteBefore_Call) Run(run func(ctx context.Context, before time.Time)) *AuditRepositoryInterface_DeleteBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AuditRepositoryInterface_DeleteBefore_Call) Return(_a0 int64, _a1 error) *AuditRepositoryInterface_DeleteBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditRepositoryInterface_DeleteBefore_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *AuditRepositoryInterface_DeleteBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *AuditRepositoryInterface) List(ctx context.Context, filter *dto.AuditFilter) ([]*models.AuditLog, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuditFilter) ([]*models.AuditLog, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuditFilter) []*models.AuditLog); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRepositoryInterface_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AuditRepositoryInterface_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *dto.AuditFilter
+func (_e *AuditRepositoryInterface_Expecter) List(ctx interface{}, filter interface{}) *AuditRepositoryInterface_List_Call {
+	return &AuditRepositoryInterface_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *AuditRepositoryInterface_List_Call) Run(run func(ctx context.Context, filter *dto.AuditFilter)) *AuditRepositoryInterface_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *AuditRepositoryInterface_List_Call) Return(_a0 []*models.AuditLog, _a1 error) *AuditRepositoryInterface_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditRepositoryInterface_List_Call) RunAndReturn(run func(context.Context, *dto.AuditFilter) ([]*models.AuditLog, error)) *AuditRepositoryInterface_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditRepositoryInterface creates a new instance of AuditRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepositoryInterface {
+	mock := &AuditRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,180 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Cache is an autogenerated mock type for the Cache type
+type Cache struct {
+	mock.Mock
+}
+
+type Cache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Cache) EXPECT() *Cache_Expecter {
+	return &Cache_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, keys
+func (_m *Cache) Delete(ctx context.Context, keys ...string) {
+	_va := make([]interface{}, len(keys))
+	for _i := range keys {
+		_va[_i] = keys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// Cache_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type Cache_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys ...string
+func (_e *Cache_Expecter) Delete(ctx interface{}, keys ...interface{}) *Cache_Delete_Call {
+	return &Cache_Delete_Call{Call: _e.mock.On("Delete",
+		append([]interface{}{ctx}, keys...)...)}
+}
+
+func (_c *Cache_Delete_Call) Run(run func(ctx context.Context, keys ...string)) *Cache_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Cache_Delete_Call) Return() *Cache_Delete_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Cache_Delete_Call) RunAndReturn(run func(context.Context, ...string)) *Cache_Delete_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *Cache) Get(ctx context.Context, key string) (interface{}, bool) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 interface{}
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, bool)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// Cache_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type Cache_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *Cache_Expecter) Get(ctx interface{}, key interface{}) *Cache_Get_Call {
+	return &Cache_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *Cache_Get_Call) Run(run func(ctx context.Context, key string)) *Cache_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Cache_Get_Call) Return(value interface{}, ok bool) *Cache_Get_Call {
+	_c.Call.Return(value, ok)
+	return _c
+}
+
+func (_c *Cache_Get_Call) RunAndReturn(run func(context.Context, string) (interface{}, bool)) *Cache_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function with given fields: ctx, key, value, ttl
+func (_m *Cache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	_m.Called(ctx, key, value, ttl)
+}
+
+// Cache_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type Cache_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - ttl time.Duration
+func (_e *Cache_Expecter) Set(ctx interface{}, key interface{}, value interface{}, ttl interface{}) *Cache_Set_Call {
+	return &Cache_Set_Call{Call: _e.mock.On("Set", ctx, key, value, ttl)}
+}
+
+func (_c *Cache_Set_Call) Run(run func(ctx context.Context, key string, value interface{}, ttl time.Duration)) *Cache_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Cache_Set_Call) Return() *Cache_Set_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Cache_Set_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration)) *Cache_Set_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewCache creates a new instance of Cache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Cache {
+	mock := &Cache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
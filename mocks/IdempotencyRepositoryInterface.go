@@ -0,0 +1,299 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/sarrooo/go-clean/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IdempotencyRepositoryInterface is an autogenerated mock type for the IdempotencyRepositoryInterface type
+type IdempotencyRepositoryInterface struct {
+	mock.Mock
+}
+
+type IdempotencyRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IdempotencyRepositoryInterface) EXPECT() *IdempotencyRepositoryInterface_Expecter {
+	return &IdempotencyRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: ctx, id, statusCode, response
+func (_m *IdempotencyRepositoryInterface) Complete(ctx context.Context, id uint, statusCode int, response string) error {
+	ret := _m.Called(ctx, id, statusCode, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int, string) error); ok {
+		r0 = rf(ctx, id, statusCode, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepositoryInterface_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type IdempotencyRepositoryInterface_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+//   - statusCode int
+//   - response string
+func (_e *IdempotencyRepositoryInterface_Expecter) Complete(ctx interface{}, id interface{}, statusCode interface{}, response interface{}) *IdempotencyRepositoryInterface_Complete_Call {
+	return &IdempotencyRepositoryInterface_Complete_Call{Call: _e.mock.On("Complete", ctx, id, statusCode, response)}
+}
+
+func (_c *IdempotencyRepositoryInterface_Complete_Call) Run(run func(ctx context.Context, id uint, statusCode int, response string)) *IdempotencyRepositoryInterface_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(int), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_Complete_Call) Return(_a0 error) *IdempotencyRepositoryInterface_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_Complete_Call) RunAndReturn(run func(context.Context, uint, int, string) error) *IdempotencyRepositoryInterface_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, key
+func (_m *IdempotencyRepositoryInterface) Create(ctx context.Context, key *models.IdempotencyKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IdempotencyRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key *models.IdempotencyKey
+func (_e *IdempotencyRepositoryInterface_Expecter) Create(ctx interface{}, key interface{}) *IdempotencyRepositoryInterface_Create_Call {
+	return &IdempotencyRepositoryInterface_Create_Call{Call: _e.mock.On("Create", ctx, key)}
+}
+
+func (_c *IdempotencyRepositoryInterface_Create_Call) Run(run func(ctx context.Context, key *models.IdempotencyKey)) *IdempotencyRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.IdempotencyKey))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_Create_Call) Return(_a0 error) *IdempotencyRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_Create_Call) RunAndReturn(run func(context.Context, *models.IdempotencyKey) error) *IdempotencyRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *IdempotencyRepositoryInterface) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type IdempotencyRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *IdempotencyRepositoryInterface_Expecter) Delete(ctx interface{}, id interface{}) *IdempotencyRepositoryInterface_Delete_Call {
+	return &IdempotencyRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *IdempotencyRepositoryInterface_Delete_Call) Run(run func(ctx context.Context, id uint)) *IdempotencyRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_Delete_Call) Return(_a0 error) *IdempotencyRepositoryInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_Delete_Call) RunAndReturn(run func(context.Context, uint) error) *IdempotencyRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, before
+func (_m *IdempotencyRepositoryInterface) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyRepositoryInterface_DeleteExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpired'
+type IdempotencyRepositoryInterface_DeleteExpired_Call struct {
+	*mock.Call
+}
+
+// DeleteExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *IdempotencyRepositoryInterface_Expecter) DeleteExpired(ctx interface{}, before interface{}) *IdempotencyRepositoryInterface_DeleteExpired_Call {
+	return &IdempotencyRepositoryInterface_DeleteExpired_Call{Call: _e.mock.On("DeleteExpired", ctx, before)}
+}
+
+func (_c *IdempotencyRepositoryInterface_DeleteExpired_Call) Run(run func(ctx context.Context, before time.Time)) *IdempotencyRepositoryInterface_DeleteExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_DeleteExpired_Call) Return(_a0 int64, _a1 error) *IdempotencyRepositoryInterface_DeleteExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_DeleteExpired_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *IdempotencyRepositoryInterface_DeleteExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, scope, key
+func (_m *IdempotencyRepositoryInterface) Get(ctx context.Context, scope string, key string) (*models.IdempotencyKey, error) {
+	ret := _m.Called(ctx, scope, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.IdempotencyKey, error)); ok {
+		return rf(ctx, scope, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.IdempotencyKey); ok {
+		r0 = rf(ctx, scope, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, scope, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyRepositoryInterface_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type IdempotencyRepositoryInterface_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+//   - key string
+func (_e *IdempotencyRepositoryInterface_Expecter) Get(ctx interface{}, scope interface{}, key interface{}) *IdempotencyRepositoryInterface_Get_Call {
+	return &IdempotencyRepositoryInterface_Get_Call{Call: _e.mock.On("Get", ctx, scope, key)}
+}
+
+func (_c *IdempotencyRepositoryInterface_Get_Call) Run(run func(ctx context.Context, scope string, key string)) *IdempotencyRepositoryInterface_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_Get_Call) Return(_a0 *models.IdempotencyKey, _a1 error) *IdempotencyRepositoryInterface_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyRepositoryInterface_Get_Call) RunAndReturn(run func(context.Context, string, string) (*models.IdempotencyKey, error)) *IdempotencyRepositoryInterface_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIdempotencyRepositoryInterface creates a new instance of IdempotencyRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyRepositoryInterface {
+	mock := &IdempotencyRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
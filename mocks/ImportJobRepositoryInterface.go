@@ -0,0 +1,297 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/sarrooo/go-clean/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ImportJobRepositoryInterface is an autogenerated mock type for the ImportJobRepositoryInterface type
+type ImportJobRepositoryInterface struct {
+	mock.Mock
+}
+
+type ImportJobRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ImportJobRepositoryInterface) EXPECT() *ImportJobRepositoryInterface_Expecter {
+	return &ImportJobRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, job
+func (_m *ImportJobRepositoryInterface) Create(ctx context.Context, job *models.ImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportJobRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ImportJobRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *models.ImportJob
+func (_e *ImportJobRepositoryInterface_Expecter) Create(ctx interface{}, job interface{}) *ImportJobRepositoryInterface_Create_Call {
+	return &ImportJobRepositoryInterface_Create_Call{Call: _e.mock.On("Create", ctx, job)}
+}
+
+func (_c *ImportJobRepositoryInterface_Create_Call) Run(run func(ctx context.Context, job *models.ImportJob)) *ImportJobRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ImportJob))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_Create_Call) Return(_a0 error) *ImportJobRepositoryInterface_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_Create_Call) RunAndReturn(run func(context.Context, *models.ImportJob) error) *ImportJobRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailStale provides a mock function with given fields: ctx, before, message
+func (_m *ImportJobRepositoryInterface) FailStale(ctx context.Context, before time.Time, message string) (int64, error) {
+	ret := _m.Called(ctx, before, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailStale")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) (int64, error)); ok {
+		return rf(ctx, before, message)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) int64); ok {
+		r0 = rf(ctx, before, message)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, string) error); ok {
+		r1 = rf(ctx, before, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportJobRepositoryInterface_FailStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailStale'
+type ImportJobRepositoryInterface_FailStale_Call struct {
+	*mock.Call
+}
+
+// FailStale is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - message string
+func (_e *ImportJobRepositoryInterface_Expecter) FailStale(ctx interface{}, before interface{}, message interface{}) *ImportJobRepositoryInterface_FailStale_Call {
+	return &ImportJobRepositoryInterface_FailStale_Call{Call: _e.mock.On("FailStale", ctx, before, message)}
+}
+
+func (_c *ImportJobRepositoryInterface_FailStale_Call) Run(run func(ctx context.Context, before time.Time, message string)) *ImportJobRepositoryInterface_FailStale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_FailStale_Call) Return(_a0 int64, _a1 error) *ImportJobRepositoryInterface_FailStale_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_FailStale_Call) RunAndReturn(run func(context.Context, time.Time, string) (int64, error)) *ImportJobRepositoryInterface_FailStale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *ImportJobRepositoryInterface) Get(ctx context.Context, id uint) (*models.ImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.ImportJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportJobRepositoryInterface_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type ImportJobRepositoryInterface_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *ImportJobRepositoryInterface_Expecter) Get(ctx interface{}, id interface{}) *ImportJobRepositoryInterface_Get_Call {
+	return &ImportJobRepositoryInterface_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *ImportJobRepositoryInterface_Get_Call) Run(run func(ctx context.Context, id uint)) *ImportJobRepositoryInterface_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_Get_Call) Return(_a0 *models.ImportJob, _a1 error) *ImportJobRepositoryInterface_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_Get_Call) RunAndReturn(run func(context.Context, uint) (*models.ImportJob, error)) *ImportJobRepositoryInterface_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Heartbeat provides a mock function with given fields: ctx, id
+func (_m *ImportJobRepositoryInterface) Heartbeat(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Heartbeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportJobRepositoryInterface_Heartbeat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Heartbeat'
+type ImportJobRepositoryInterface_Heartbeat_Call struct {
+	*mock.Call
+}
+
+// Heartbeat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *ImportJobRepositoryInterface_Expecter) Heartbeat(ctx interface{}, id interface{}) *ImportJobRepositoryInterface_Heartbeat_Call {
+	return &ImportJobRepositoryInterface_Heartbeat_Call{Call: _e.mock.On("Heartbeat", ctx, id)}
+}
+
+func (_c *ImportJobRepositoryInterface_Heartbeat_Call) Run(run func(ctx context.Context, id uint)) *ImportJobRepositoryInterface_Heartbeat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_Heartbeat_Call) Return(_a0 error) *ImportJobRepositoryInterface_Heartbeat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_Heartbeat_Call) RunAndReturn(run func(context.Context, uint) error) *ImportJobRepositoryInterface_Heartbeat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, job
+func (_m *ImportJobRepositoryInterface) Update(ctx context.Context, job *models.ImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportJobRepositoryInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ImportJobRepositoryInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *models.ImportJob
+func (_e *ImportJobRepositoryInterface_Expecter) Update(ctx interface{}, job interface{}) *ImportJobRepositoryInterface_Update_Call {
+	return &ImportJobRepositoryInterface_Update_Call{Call: _e.mock.On("Update", ctx, job)}
+}
+
+func (_c *ImportJobRepositoryInterface_Update_Call) Run(run func(ctx context.Context, job *models.ImportJob)) *ImportJobRepositoryInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ImportJob))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_Update_Call) Return(_a0 error) *ImportJobRepositoryInterface_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ImportJobRepositoryInterface_Update_Call) RunAndReturn(run func(context.Context, *models.ImportJob) error) *ImportJobRepositoryInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewImportJobRepositoryInterface creates a new instance of ImportJobRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImportJobRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImportJobRepositoryInterface {
+	mock := &ImportJobRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReadinessProbe is an autogenerated mock type for the ReadinessProbe type
+type ReadinessProbe struct {
+	mock.Mock
+}
+
+type ReadinessProbe_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReadinessProbe) EXPECT() *ReadinessProbe_Expecter {
+	return &ReadinessProbe_Expecter{mock: &_m.Mock}
+}
+
+// Ready provides a mock function with given fields: ctx
+func (_m *ReadinessProbe) Ready(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReadinessProbe_Ready_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ready'
+type ReadinessProbe_Ready_Call struct {
+	*mock.Call
+}
+
+// Ready is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ReadinessProbe_Expecter) Ready(ctx interface{}) *ReadinessProbe_Ready_Call {
+	return &ReadinessProbe_Ready_Call{Call: _e.mock.On("Ready", ctx)}
+}
+
+func (_c *ReadinessProbe_Ready_Call) Run(run func(ctx context.Context)) *ReadinessProbe_Ready_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ReadinessProbe_Ready_Call) Return(_a0 error) *ReadinessProbe_Ready_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReadinessProbe_Ready_Call) RunAndReturn(run func(context.Context) error) *ReadinessProbe_Ready_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReadinessProbe creates a new instance of ReadinessProbe. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReadinessProbe(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReadinessProbe {
+	mock := &ReadinessProbe{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/sarrooo/go-clean/internal/dto"
+	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/sarrooo/go-clean/internal/pagination"
+)
+
+// SearchRepositoryInterface is an autogenerated mock type for the SearchRepositoryInterface type
+type SearchRepositoryInterface struct {
+	mock.Mock
+}
+
+type SearchRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SearchRepositoryInterface) EXPECT() *SearchRepositoryInterface_Expecter {
+	return &SearchRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function with given fields: ctx, query, params
+func (_m *SearchRepositoryInterface) Search(ctx context.Context, query *dto.SearchQuery, params *pagination.Params) (*pagination.Page[*dto.SearchResult], error) {
+	ret := _m.Called(ctx, query, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *pagination.Page[*dto.SearchResult]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.SearchQuery, *pagination.Params) (*pagination.Page[*dto.SearchResult], error)); ok {
+		return rf(ctx, query, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.SearchQuery, *pagination.Params) *pagination.Page[*dto.SearchResult]); ok {
+		r0 = rf(ctx, query, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Page[*dto.SearchResult])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.SearchQuery, *pagination.Params) error); ok {
+		r1 = rf(ctx, query, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchRepositoryInterface_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type SearchRepositoryInterface_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *dto.SearchQuery
+//   - params *pagination.Params
+func (_e *SearchRepositoryInterface_Expecter) Search(ctx interface{}, query interface{}, params interface{}) *SearchRepositoryInterface_Search_Call {
+	return &SearchRepositoryInterface_Search_Call{Call: _e.mock.On("Search", ctx, query, params)}
+}
+
+func (_c *SearchRepositoryInterface_Search_Call) Run(run func(ctx context.Context, query *dto.SearchQuery, params *pagination.Params)) *SearchRepositoryInterface_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.SearchQuery), args[2].(*pagination.Params))
+	})
+	return _c
+}
+
+func (_c *SearchRepositoryInterface_Search_Call) Return(_a0 *pagination.Page[*dto.SearchResult], _a1 error) *SearchRepositoryInterface_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SearchRepositoryInterface_Search_Call) RunAndReturn(run func(context.Context, *dto.SearchQuery, *pagination.Params) (*pagination.Page[*dto.SearchResult], error)) *SearchRepositoryInterface_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSearchRepositoryInterface creates a new instance of SearchRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSearchRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SearchRepositoryInterface {
+	mock := &SearchRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,1608 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/sarrooo/go-clean/internal/dto"
+	filter "github.com/sarrooo/go-clean/internal/filter"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sarrooo/go-clean/internal/models"
+
+	pagination "github.com/sarrooo/go-clean/internal/pagination"
+
+	time "time"
+)
+
+// ServiceInterface is an autogenerated mock type for the ServiceInterface type
+type ServiceInterface struct {
+	mock.Mock
+}
+
+type ServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ServiceInterface) EXPECT() *ServiceInterface_Expecter {
+	return &ServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// AuthenticateToken provides a mock function with given fields: ctx, tokenString
+func (_m *ServiceInterface) AuthenticateToken(ctx context.Context, tokenString string) (*models.User, error) {
+	ret := _m.Called(ctx, tokenString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateToken")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, tokenString)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, tokenString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_AuthenticateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticateToken'
+type ServiceInterface_AuthenticateToken_Call struct {
+	*mock.Call
+}
+
+// AuthenticateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenString string
+func (_e *ServiceInterface_Expecter) AuthenticateToken(ctx interface{}, tokenString interface{}) *ServiceInterface_AuthenticateToken_Call {
+	return &ServiceInterface_AuthenticateToken_Call{Call: _e.mock.On("AuthenticateToken", ctx, tokenString)}
+}
+
+func (_c *ServiceInterface_AuthenticateToken_Call) Run(run func(ctx context.Context, tokenString string)) *ServiceInterface_AuthenticateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_AuthenticateToken_Call) Return(user *models.User, err error) *ServiceInterface_AuthenticateToken_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *ServiceInterface_AuthenticateToken_Call) RunAndReturn(run func(context.Context, string) (*models.User, error)) *ServiceInterface_AuthenticateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BeginIdempotentRequest provides a mock function with given fields: ctx, request
+func (_m *ServiceInterface) BeginIdempotentRequest(ctx context.Context, request *dto.IdempotentRequest) (*dto.IdempotentResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginIdempotentRequest")
+	}
+
+	var r0 *dto.IdempotentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.IdempotentRequest) (*dto.IdempotentResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.IdempotentRequest) *dto.IdempotentResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.IdempotentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.IdempotentRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_BeginIdempotentRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginIdempotentRequest'
+type ServiceInterface_BeginIdempotentRequest_Call struct {
+	*mock.Call
+}
+
+// BeginIdempotentRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dto.IdempotentRequest
+func (_e *ServiceInterface_Expecter) BeginIdempotentRequest(ctx interface{}, request interface{}) *ServiceInterface_BeginIdempotentRequest_Call {
+	return &ServiceInterface_BeginIdempotentRequest_Call{Call: _e.mock.On("BeginIdempotentRequest", ctx, request)}
+}
+
+func (_c *ServiceInterface_BeginIdempotentRequest_Call) Run(run func(ctx context.Context, request *dto.IdempotentRequest)) *ServiceInterface_BeginIdempotentRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.IdempotentRequest))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_BeginIdempotentRequest_Call) Return(response *dto.IdempotentResponse, err error) *ServiceInterface_BeginIdempotentRequest_Call {
+	_c.Call.Return(response, err)
+	return _c
+}
+
+func (_c *ServiceInterface_BeginIdempotentRequest_Call) RunAndReturn(run func(context.Context, *dto.IdempotentRequest) (*dto.IdempotentResponse, error)) *ServiceInterface_BeginIdempotentRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkCreateArtists provides a mock function with given fields: ctx, artists, mode
+func (_m *ServiceInterface) BulkCreateArtists(ctx context.Context, artists []*models.Artist, mode string) ([]error, error) {
+	ret := _m.Called(ctx, artists, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreateArtists")
+	}
+
+	var r0 []error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Artist, string) ([]error, error)); ok {
+		return rf(ctx, artists, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Artist, string) []error); ok {
+		r0 = rf(ctx, artists, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*models.Artist, string) error); ok {
+		r1 = rf(ctx, artists, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_BulkCreateArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkCreateArtists'
+type ServiceInterface_BulkCreateArtists_Call struct {
+	*mock.Call
+}
+
+// BulkCreateArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - artists []*models.Artist
+//   - mode string
+func (_e *ServiceInterface_Expecter) BulkCreateArtists(ctx interface{}, artists interface{}, mode interface{}) *ServiceInterface_BulkCreateArtists_Call {
+	return &ServiceInterface_BulkCreateArtists_Call{Call: _e.mock.On("BulkCreateArtists", ctx, artists, mode)}
+}
+
+func (_c *ServiceInterface_BulkCreateArtists_Call) Run(run func(ctx context.Context, artists []*models.Artist, mode string)) *ServiceInterface_BulkCreateArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*models.Artist), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_BulkCreateArtists_Call) Return(errs []error, err error) *ServiceInterface_BulkCreateArtists_Call {
+	_c.Call.Return(errs, err)
+	return _c
+}
+
+func (_c *ServiceInterface_BulkCreateArtists_Call) RunAndReturn(run func(context.Context, []*models.Artist, string) ([]error, error)) *ServiceInterface_BulkCreateArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkDeleteArtists provides a mock function with given fields: ctx, deleteArtists, mode
+func (_m *ServiceInterface) BulkDeleteArtists(ctx context.Context, deleteArtists []*dto.DeleteArtist, mode string) ([]error, error) {
+	ret := _m.Called(ctx, deleteArtists, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkDeleteArtists")
+	}
+
+	var r0 []error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*dto.DeleteArtist, string) ([]error, error)); ok {
+		return rf(ctx, deleteArtists, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*dto.DeleteArtist, string) []error); ok {
+		r0 = rf(ctx, deleteArtists, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*dto.DeleteArtist, string) error); ok {
+		r1 = rf(ctx, deleteArtists, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_BulkDeleteArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkDeleteArtists'
+type ServiceInterface_BulkDeleteArtists_Call struct {
+	*mock.Call
+}
+
+// BulkDeleteArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deleteArtists []*dto.DeleteArtist
+//   - mode string
+func (_e *ServiceInterface_Expecter) BulkDeleteArtists(ctx interface{}, deleteArtists interface{}, mode interface{}) *ServiceInterface_BulkDeleteArtists_Call {
+	return &ServiceInterface_BulkDeleteArtists_Call{Call: _e.mock.On("BulkDeleteArtists", ctx, deleteArtists, mode)}
+}
+
+func (_c *ServiceInterface_BulkDeleteArtists_Call) Run(run func(ctx context.Context, deleteArtists []*dto.DeleteArtist, mode string)) *ServiceInterface_BulkDeleteArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*dto.DeleteArtist), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_BulkDeleteArtists_Call) Return(errs []error, err error) *ServiceInterface_BulkDeleteArtists_Call {
+	_c.Call.Return(errs, err)
+	return _c
+}
+
+func (_c *ServiceInterface_BulkDeleteArtists_Call) RunAndReturn(run func(context.Context, []*dto.DeleteArtist, string) ([]error, error)) *ServiceInterface_BulkDeleteArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkUpdateArtists provides a mock function with given fields: ctx, artists, mode
+func (_m *ServiceInterface) BulkUpdateArtists(ctx context.Context, artists []*models.Artist, mode string) ([]error, error) {
+	ret := _m.Called(ctx, artists, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdateArtists")
+	}
+
+	var r0 []error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Artist, string) ([]error, error)); ok {
+		return rf(ctx, artists, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Artist, string) []error); ok {
+		r0 = rf(ctx, artists, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*models.Artist, string) error); ok {
+		r1 = rf(ctx, artists, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_BulkUpdateArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkUpdateArtists'
+type ServiceInterface_BulkUpdateArtists_Call struct {
+	*mock.Call
+}
+
+// BulkUpdateArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - artists []*models.Artist
+//   - mode string
+func (_e *ServiceInterface_Expecter) BulkUpdateArtists(ctx interface{}, artists interface{}, mode interface{}) *ServiceInterface_BulkUpdateArtists_Call {
+	return &ServiceInterface_BulkUpdateArtists_Call{Call: _e.mock.On("BulkUpdateArtists", ctx, artists, mode)}
+}
+
+func (_c *ServiceInterface_BulkUpdateArtists_Call) Run(run func(ctx context.Context, artists []*models.Artist, mode string)) *ServiceInterface_BulkUpdateArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*models.Artist), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_BulkUpdateArtists_Call) Return(errs []error, err error) *ServiceInterface_BulkUpdateArtists_Call {
+	_c.Call.Return(errs, err)
+	return _c
+}
+
+func (_c *ServiceInterface_BulkUpdateArtists_Call) RunAndReturn(run func(context.Context, []*models.Artist, string) ([]error, error)) *ServiceInterface_BulkUpdateArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteIdempotentRequest provides a mock function with given fields: ctx, request, response
+func (_m *ServiceInterface) CompleteIdempotentRequest(ctx context.Context, request *dto.IdempotentRequest, response *dto.IdempotentResponse) error {
+	ret := _m.Called(ctx, request, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteIdempotentRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.IdempotentRequest, *dto.IdempotentResponse) error); ok {
+		r0 = rf(ctx, request, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceInterface_CompleteIdempotentRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteIdempotentRequest'
+type ServiceInterface_CompleteIdempotentRequest_Call struct {
+	*mock.Call
+}
+
+// CompleteIdempotentRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dto.IdempotentRequest
+//   - response *dto.IdempotentResponse
+func (_e *ServiceInterface_Expecter) CompleteIdempotentRequest(ctx interface{}, request interface{}, response interface{}) *ServiceInterface_CompleteIdempotentRequest_Call {
+	return &ServiceInterface_CompleteIdempotentRequest_Call{Call: _e.mock.On("CompleteIdempotentRequest", ctx, request, response)}
+}
+
+func (_c *ServiceInterface_CompleteIdempotentRequest_Call) Run(run func(ctx context.Context, request *dto.IdempotentRequest, response *dto.IdempotentResponse)) *ServiceInterface_CompleteIdempotentRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.IdempotentRequest), args[2].(*dto.IdempotentResponse))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_CompleteIdempotentRequest_Call) Return(err error) *ServiceInterface_CompleteIdempotentRequest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceInterface_CompleteIdempotentRequest_Call) RunAndReturn(run func(context.Context, *dto.IdempotentRequest, *dto.IdempotentResponse) error) *ServiceInterface_CompleteIdempotentRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateArtist provides a mock function with given fields: ctx, artist
+func (_m *ServiceInterface) CreateArtist(ctx context.Context, artist *models.Artist) error {
+	ret := _m.Called(ctx, artist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateArtist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Artist) error); ok {
+		r0 = rf(ctx, artist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceInterface_CreateArtist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateArtist'
+type ServiceInterface_CreateArtist_Call struct {
+	*mock.Call
+}
+
+// CreateArtist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - artist *models.Artist
+func (_e *ServiceInterface_Expecter) CreateArtist(ctx interface{}, artist interface{}) *ServiceInterface_CreateArtist_Call {
+	return &ServiceInterface_CreateArtist_Call{Call: _e.mock.On("CreateArtist", ctx, artist)}
+}
+
+func (_c *ServiceInterface_CreateArtist_Call) Run(run func(ctx context.Context, artist *models.Artist)) *ServiceInterface_CreateArtist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Artist))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_CreateArtist_Call) Return(err error) *ServiceInterface_CreateArtist_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceInterface_CreateArtist_Call) RunAndReturn(run func(context.Context, *models.Artist) error) *ServiceInterface_CreateArtist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteArtist provides a mock function with given fields: ctx, deleteArtist
+func (_m *ServiceInterface) DeleteArtist(ctx context.Context, deleteArtist *dto.DeleteArtist) error {
+	ret := _m.Called(ctx, deleteArtist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArtist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.DeleteArtist) error); ok {
+		r0 = rf(ctx, deleteArtist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceInterface_DeleteArtist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteArtist'
+type ServiceInterface_DeleteArtist_Call struct {
+	*mock.Call
+}
+
+// DeleteArtist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deleteArtist *dto.DeleteArtist
+func (_e *ServiceInterface_Expecter) DeleteArtist(ctx interface{}, deleteArtist interface{}) *ServiceInterface_DeleteArtist_Call {
+	return &ServiceInterface_DeleteArtist_Call{Call: _e.mock.On("DeleteArtist", ctx, deleteArtist)}
+}
+
+func (_c *ServiceInterface_DeleteArtist_Call) Run(run func(ctx context.Context, deleteArtist *dto.DeleteArtist)) *ServiceInterface_DeleteArtist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.DeleteArtist))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_DeleteArtist_Call) Return(err error) *ServiceInterface_DeleteArtist_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceInterface_DeleteArtist_Call) RunAndReturn(run func(context.Context, *dto.DeleteArtist) error) *ServiceInterface_DeleteArtist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportArtists provides a mock function with given fields: ctx, format, w
+func (_m *ServiceInterface) ExportArtists(ctx context.Context, format string, w io.Writer) error {
+	ret := _m.Called(ctx, format, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportArtists")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Writer) error); ok {
+		r0 = rf(ctx, format, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceInterface_ExportArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportArtists'
+type ServiceInterface_ExportArtists_Call struct {
+	*mock.Call
+}
+
+// ExportArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - format string
+//   - w io.Writer
+func (_e *ServiceInterface_Expecter) ExportArtists(ctx interface{}, format interface{}, w interface{}) *ServiceInterface_ExportArtists_Call {
+	return &ServiceInterface_ExportArtists_Call{Call: _e.mock.On("ExportArtists", ctx, format, w)}
+}
+
+func (_c *ServiceInterface_ExportArtists_Call) Run(run func(ctx context.Context, format string, w io.Writer)) *ServiceInterface_ExportArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_ExportArtists_Call) Return(err error) *ServiceInterface_ExportArtists_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceInterface_ExportArtists_Call) RunAndReturn(run func(context.Context, string, io.Writer) error) *ServiceInterface_ExportArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateToken provides a mock function with given fields: ctx, user
+func (_m *ServiceInterface) GenerateToken(ctx context.Context, user *models.User) (string, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) (string, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) string); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_GenerateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateToken'
+type ServiceInterface_GenerateToken_Call struct {
+	*mock.Call
+}
+
+// GenerateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.User
+func (_e *ServiceInterface_Expecter) GenerateToken(ctx interface{}, user interface{}) *ServiceInterface_GenerateToken_Call {
+	return &ServiceInterface_GenerateToken_Call{Call: _e.mock.On("GenerateToken", ctx, user)}
+}
+
+func (_c *ServiceInterface_GenerateToken_Call) Run(run func(ctx context.Context, user *models.User)) *ServiceInterface_GenerateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.User))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_GenerateToken_Call) Return(tokenString string, err error) *ServiceInterface_GenerateToken_Call {
+	_c.Call.Return(tokenString, err)
+	return _c
+}
+
+func (_c *ServiceInterface_GenerateToken_Call) RunAndReturn(run func(context.Context, *models.User) (string, error)) *ServiceInterface_GenerateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetArtist provides a mock function with given fields: ctx, id, preloads
+func (_m *ServiceInterface) GetArtist(ctx context.Context, id uint, preloads []string) (*models.Artist, error) {
+	ret := _m.Called(ctx, id, preloads)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtist")
+	}
+
+	var r0 *models.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) (*models.Artist, error)); ok {
+		return rf(ctx, id, preloads)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) *models.Artist); ok {
+		r0 = rf(ctx, id, preloads)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string) error); ok {
+		r1 = rf(ctx, id, preloads)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_GetArtist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetArtist'
+type ServiceInterface_GetArtist_Call struct {
+	*mock.Call
+}
+
+// GetArtist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+//   - preloads []string
+func (_e *ServiceInterface_Expecter) GetArtist(ctx interface{}, id interface{}, preloads interface{}) *ServiceInterface_GetArtist_Call {
+	return &ServiceInterface_GetArtist_Call{Call: _e.mock.On("GetArtist", ctx, id, preloads)}
+}
+
+func (_c *ServiceInterface_GetArtist_Call) Run(run func(ctx context.Context, id uint, preloads []string)) *ServiceInterface_GetArtist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_GetArtist_Call) Return(artist *models.Artist, err error) *ServiceInterface_GetArtist_Call {
+	_c.Call.Return(artist, err)
+	return _c
+}
+
+func (_c *ServiceInterface_GetArtist_Call) RunAndReturn(run func(context.Context, uint, []string) (*models.Artist, error)) *ServiceInterface_GetArtist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImportJob provides a mock function with given fields: ctx, id
+func (_m *ServiceInterface) GetImportJob(ctx context.Context, id uint) (*models.ImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImportJob")
+	}
+
+	var r0 *models.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.ImportJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_GetImportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImportJob'
+type ServiceInterface_GetImportJob_Call struct {
+	*mock.Call
+}
+
+// GetImportJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *ServiceInterface_Expecter) GetImportJob(ctx interface{}, id interface{}) *ServiceInterface_GetImportJob_Call {
+	return &ServiceInterface_GetImportJob_Call{Call: _e.mock.On("GetImportJob", ctx, id)}
+}
+
+func (_c *ServiceInterface_GetImportJob_Call) Run(run func(ctx context.Context, id uint)) *ServiceInterface_GetImportJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_GetImportJob_Call) Return(job *models.ImportJob, err error) *ServiceInterface_GetImportJob_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *ServiceInterface_GetImportJob_Call) RunAndReturn(run func(context.Context, uint) (*models.ImportJob, error)) *ServiceInterface_GetImportJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *ServiceInterface) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type ServiceInterface_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *ServiceInterface_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *ServiceInterface_GetUserByEmail_Call {
+	return &ServiceInterface_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+}
+
+func (_c *ServiceInterface_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *ServiceInterface_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_GetUserByEmail_Call) Return(user *models.User, err error) *ServiceInterface_GetUserByEmail_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *ServiceInterface_GetUserByEmail_Call) RunAndReturn(run func(context.Context, string) (*models.User, error)) *ServiceInterface_GetUserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportArtists provides a mock function with given fields: ctx, imp
+func (_m *ServiceInterface) ImportArtists(ctx context.Context, imp *dto.Import) (*dto.ImportReport, error) {
+	ret := _m.Called(ctx, imp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportArtists")
+	}
+
+	var r0 *dto.ImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.Import) (*dto.ImportReport, error)); ok {
+		return rf(ctx, imp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.Import) *dto.ImportReport); ok {
+		r0 = rf(ctx, imp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.ImportReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.Import) error); ok {
+		r1 = rf(ctx, imp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_ImportArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportArtists'
+type ServiceInterface_ImportArtists_Call struct {
+	*mock.Call
+}
+
+// ImportArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - imp *dto.Import
+func (_e *ServiceInterface_Expecter) ImportArtists(ctx interface{}, imp interface{}) *ServiceInterface_ImportArtists_Call {
+	return &ServiceInterface_ImportArtists_Call{Call: _e.mock.On("ImportArtists", ctx, imp)}
+}
+
+func (_c *ServiceInterface_ImportArtists_Call) Run(run func(ctx context.Context, imp *dto.Import)) *ServiceInterface_ImportArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.Import))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_ImportArtists_Call) Return(report *dto.ImportReport, err error) *ServiceInterface_ImportArtists_Call {
+	_c.Call.Return(report, err)
+	return _c
+}
+
+func (_c *ServiceInterface_ImportArtists_Call) RunAndReturn(run func(context.Context, *dto.Import) (*dto.ImportReport, error)) *ServiceInterface_ImportArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListArtists provides a mock function with given fields: ctx, filters, params, preloads
+func (_m *ServiceInterface) ListArtists(ctx context.Context, filters []filter.Condition, params *pagination.Params, preloads []string) (*pagination.Page[*models.Artist], error) {
+	ret := _m.Called(ctx, filters, params, preloads)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListArtists")
+	}
+
+	var r0 *pagination.Page[*models.Artist]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []filter.Condition, *pagination.Params, []string) (*pagination.Page[*models.Artist], error)); ok {
+		return rf(ctx, filters, params, preloads)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []filter.Condition, *pagination.Params, []string) *pagination.Page[*models.Artist]); ok {
+		r0 = rf(ctx, filters, params, preloads)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Page[*models.Artist])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []filter.Condition, *pagination.Params, []string) error); ok {
+		r1 = rf(ctx, filters, params, preloads)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_ListArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListArtists'
+type ServiceInterface_ListArtists_Call struct {
+	*mock.Call
+}
+
+// ListArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters []filter.Condition
+//   - params *pagination.Params
+//   - preloads []string
+func (_e *ServiceInterface_Expecter) ListArtists(ctx interface{}, filters interface{}, params interface{}, preloads interface{}) *ServiceInterface_ListArtists_Call {
+	return &ServiceInterface_ListArtists_Call{Call: _e.mock.On("ListArtists", ctx, filters, params, preloads)}
+}
+
+func (_c *ServiceInterface_ListArtists_Call) Run(run func(ctx context.Context, filters []filter.Condition, params *pagination.Params, preloads []string)) *ServiceInterface_ListArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]filter.Condition), args[2].(*pagination.Params), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_ListArtists_Call) Return(page *pagination.Page[*models.Artist], err error) *ServiceInterface_ListArtists_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *ServiceInterface_ListArtists_Call) RunAndReturn(run func(context.Context, []filter.Condition, *pagination.Params, []string) (*pagination.Page[*models.Artist], error)) *ServiceInterface_ListArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuditLogs provides a mock function with given fields: ctx, _a1
+func (_m *ServiceInterface) ListAuditLogs(ctx context.Context, _a1 *dto.AuditFilter) ([]*models.AuditLog, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditLogs")
+	}
+
+	var r0 []*models.AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuditFilter) ([]*models.AuditLog, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuditFilter) []*models.AuditLog); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AuditFilter) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_ListAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditLogs'
+type ServiceInterface_ListAuditLogs_Call struct {
+	*mock.Call
+}
+
+// ListAuditLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *dto.AuditFilter
+func (_e *ServiceInterface_Expecter) ListAuditLogs(ctx interface{}, _a1 interface{}) *ServiceInterface_ListAuditLogs_Call {
+	return &ServiceInterface_ListAuditLogs_Call{Call: _e.mock.On("ListAuditLogs", ctx, _a1)}
+}
+
+func (_c *ServiceInterface_ListAuditLogs_Call) Run(run func(ctx context.Context, _a1 *dto.AuditFilter)) *ServiceInterface_ListAuditLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_ListAuditLogs_Call) Return(logs []*models.AuditLog, err error) *ServiceInterface_ListAuditLogs_Call {
+	_c.Call.Return(logs, err)
+	return _c
+}
+
+func (_c *ServiceInterface_ListAuditLogs_Call) RunAndReturn(run func(context.Context, *dto.AuditFilter) ([]*models.AuditLog, error)) *ServiceInterface_ListAuditLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTrash provides a mock function with given fields: ctx, entity
+func (_m *ServiceInterface) ListTrash(ctx context.Context, entity string) ([]*dto.TrashItem, error) {
+	ret := _m.Called(ctx, entity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrash")
+	}
+
+	var r0 []*dto.TrashItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*dto.TrashItem, error)); ok {
+		return rf(ctx, entity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*dto.TrashItem); ok {
+		r0 = rf(ctx, entity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.TrashItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, entity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_ListTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrash'
+type ServiceInterface_ListTrash_Call struct {
+	*mock.Call
+}
+
+// ListTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity string
+func (_e *ServiceInterface_Expecter) ListTrash(ctx interface{}, entity interface{}) *ServiceInterface_ListTrash_Call {
+	return &ServiceInterface_ListTrash_Call{Call: _e.mock.On("ListTrash", ctx, entity)}
+}
+
+func (_c *ServiceInterface_ListTrash_Call) Run(run func(ctx context.Context, entity string)) *ServiceInterface_ListTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_ListTrash_Call) Return(items []*dto.TrashItem, err error) *ServiceInterface_ListTrash_Call {
+	_c.Call.Return(items, err)
+	return _c
+}
+
+func (_c *ServiceInterface_ListTrash_Call) RunAndReturn(run func(context.Context, string) ([]*dto.TrashItem, error)) *ServiceInterface_ListTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginUser provides a mock function with given fields: ctx, email, password
+func (_m *ServiceInterface) LoginUser(ctx context.Context, email string, password string) (*models.User, error) {
+	ret := _m.Called(ctx, email, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginUser")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.User, error)); ok {
+		return rf(ctx, email, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.User); ok {
+		r0 = rf(ctx, email, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_LoginUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginUser'
+type ServiceInterface_LoginUser_Call struct {
+	*mock.Call
+}
+
+// LoginUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+func (_e *ServiceInterface_Expecter) LoginUser(ctx interface{}, email interface{}, password interface{}) *ServiceInterface_LoginUser_Call {
+	return &ServiceInterface_LoginUser_Call{Call: _e.mock.On("LoginUser", ctx, email, password)}
+}
+
+func (_c *ServiceInterface_LoginUser_Call) Run(run func(ctx context.Context, email string, password string)) *ServiceInterface_LoginUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_LoginUser_Call) Return(user *models.User, err error) *ServiceInterface_LoginUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *ServiceInterface_LoginUser_Call) RunAndReturn(run func(context.Context, string, string) (*models.User, error)) *ServiceInterface_LoginUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeAuditLogs provides a mock function with given fields: ctx, before
+func (_m *ServiceInterface) PurgeAuditLogs(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeAuditLogs")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_PurgeAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeAuditLogs'
+type ServiceInterface_PurgeAuditLogs_Call struct {
+	*mock.Call
+}
+
+// PurgeAuditLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *ServiceInterface_Expecter) PurgeAuditLogs(ctx interface{}, before interface{}) *ServiceInterface_PurgeAuditLogs_Call {
+	return &ServiceInterface_PurgeAuditLogs_Call{Call: _e.mock.On("PurgeAuditLogs", ctx, before)}
+}
+
+func (_c *ServiceInterface_PurgeAuditLogs_Call) Run(run func(ctx context.Context, before time.Time)) *ServiceInterface_PurgeAuditLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_PurgeAuditLogs_Call) Return(purged int64, err error) *ServiceInterface_PurgeAuditLogs_Call {
+	_c.Call.Return(purged, err)
+	return _c
+}
+
+func (_c *ServiceInterface_PurgeAuditLogs_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *ServiceInterface_PurgeAuditLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpiredTrash provides a mock function with given fields: ctx, before
+func (_m *ServiceInterface) PurgeExpiredTrash(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredTrash")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_PurgeExpiredTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredTrash'
+type ServiceInterface_PurgeExpiredTrash_Call struct {
+	*mock.Call
+}
+
+// PurgeExpiredTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *ServiceInterface_Expecter) PurgeExpiredTrash(ctx interface{}, before interface{}) *ServiceInterface_PurgeExpiredTrash_Call {
+	return &ServiceInterface_PurgeExpiredTrash_Call{Call: _e.mock.On("PurgeExpiredTrash", ctx, before)}
+}
+
+func (_c *ServiceInterface_PurgeExpiredTrash_Call) Run(run func(ctx context.Context, before time.Time)) *ServiceInterface_PurgeExpiredTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_PurgeExpiredTrash_Call) Return(purged int, err error) *ServiceInterface_PurgeExpiredTrash_Call {
+	_c.Call.Return(purged, err)
+	return _c
+}
+
+func (_c *ServiceInterface_PurgeExpiredTrash_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *ServiceInterface_PurgeExpiredTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeIdempotencyKeys provides a mock function with given fields: ctx, before
+func (_m *ServiceInterface) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeIdempotencyKeys")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_PurgeIdempotencyKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeIdempotencyKeys'
+type ServiceInterface_PurgeIdempotencyKeys_Call struct {
+	*mock.Call
+}
+
+// PurgeIdempotencyKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *ServiceInterface_Expecter) PurgeIdempotencyKeys(ctx interface{}, before interface{}) *ServiceInterface_PurgeIdempotencyKeys_Call {
+	return &ServiceInterface_PurgeIdempotencyKeys_Call{Call: _e.mock.On("PurgeIdempotencyKeys", ctx, before)}
+}
+
+func (_c *ServiceInterface_PurgeIdempotencyKeys_Call) Run(run func(ctx context.Context, before time.Time)) *ServiceInterface_PurgeIdempotencyKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_PurgeIdempotencyKeys_Call) Return(purged int64, err error) *ServiceInterface_PurgeIdempotencyKeys_Call {
+	_c.Call.Return(purged, err)
+	return _c
+}
+
+func (_c *ServiceInterface_PurgeIdempotencyKeys_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *ServiceInterface_PurgeIdempotencyKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, entity, id
+func (_m *ServiceInterface) PurgeTrash(ctx context.Context, entity string, id uint) error {
+	ret := _m.Called(ctx, entity, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, entity, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceInterface_PurgeTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTrash'
+type ServiceInterface_PurgeTrash_Call struct {
+	*mock.Call
+}
+
+// PurgeTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity string
+//   - id uint
+func (_e *ServiceInterface_Expecter) PurgeTrash(ctx interface{}, entity interface{}, id interface{}) *ServiceInterface_PurgeTrash_Call {
+	return &ServiceInterface_PurgeTrash_Call{Call: _e.mock.On("PurgeTrash", ctx, entity, id)}
+}
+
+func (_c *ServiceInterface_PurgeTrash_Call) Run(run func(ctx context.Context, entity string, id uint)) *ServiceInterface_PurgeTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_PurgeTrash_Call) Return(err error) *ServiceInterface_PurgeTrash_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceInterface_PurgeTrash_Call) RunAndReturn(run func(context.Context, string, uint) error) *ServiceInterface_PurgeTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterUser provides a mock function with given fields: ctx, registerUser
+func (_m *ServiceInterface) RegisterUser(ctx context.Context, registerUser *dto.RegisterUser) (*models.User, error) {
+	ret := _m.Called(ctx, registerUser)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterUser")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.RegisterUser) (*models.User, error)); ok {
+		return rf(ctx, registerUser)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.RegisterUser) *models.User); ok {
+		r0 = rf(ctx, registerUser)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.RegisterUser) error); ok {
+		r1 = rf(ctx, registerUser)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_RegisterUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterUser'
+type ServiceInterface_RegisterUser_Call struct {
+	*mock.Call
+}
+
+// RegisterUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registerUser *dto.RegisterUser
+func (_e *ServiceInterface_Expecter) RegisterUser(ctx interface{}, registerUser interface{}) *ServiceInterface_RegisterUser_Call {
+	return &ServiceInterface_RegisterUser_Call{Call: _e.mock.On("RegisterUser", ctx, registerUser)}
+}
+
+func (_c *ServiceInterface_RegisterUser_Call) Run(run func(ctx context.Context, registerUser *dto.RegisterUser)) *ServiceInterface_RegisterUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.RegisterUser))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_RegisterUser_Call) Return(user *models.User, err error) *ServiceInterface_RegisterUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *ServiceInterface_RegisterUser_Call) RunAndReturn(run func(context.Context, *dto.RegisterUser) (*models.User, error)) *ServiceInterface_RegisterUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseIdempotentRequest provides a mock function with given fields: ctx, request
+func (_m *ServiceInterface) ReleaseIdempotentRequest(ctx context.Context, request *dto.IdempotentRequest) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseIdempotentRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.IdempotentRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceInterface_ReleaseIdempotentRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseIdempotentRequest'
+type ServiceInterface_ReleaseIdempotentRequest_Call struct {
+	*mock.Call
+}
+
+// ReleaseIdempotentRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dto.IdempotentRequest
+func (_e *ServiceInterface_Expecter) ReleaseIdempotentRequest(ctx interface{}, request interface{}) *ServiceInterface_ReleaseIdempotentRequest_Call {
+	return &ServiceInterface_ReleaseIdempotentRequest_Call{Call: _e.mock.On("ReleaseIdempotentRequest", ctx, request)}
+}
+
+func (_c *ServiceInterface_ReleaseIdempotentRequest_Call) Run(run func(ctx context.Context, request *dto.IdempotentRequest)) *ServiceInterface_ReleaseIdempotentRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.IdempotentRequest))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_ReleaseIdempotentRequest_Call) Return(err error) *ServiceInterface_ReleaseIdempotentRequest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceInterface_ReleaseIdempotentRequest_Call) RunAndReturn(run func(context.Context, *dto.IdempotentRequest) error) *ServiceInterface_ReleaseIdempotentRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTrash provides a mock function with given fields: ctx, entity, id
+func (_m *ServiceInterface) RestoreTrash(ctx context.Context, entity string, id uint) error {
+	ret := _m.Called(ctx, entity, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTrash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, entity, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceInterface_RestoreTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTrash'
+type ServiceInterface_RestoreTrash_Call struct {
+	*mock.Call
+}
+
+// RestoreTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity string
+//   - id uint
+func (_e *ServiceInterface_Expecter) RestoreTrash(ctx interface{}, entity interface{}, id interface{}) *ServiceInterface_RestoreTrash_Call {
+	return &ServiceInterface_RestoreTrash_Call{Call: _e.mock.On("RestoreTrash", ctx, entity, id)}
+}
+
+func (_c *ServiceInterface_RestoreTrash_Call) Run(run func(ctx context.Context, entity string, id uint)) *ServiceInterface_RestoreTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_RestoreTrash_Call) Return(err error) *ServiceInterface_RestoreTrash_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceInterface_RestoreTrash_Call) RunAndReturn(run func(context.Context, string, uint) error) *ServiceInterface_RestoreTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, text, types, params
+func (_m *ServiceInterface) Search(ctx context.Context, text string, types []string, params *pagination.Params) (*pagination.Page[*dto.SearchResult], error) {
+	ret := _m.Called(ctx, text, types, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *pagination.Page[*dto.SearchResult]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *pagination.Params) (*pagination.Page[*dto.SearchResult], error)); ok {
+		return rf(ctx, text, types, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *pagination.Params) *pagination.Page[*dto.SearchResult]); ok {
+		r0 = rf(ctx, text, types, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagination.Page[*dto.SearchResult])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, *pagination.Params) error); ok {
+		r1 = rf(ctx, text, types, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type ServiceInterface_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - text string
+//   - types []string
+//   - params *pagination.Params
+func (_e *ServiceInterface_Expecter) Search(ctx interface{}, text interface{}, types interface{}, params interface{}) *ServiceInterface_Search_Call {
+	return &ServiceInterface_Search_Call{Call: _e.mock.On("Search", ctx, text, types, params)}
+}
+
+func (_c *ServiceInterface_Search_Call) Run(run func(ctx context.Context, text string, types []string, params *pagination.Params)) *ServiceInterface_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].(*pagination.Params))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_Search_Call) Return(page *pagination.Page[*dto.SearchResult], err error) *ServiceInterface_Search_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *ServiceInterface_Search_Call) RunAndReturn(run func(context.Context, string, []string, *pagination.Params) (*pagination.Page[*dto.SearchResult], error)) *ServiceInterface_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartImportArtists provides a mock function with given fields: ctx, imp
+func (_m *ServiceInterface) StartImportArtists(ctx context.Context, imp *dto.Import) (*models.ImportJob, error) {
+	ret := _m.Called(ctx, imp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartImportArtists")
+	}
+
+	var r0 *models.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.Import) (*models.ImportJob, error)); ok {
+		return rf(ctx, imp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.Import) *models.ImportJob); ok {
+		r0 = rf(ctx, imp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.Import) error); ok {
+		r1 = rf(ctx, imp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceInterface_StartImportArtists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartImportArtists'
+type ServiceInterface_StartImportArtists_Call struct {
+	*mock.Call
+}
+
+// StartImportArtists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - imp *dto.Import
+func (_e *ServiceInterface_Expecter) StartImportArtists(ctx interface{}, imp interface{}) *ServiceInterface_StartImportArtists_Call {
+	return &ServiceInterface_StartImportArtists_Call{Call: _e.mock.On("StartImportArtists", ctx, imp)}
+}
+
+func (_c *ServiceInterface_StartImportArtists_Call) Run(run func(ctx context.Context, imp *dto.Import)) *ServiceInterface_StartImportArtists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.Import))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_StartImportArtists_Call) Return(job *models.ImportJob, err error) *ServiceInterface_StartImportArtists_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *ServiceInterface_StartImportArtists_Call) RunAndReturn(run func(context.Context, *dto.Import) (*models.ImportJob, error)) *ServiceInterface_StartImportArtists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateArtist provides a mock function with given fields: ctx, artist
+func (_m *ServiceInterface) UpdateArtist(ctx context.Context, artist *models.Artist) error {
+	ret := _m.Called(ctx, artist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateArtist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Artist) error); ok {
+		r0 = rf(ctx, artist)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceInterface_UpdateArtist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateArtist'
+type ServiceInterface_UpdateArtist_Call struct {
+	*mock.Call
+}
+
+// UpdateArtist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - artist *models.Artist
+func (_e *ServiceInterface_Expecter) UpdateArtist(ctx interface{}, artist interface{}) *ServiceInterface_UpdateArtist_Call {
+	return &ServiceInterface_UpdateArtist_Call{Call: _e.mock.On("UpdateArtist", ctx, artist)}
+}
+
+func (_c *ServiceInterface_UpdateArtist_Call) Run(run func(ctx context.Context, artist *models.Artist)) *ServiceInterface_UpdateArtist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Artist))
+	})
+	return _c
+}
+
+func (_c *ServiceInterface_UpdateArtist_Call) Return(err error) *ServiceInterface_UpdateArtist_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceInterface_UpdateArtist_Call) RunAndReturn(run func(context.Context, *models.Artist) error) *ServiceInterface_UpdateArtist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewServiceInterface creates a new instance of ServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ServiceInterface {
+	mock := &ServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
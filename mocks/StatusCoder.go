@@ -0,0 +1,79 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// StatusCoder is an autogenerated mock type for the StatusCoder type
+type StatusCoder struct {
+	mock.Mock
+}
+
+type StatusCoder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StatusCoder) EXPECT() *StatusCoder_Expecter {
+	return &StatusCoder_Expecter{mock: &_m.Mock}
+}
+
+// StatusCodes provides a mock function with no fields
+func (_m *StatusCoder) StatusCodes() []int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for StatusCodes")
+	}
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func() []int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	return r0
+}
+
+// StatusCoder_StatusCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StatusCodes'
+type StatusCoder_StatusCodes_Call struct {
+	*mock.Call
+}
+
+// StatusCodes is a helper method to define mock.On call
+func (_e *StatusCoder_Expecter) StatusCodes() *StatusCoder_StatusCodes_Call {
+	return &StatusCoder_StatusCodes_Call{Call: _e.mock.On("StatusCodes")}
+}
+
+func (_c *StatusCoder_StatusCodes_Call) Run(run func()) *StatusCoder_StatusCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StatusCoder_StatusCodes_Call) Return(_a0 []int) *StatusCoder_StatusCodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StatusCoder_StatusCodes_Call) RunAndReturn(run func() []int) *StatusCoder_StatusCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewStatusCoder creates a new instance of StatusCoder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStatusCoder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StatusCoder {
+	mock := &StatusCoder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	repositories "github.com/sarrooo/go-clean/internal/repositories"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionManagerInterface is an autogenerated mock type for the TransactionManagerInterface type
+type TransactionManagerInterface struct {
+	mock.Mock
+}
+
+type TransactionManagerInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TransactionManagerInterface) EXPECT() *TransactionManagerInterface_Expecter {
+	return &TransactionManagerInterface_Expecter{mock: &_m.Mock}
+}
+
+// WithinTx provides a mock function with given fields: ctx, fn
+func (_m *TransactionManagerInterface) WithinTx(ctx context.Context, fn func(*repositories.GlobalRepository) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithinTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*repositories.GlobalRepository) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TransactionManagerInterface_WithinTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithinTx'
+type TransactionManagerInterface_WithinTx_Call struct {
+	*mock.Call
+}
+
+// WithinTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*repositories.GlobalRepository) error
+func (_e *TransactionManagerInterface_Expecter) WithinTx(ctx interface{}, fn interface{}) *TransactionManagerInterface_WithinTx_Call {
+	return &TransactionManagerInterface_WithinTx_Call{Call: _e.mock.On("WithinTx", ctx, fn)}
+}
+
+func (_c *TransactionManagerInterface_WithinTx_Call) Run(run func(ctx context.Context, fn func(*repositories.GlobalRepository) error)) *TransactionManagerInterface_WithinTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*repositories.GlobalRepository) error))
+	})
+	return _c
+}
+
+func (_c *TransactionManagerInterface_WithinTx_Call) Return(_a0 error) *TransactionManagerInterface_WithinTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TransactionManagerInterface_WithinTx_Call) RunAndReturn(run func(context.Context, func(*repositories.GlobalRepository) error) error) *TransactionManagerInterface_WithinTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTransactionManagerInterface creates a new instance of TransactionManagerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTransactionManagerInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TransactionManagerInterface {
+	mock := &TransactionManagerInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,254 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/sarrooo/go-clean/internal/dto"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TrashRepositoryInterface is an autogenerated mock type for the TrashRepositoryInterface type
+type TrashRepositoryInterface struct {
+	mock.Mock
+}
+
+type TrashRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TrashRepositoryInterface) EXPECT() *TrashRepositoryInterface_Expecter {
+	return &TrashRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, entity
+func (_m *TrashRepositoryInterface) List(ctx context.Context, entity string) ([]*dto.TrashItem, error) {
+	ret := _m.Called(ctx, entity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*dto.TrashItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*dto.TrashItem, error)); ok {
+		return rf(ctx, entity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*dto.TrashItem); ok {
+		r0 = rf(ctx, entity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.TrashItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, entity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TrashRepositoryInterface_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type TrashRepositoryInterface_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity string
+func (_e *TrashRepositoryInterface_Expecter) List(ctx interface{}, entity interface{}) *TrashRepositoryInterface_List_Call {
+	return &TrashRepositoryInterface_List_Call{Call: _e.mock.On("List", ctx, entity)}
+}
+
+func (_c *TrashRepositoryInterface_List_Call) Run(run func(ctx context.Context, entity string)) *TrashRepositoryInterface_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TrashRepositoryInterface_List_Call) Return(_a0 []*dto.TrashItem, _a1 error) *TrashRepositoryInterface_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TrashRepositoryInterface_List_Call) RunAndReturn(run func(context.Context, string) ([]*dto.TrashItem, error)) *TrashRepositoryInterface_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeletedBefore provides a mock function with given fields: ctx, entity, before
+func (_m *TrashRepositoryInterface) ListDeletedBefore(ctx context.Context, entity string, before time.Time) ([]uint, error) {
+	ret := _m.Called(ctx, entity, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeletedBefore")
+	}
+
+	var r0 []uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]uint, error)); ok {
+		return rf(ctx, entity, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []uint); ok {
+		r0 = rf(ctx, entity, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, entity, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TrashRepositoryInterface_ListDeletedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeletedBefore'
+type TrashRepositoryInterface_ListDeletedBefore_Call struct {
+	*mock.Call
+}
+
+// ListDeletedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity string
+//   - before time.Time
+func (_e *TrashRepositoryInterface_Expecter) ListDeletedBefore(ctx interface{}, entity interface{}, before interface{}) *TrashRepositoryInterface_ListDeletedBefore_Call {
+	return &TrashRepositoryInterface_ListDeletedBefore_Call{Call: _e.mock.On("ListDeletedBefore", ctx, entity, before)}
+}
+
+func (_c *TrashRepositoryInterface_ListDeletedBefore_Call) Run(run func(ctx context.Context, entity string, before time.Time)) *TrashRepositoryInterface_ListDeletedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TrashRepositoryInterface_ListDeletedBefore_Call) Return(_a0 []uint, _a1 error) *TrashRepositoryInterface_ListDeletedBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TrashRepositoryInterface_ListDeletedBefore_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]uint, error)) *TrashRepositoryInterface_ListDeletedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function with given fields: ctx, entity, id
+func (_m *TrashRepositoryInterface) Purge(ctx context.Context, entity string, id uint) error {
+	ret := _m.Called(ctx, entity, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, entity, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TrashRepositoryInterface_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type TrashRepositoryInterface_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity string
+//   - id uint
+func (_e *TrashRepositoryInterface_Expecter) Purge(ctx interface{}, entity interface{}, id interface{}) *TrashRepositoryInterface_Purge_Call {
+	return &TrashRepositoryInterface_Purge_Call{Call: _e.mock.On("Purge", ctx, entity, id)}
+}
+
+func (_c *TrashRepositoryInterface_Purge_Call) Run(run func(ctx context.Context, entity string, id uint)) *TrashRepositoryInterface_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *TrashRepositoryInterface_Purge_Call) Return(_a0 error) *TrashRepositoryInterface_Purge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TrashRepositoryInterface_Purge_Call) RunAndReturn(run func(context.Context, string, uint) error) *TrashRepositoryInterface_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, entity, id
+func (_m *TrashRepositoryInterface) Restore(ctx context.Context, entity string, id uint) error {
+	ret := _m.Called(ctx, entity, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, entity, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TrashRepositoryInterface_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type TrashRepositoryInterface_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity string
+//   - id uint
+func (_e *TrashRepositoryInterface_Expecter) Restore(ctx interface{}, entity interface{}, id interface{}) *TrashRepositoryInterface_Restore_Call {
+	return &TrashRepositoryInterface_Restore_Call{Call: _e.mock.On("Restore", ctx, entity, id)}
+}
+
+func (_c *TrashRepositoryInterface_Restore_Call) Run(run func(ctx context.Context, entity string, id uint)) *TrashRepositoryInterface_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *TrashRepositoryInterface_Restore_Call) Return(_a0 error) *TrashRepositoryInterface_Restore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TrashRepositoryInterface_Restore_Call) RunAndReturn(run func(context.Context, string, uint) error) *TrashRepositoryInterface_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTrashRepositoryInterface creates a new instance of TrashRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTrashRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TrashRepositoryInterface {
+	mock := &TrashRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,249 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/sarrooo/go-clean/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserRepositoryInterface is an autogenerated mock type for the UserRepositoryInterface type
+type UserRepositoryInterface struct {
+	mock.Mock
+}
+
+type UserRepositoryInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserRepositoryInterface) EXPECT() *UserRepositoryInterface_Expecter {
+	return &UserRepositoryInterface_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, user
+func (_m *UserRepositoryInterface) Create(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepositoryInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type UserRepositoryInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.User
+func (_e *UserRepositoryInterface_Expecter) Create(ctx interface{}, user interface{}) *UserRepositoryInterface_Create_Call {
+	return &UserRepositoryInterface_Create_Call{Call: _e.mock.On("Create", ctx, user)}
+}
+
+func (_c *UserRepositoryInterface_Create_Call) Run(run func(ctx context.Context, user *models.User)) *UserRepositoryInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.User))
+	})
+	return _c
+}
+
+func (_c *UserRepositoryInterface_Create_Call) Return(err error) *UserRepositoryInterface_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepositoryInterface_Create_Call) RunAndReturn(run func(context.Context, *models.User) error) *UserRepositoryInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByEmail provides a mock function with given fields: ctx, email
+func (_m *UserRepositoryInterface) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEmail")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepositoryInterface_GetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEmail'
+type UserRepositoryInterface_GetByEmail_Call struct {
+	*mock.Call
+}
+
+// GetByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *UserRepositoryInterface_Expecter) GetByEmail(ctx interface{}, email interface{}) *UserRepositoryInterface_GetByEmail_Call {
+	return &UserRepositoryInterface_GetByEmail_Call{Call: _e.mock.On("GetByEmail", ctx, email)}
+}
+
+func (_c *UserRepositoryInterface_GetByEmail_Call) Run(run func(ctx context.Context, email string)) *UserRepositoryInterface_GetByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepositoryInterface_GetByEmail_Call) Return(user *models.User, err error) *UserRepositoryInterface_GetByEmail_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *UserRepositoryInterface_GetByEmail_Call) RunAndReturn(run func(context.Context, string) (*models.User, error)) *UserRepositoryInterface_GetByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *UserRepositoryInterface) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepositoryInterface_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type UserRepositoryInterface_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *UserRepositoryInterface_Expecter) GetByID(ctx interface{}, id interface{}) *UserRepositoryInterface_GetByID_Call {
+	return &UserRepositoryInterface_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *UserRepositoryInterface_GetByID_Call) Run(run func(ctx context.Context, id uint)) *UserRepositoryInterface_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *UserRepositoryInterface_GetByID_Call) Return(user *models.User, err error) *UserRepositoryInterface_GetByID_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *UserRepositoryInterface_GetByID_Call) RunAndReturn(run func(context.Context, uint) (*models.User, error)) *UserRepositoryInterface_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateColumns provides a mock function with given fields: ctx, user
+func (_m *UserRepositoryInterface) UpdateColumns(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateColumns")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepositoryInterface_UpdateColumns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateColumns'
+type UserRepositoryInterface_UpdateColumns_Call struct {
+	*mock.Call
+}
+
+// UpdateColumns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.User
+func (_e *UserRepositoryInterface_Expecter) UpdateColumns(ctx interface{}, user interface{}) *UserRepositoryInterface_UpdateColumns_Call {
+	return &UserRepositoryInterface_UpdateColumns_Call{Call: _e.mock.On("UpdateColumns", ctx, user)}
+}
+
+func (_c *UserRepositoryInterface_UpdateColumns_Call) Run(run func(ctx context.Context, user *models.User)) *UserRepositoryInterface_UpdateColumns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.User))
+	})
+	return _c
+}
+
+func (_c *UserRepositoryInterface_UpdateColumns_Call) Return(err error) *UserRepositoryInterface_UpdateColumns_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepositoryInterface_UpdateColumns_Call) RunAndReturn(run func(context.Context, *models.User) error) *UserRepositoryInterface_UpdateColumns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserRepositoryInterface creates a new instance of UserRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserRepositoryInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserRepositoryInterface {
+	mock := &UserRepositoryInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,132 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// Writer is an autogenerated mock type for the Writer type
+type Writer struct {
+	mock.Mock
+}
+
+type Writer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Writer) EXPECT() *Writer_Expecter {
+	return &Writer_Expecter{mock: &_m.Mock}
+}
+
+// Flush provides a mock function with no fields
+func (_m *Writer) Flush() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Writer_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type Writer_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+func (_e *Writer_Expecter) Flush() *Writer_Flush_Call {
+	return &Writer_Flush_Call{Call: _e.mock.On("Flush")}
+}
+
+func (_c *Writer_Flush_Call) Run(run func()) *Writer_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Writer_Flush_Call) Return(_a0 error) *Writer_Flush_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Writer_Flush_Call) RunAndReturn(run func() error) *Writer_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Write provides a mock function with given fields: values
+func (_m *Writer) Write(values ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, values...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...interface{}) error); ok {
+		r0 = rf(values...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Writer_Write_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Write'
+type Writer_Write_Call struct {
+	*mock.Call
+}
+
+// Write is a helper method to define mock.On call
+//   - values ...interface{}
+func (_e *Writer_Expecter) Write(values ...interface{}) *Writer_Write_Call {
+	return &Writer_Write_Call{Call: _e.mock.On("Write",
+		append([]interface{}{}, values...)...)}
+}
+
+func (_c *Writer_Write_Call) Run(run func(values ...interface{})) *Writer_Write_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Writer_Write_Call) Return(_a0 error) *Writer_Write_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Writer_Write_Call) RunAndReturn(run func(...interface{}) error) *Writer_Write_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWriter creates a new instance of Writer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Writer {
+	mock := &Writer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}